@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/admin"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+)
+
+// GoalComment is a comment in the discussion thread of a goal
+type GoalComment struct {
+	gorm.Model
+	Goal        RetrospectiveFeedback
+	GoalID      uint   `gorm:"not null"`
+	Text        string `gorm:"type:text; not null"`
+	CreatedBy   userModels.User
+	CreatedByID uint `gorm:"not null"`
+}
+
+// Validate ...
+func (comment *GoalComment) Validate(db *gorm.DB) (err error) {
+	if strings.TrimSpace(comment.Text) == "" {
+		return errors.New("comment can not be empty")
+	}
+	return
+}
+
+// BeforeSave ...
+func (comment *GoalComment) BeforeSave(db *gorm.DB) (err error) {
+	return comment.Validate(db)
+}
+
+// BeforeUpdate ...
+func (comment *GoalComment) BeforeUpdate(db *gorm.DB) (err error) {
+	return comment.Validate(db)
+}
+
+// RegisterGoalCommentToAdmin ...
+func RegisterGoalCommentToAdmin(Admin *admin.Admin, config admin.Config) {
+	comment := Admin.AddResource(&GoalComment{}, &config)
+	createdByMeta := userModels.GetUserFieldMeta("CreatedBy")
+
+	comment.Meta(&createdByMeta)
+}
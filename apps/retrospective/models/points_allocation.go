@@ -0,0 +1,152 @@
+package models
+
+import "strings"
+
+// PointsAllocationStrategyValues ...
+var PointsAllocationStrategyValues = [...]string{
+	"Proportional To Time",
+	"Role Weighted",
+	"Equal Split",
+	"Assignee Takes All",
+}
+
+// PointsAllocationStrategy is the way the points of a done task are split among the members who worked on it
+type PointsAllocationStrategy int8
+
+// GetStringValue ...
+func (strategy PointsAllocationStrategy) GetStringValue() string {
+	return PointsAllocationStrategyValues[strategy]
+}
+
+// PointsAllocationStrategy
+const (
+	ProportionalToTimeAllocation PointsAllocationStrategy = iota
+	RoleWeightedAllocation
+	EqualSplitAllocation
+	AssigneeTakesAllAllocation
+)
+
+// DefaultReviewerPointsWeight is the fraction of the points a reviewer earns for the time a developer would earn
+// the full points for, with the role weighted allocation
+const DefaultReviewerPointsWeight = 0.5
+
+// PointsAllocationShare is a sprint member task sharing the points of a task
+type PointsAllocationShare struct {
+	ID               uint
+	Role             MemberTaskRole
+	TimeSpentMinutes uint
+	MemberName       string
+}
+
+// PointsAllocationTask is a task whose remaining points are to be split among its sprint member tasks,
+// Assignee is the assignee of the task in the task tracker
+type PointsAllocationTask struct {
+	Points   float64
+	Assignee string
+	Shares   []PointsAllocationShare
+}
+
+// PointsAllocator splits the points of a task among its sprint member tasks,
+// it returns the points allocated to each sprint member task by its ID
+type PointsAllocator interface {
+	Allocate(task PointsAllocationTask) map[uint]float64
+}
+
+// GetPointsAllocator returns the allocator of the strategy, the proportional to time allocator is the fallback
+func GetPointsAllocator(strategy PointsAllocationStrategy, reviewerPointsWeight float64) PointsAllocator {
+	switch strategy {
+	case RoleWeightedAllocation:
+		return RoleWeightedAllocator{ReviewerWeight: reviewerPointsWeight}
+	case EqualSplitAllocation:
+		return EqualSplitAllocator{}
+	case AssigneeTakesAllAllocation:
+		return AssigneeTakesAllAllocator{}
+	default:
+		return ProportionalToTimeAllocator{}
+	}
+}
+
+// ProportionalToTimeAllocator splits the points proportionally to the time spent on the task
+type ProportionalToTimeAllocator struct{}
+
+// Allocate ...
+func (allocator ProportionalToTimeAllocator) Allocate(task PointsAllocationTask) map[uint]float64 {
+	return allocateByWeight(task, func(share PointsAllocationShare) float64 {
+		return float64(share.TimeSpentMinutes)
+	})
+}
+
+// RoleWeightedAllocator splits the points proportionally to the time spent on the task,
+// the time of the reviewers is weighted by ReviewerWeight
+type RoleWeightedAllocator struct {
+	ReviewerWeight float64
+}
+
+// Allocate ...
+func (allocator RoleWeightedAllocator) Allocate(task PointsAllocationTask) map[uint]float64 {
+	return allocateByWeight(task, func(share PointsAllocationShare) float64 {
+		if share.Role == Reviewer {
+			return float64(share.TimeSpentMinutes) * allocator.ReviewerWeight
+		}
+		return float64(share.TimeSpentMinutes)
+	})
+}
+
+// EqualSplitAllocator splits the points equally, irrespective of the time spent on the task
+type EqualSplitAllocator struct{}
+
+// Allocate ...
+func (allocator EqualSplitAllocator) Allocate(task PointsAllocationTask) map[uint]float64 {
+	return allocateByWeight(task, func(share PointsAllocationShare) float64 {
+		return 1
+	})
+}
+
+// AssigneeTakesAllAllocator gives all the points to the assignees of the task, the points are split by time
+// when none of the members is an assignee of the task
+type AssigneeTakesAllAllocator struct{}
+
+// Allocate ...
+func (allocator AssigneeTakesAllAllocator) Allocate(task PointsAllocationTask) map[uint]float64 {
+	// A task can have multiple comma separated assignees, i.e. the owners of a Pivotal Tracker story
+	assignees := make(map[string]bool)
+	for _, assignee := range strings.Split(task.Assignee, ",") {
+		if assignee = strings.ToLower(strings.TrimSpace(assignee)); assignee != "" {
+			assignees[assignee] = true
+		}
+	}
+
+	isAssignee := func(share PointsAllocationShare) bool {
+		return assignees[strings.ToLower(strings.TrimSpace(share.MemberName))]
+	}
+
+	for _, share := range task.Shares {
+		if isAssignee(share) {
+			return allocateByWeight(task, func(share PointsAllocationShare) float64 {
+				if isAssignee(share) {
+					return 1
+				}
+				return 0
+			})
+		}
+	}
+	return ProportionalToTimeAllocator{}.Allocate(task)
+}
+
+// allocateByWeight splits the points proportionally to the weights of the shares,
+// nothing is allocated when all the weights are zero
+func allocateByWeight(task PointsAllocationTask, weight func(share PointsAllocationShare) float64) map[uint]float64 {
+	allocation := make(map[uint]float64)
+	totalWeight := float64(0)
+	for _, share := range task.Shares {
+		totalWeight += weight(share)
+	}
+	for _, share := range task.Shares {
+		if totalWeight == 0 {
+			allocation[share.ID] = 0
+			continue
+		}
+		allocation[share.ID] = weight(share) / totalWeight * task.Points
+	}
+	return allocation
+}
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+// pointsAllocationFixture is a task worked upon by a developer, a reviewer and a developer who logged no time
+var pointsAllocationFixture = PointsAllocationTask{
+	Points:   10,
+	Assignee: "Jane Doe",
+	Shares: []PointsAllocationShare{
+		{ID: 1, Role: Developer, TimeSpentMinutes: 300, MemberName: "Jane Doe"},
+		{ID: 2, Role: Reviewer, TimeSpentMinutes: 100, MemberName: "John Roe"},
+		{ID: 3, Role: Developer, TimeSpentMinutes: 0, MemberName: "Max Moe"},
+	},
+}
+
+func assertAllocation(t *testing.T, expected map[uint]float64, allocation map[uint]float64) {
+	if len(expected) != len(allocation) {
+		t.Fatalf("Allocation should have %d shares, got %d - %v", len(expected), len(allocation), allocation)
+	}
+	for id, points := range expected {
+		if math.Abs(allocation[id]-points) > 1e-9 {
+			t.Fatalf("Share %d should be allocated %v points, got %v - %v", id, points, allocation[id], allocation)
+		}
+	}
+}
+
+func TestProportionalToTimeAllocator(t *testing.T) {
+	allocation := ProportionalToTimeAllocator{}.Allocate(pointsAllocationFixture)
+	assertAllocation(t, map[uint]float64{1: 7.5, 2: 2.5, 3: 0}, allocation)
+}
+
+func TestProportionalToTimeAllocatorWithoutTime(t *testing.T) {
+	task := PointsAllocationTask{
+		Points: 5,
+		Shares: []PointsAllocationShare{{ID: 1}, {ID: 2, Role: Reviewer}},
+	}
+	allocation := ProportionalToTimeAllocator{}.Allocate(task)
+	assertAllocation(t, map[uint]float64{1: 0, 2: 0}, allocation)
+}
+
+func TestRoleWeightedAllocator(t *testing.T) {
+	allocation := RoleWeightedAllocator{ReviewerWeight: 0.5}.Allocate(pointsAllocationFixture)
+	// The reviewer's 100 minutes count as 50, i.e. 300:50 of the 10 points
+	assertAllocation(t, map[uint]float64{1: 60.0 / 7, 2: 10.0 / 7, 3: 0}, allocation)
+}
+
+func TestRoleWeightedAllocatorWithoutReviewerWeight(t *testing.T) {
+	allocation := RoleWeightedAllocator{ReviewerWeight: 0}.Allocate(pointsAllocationFixture)
+	assertAllocation(t, map[uint]float64{1: 10, 2: 0, 3: 0}, allocation)
+}
+
+func TestRoleWeightedAllocatorWithFullReviewerWeight(t *testing.T) {
+	allocation := RoleWeightedAllocator{ReviewerWeight: 1}.Allocate(pointsAllocationFixture)
+	assertAllocation(t, ProportionalToTimeAllocator{}.Allocate(pointsAllocationFixture), allocation)
+}
+
+func TestEqualSplitAllocator(t *testing.T) {
+	allocation := EqualSplitAllocator{}.Allocate(pointsAllocationFixture)
+	assertAllocation(t, map[uint]float64{1: 10.0 / 3, 2: 10.0 / 3, 3: 10.0 / 3}, allocation)
+}
+
+func TestEqualSplitAllocatorWithoutShares(t *testing.T) {
+	allocation := EqualSplitAllocator{}.Allocate(PointsAllocationTask{Points: 5})
+	assertAllocation(t, map[uint]float64{}, allocation)
+}
+
+func TestAssigneeTakesAllAllocator(t *testing.T) {
+	allocation := AssigneeTakesAllAllocator{}.Allocate(pointsAllocationFixture)
+	assertAllocation(t, map[uint]float64{1: 10, 2: 0, 3: 0}, allocation)
+}
+
+func TestAssigneeTakesAllAllocatorWithMultipleAssignees(t *testing.T) {
+	task := pointsAllocationFixture
+	task.Assignee = "jane doe, Max Moe"
+	allocation := AssigneeTakesAllAllocator{}.Allocate(task)
+	assertAllocation(t, map[uint]float64{1: 5, 2: 0, 3: 5}, allocation)
+}
+
+func TestAssigneeTakesAllAllocatorWithoutAssignee(t *testing.T) {
+	task := pointsAllocationFixture
+	task.Assignee = "Someone Else"
+	allocation := AssigneeTakesAllAllocator{}.Allocate(task)
+	assertAllocation(t, ProportionalToTimeAllocator{}.Allocate(pointsAllocationFixture), allocation)
+}
+
+func TestGetPointsAllocator(t *testing.T) {
+	if _, ok := GetPointsAllocator(ProportionalToTimeAllocation, 0.5).(ProportionalToTimeAllocator); !ok {
+		t.Fatalf("Proportional to time strategy should return the proportional to time allocator")
+	}
+	if allocator, ok := GetPointsAllocator(RoleWeightedAllocation, 0.3).(RoleWeightedAllocator); !ok ||
+		allocator.ReviewerWeight != 0.3 {
+		t.Fatalf("Role weighted strategy should return the role weighted allocator with the reviewer weight")
+	}
+	if _, ok := GetPointsAllocator(EqualSplitAllocation, 0.5).(EqualSplitAllocator); !ok {
+		t.Fatalf("Equal split strategy should return the equal split allocator")
+	}
+	if _, ok := GetPointsAllocator(AssigneeTakesAllAllocation, 0.5).(AssigneeTakesAllAllocator); !ok {
+		t.Fatalf("Assignee takes all strategy should return the assignee takes all allocator")
+	}
+}
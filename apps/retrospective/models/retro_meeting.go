@@ -0,0 +1,149 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/admin"
+	"github.com/qor/qor"
+	"github.com/qor/qor/resource"
+	"github.com/sirupsen/logrus"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+)
+
+// RetroMeetingPhaseValues ...
+var RetroMeetingPhaseValues = [...]string{
+	"Gather",
+	"Group",
+	"Vote",
+	"Discuss",
+	"Action Items",
+}
+
+// RetroMeetingPhase ...
+type RetroMeetingPhase int8
+
+// GetStringValue ...
+func (phase RetroMeetingPhase) GetStringValue() string {
+	return RetroMeetingPhaseValues[phase]
+}
+
+// RetroMeetingPhase
+const (
+	GatherPhase RetroMeetingPhase = iota
+	GroupPhase
+	VotePhase
+	DiscussPhase
+	ActionItemsPhase
+)
+
+// RetroMeeting is the facilitated retro meeting of a sprint. The timer is running when TimerStartedAt is set,
+// TimerRemaining is the seconds left as of starting or pausing the timer.
+type RetroMeeting struct {
+	gorm.Model
+	Sprint         Sprint
+	SprintID       uint `gorm:"not null"`
+	Facilitator    userModels.User
+	FacilitatorID  uint              `gorm:"not null"`
+	Phase          RetroMeetingPhase `gorm:"default:0; not null"`
+	VotesPerUser   uint              `gorm:"not null; default:3"`
+	TimerDuration  uint              `gorm:"not null; default:0"`
+	TimerRemaining uint              `gorm:"not null; default:0"`
+	TimerStartedAt *time.Time
+	Groups         []RetroMeetingGroup `gorm:"foreignkey:MeetingID"`
+}
+
+// Validate ...
+func (meeting *RetroMeeting) Validate(db *gorm.DB) (err error) {
+	if meeting.Phase < 0 || int(meeting.Phase) >= len(RetroMeetingPhaseValues) {
+		return errors.New("please select a valid meeting phase")
+	}
+	if meeting.TimerRemaining > meeting.TimerDuration {
+		return errors.New("timer remaining can not be more than the timer duration")
+	}
+	return
+}
+
+// BeforeSave ...
+func (meeting *RetroMeeting) BeforeSave(db *gorm.DB) (err error) {
+	return meeting.Validate(db)
+}
+
+// BeforeUpdate ...
+func (meeting *RetroMeeting) BeforeUpdate(db *gorm.DB) (err error) {
+	return meeting.Validate(db)
+}
+
+// RetroMeetingGroup is a group of the sprint's notes and highlights which are voted upon and discussed together,
+// the outcome of the discussion is recorded as a goal
+type RetroMeetingGroup struct {
+	gorm.Model
+	Meeting   RetroMeeting
+	MeetingID uint   `gorm:"not null"`
+	Title     string `gorm:"type:varchar(255); not null"`
+	Goal      *RetrospectiveFeedback
+	GoalID    *uint
+	Items     []RetroMeetingGroupItem `gorm:"foreignkey:GroupID"`
+	Votes     []RetroMeetingVote      `gorm:"foreignkey:GroupID"`
+}
+
+// RetroMeetingGroupItem places a note or a highlight in a group
+type RetroMeetingGroupItem struct {
+	gorm.Model
+	Group      RetroMeetingGroup
+	GroupID    uint `gorm:"not null"`
+	Feedback   RetrospectiveFeedback
+	FeedbackID uint `gorm:"not null"`
+}
+
+// RetroMeetingVote is a single dot given by a user to a group, a user can give multiple dots to a group
+type RetroMeetingVote struct {
+	gorm.Model
+	Group   RetroMeetingGroup
+	GroupID uint `gorm:"not null"`
+	Voter   userModels.User
+	VoterID uint `gorm:"not null"`
+}
+
+// RegisterRetroMeetingToAdmin ...
+func RegisterRetroMeetingToAdmin(Admin *admin.Admin, config admin.Config) {
+	meeting := Admin.AddResource(&RetroMeeting{}, &config)
+	phaseMeta := getRetroMeetingPhaseMeta()
+	facilitatorMeta := userModels.GetUserFieldMeta("Facilitator")
+
+	meeting.Meta(&phaseMeta)
+	meeting.Meta(&facilitatorMeta)
+}
+
+func getRetroMeetingPhaseMeta() admin.Meta {
+	return admin.Meta{
+		Name: "Phase",
+		Type: "select_one",
+		Valuer: func(value interface{}, context *qor.Context) interface{} {
+			meeting := value.(*RetroMeeting)
+			return strconv.Itoa(int(meeting.Phase))
+		},
+		Setter: func(resource interface{}, metaValue *resource.MetaValue, context *qor.Context) {
+			meeting := resource.(*RetroMeeting)
+			value, err := strconv.Atoi(metaValue.Value.([]string)[0])
+			if err != nil {
+				logrus.Error("Cannot convert string to int")
+				return
+			}
+			meeting.Phase = RetroMeetingPhase(value)
+		},
+		Collection: func(value interface{}, context *qor.Context) (results [][]string) {
+			for index, value := range RetroMeetingPhaseValues {
+				results = append(results, []string{strconv.Itoa(index), value})
+			}
+			return
+		},
+		FormattedValuer: func(value interface{}, context *qor.Context) interface{} {
+			meeting := value.(*RetroMeeting)
+			return meeting.Phase.GetStringValue()
+		},
+	}
+}
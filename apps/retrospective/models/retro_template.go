@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/admin"
+	"github.com/qor/qor"
+	"github.com/qor/qor/resource"
+	"github.com/sirupsen/logrus"
+)
+
+// RetroTemplate is a retro format, it defines the sub-types allowed for each type of retrospective feedback.
+// Feedbacks of a type without any sub-types in the template can have any sub-type.
+type RetroTemplate struct {
+	gorm.Model
+	Name        string                 `gorm:"type:varchar(255); not null"`
+	Description string                 `gorm:"type:text"`
+	SubTypes    []RetroTemplateSubType `gorm:"foreignkey:TemplateID"`
+}
+
+// Validate ...
+func (template *RetroTemplate) Validate(db *gorm.DB) (err error) {
+	if template.Name == "" {
+		return errors.New("template name can not be empty")
+	}
+	return
+}
+
+// BeforeSave ...
+func (template *RetroTemplate) BeforeSave(db *gorm.DB) (err error) {
+	return template.Validate(db)
+}
+
+// BeforeUpdate ...
+func (template *RetroTemplate) BeforeUpdate(db *gorm.DB) (err error) {
+	return template.Validate(db)
+}
+
+// RetroTemplateSubType is a sub-type allowed by a template for a type of retrospective feedback
+type RetroTemplateSubType struct {
+	gorm.Model
+	Template   RetroTemplate
+	TemplateID uint                      `gorm:"not null"`
+	Type       RetrospectiveFeedbackType `gorm:"default:0; not null"`
+	Name       string                    `gorm:"type:varchar(30); not null"`
+}
+
+// Validate ...
+func (subType *RetroTemplateSubType) Validate(db *gorm.DB) (err error) {
+	if subType.Type < 0 || int(subType.Type) >= len(RetrospectiveFeedbackTypeValues) {
+		return errors.New("please select a valid retrospective feedback type")
+	}
+	if subType.Name == "" {
+		return errors.New("sub-type name can not be empty")
+	}
+	return
+}
+
+// BeforeSave ...
+func (subType *RetroTemplateSubType) BeforeSave(db *gorm.DB) (err error) {
+	return subType.Validate(db)
+}
+
+// BeforeUpdate ...
+func (subType *RetroTemplateSubType) BeforeUpdate(db *gorm.DB) (err error) {
+	return subType.Validate(db)
+}
+
+// IsAllowedFeedbackSubType checks the sub-type of a retrospective feedback against the retrospective's template,
+// any sub-type is allowed when the retrospective has no template
+func IsAllowedFeedbackSubType(db *gorm.DB, retroID string, feedbackType RetrospectiveFeedbackType,
+	subType string) (bool, error) {
+	var subTypes []RetroTemplateSubType
+	if err := db.Model(&RetroTemplateSubType{}).
+		Joins("JOIN retrospectives ON retrospectives.template_id = retro_template_sub_types.template_id").
+		Where("retro_template_sub_types.deleted_at IS NULL").
+		Where("retrospectives.deleted_at IS NULL").
+		Where("retrospectives.id = ?", retroID).
+		Where("retro_template_sub_types.type = ?", feedbackType).
+		Find(&subTypes).Error; err != nil {
+		return false, err
+	}
+
+	if len(subTypes) == 0 {
+		return true, nil
+	}
+	for _, allowedSubType := range subTypes {
+		if allowedSubType.Name == subType {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// RegisterRetroTemplateToAdmin ...
+func RegisterRetroTemplateToAdmin(Admin *admin.Admin, config admin.Config) {
+	template := Admin.AddResource(&RetroTemplate{}, &config)
+	subTypes := template.Meta(&admin.Meta{Name: "SubTypes"}).Resource
+	typeMeta := getRetroTemplateSubTypeTypeMeta()
+	subTypes.Meta(&typeMeta)
+	subTypes.EditAttrs("Type", "Name")
+	subTypes.NewAttrs("Type", "Name")
+}
+
+// getRetroTemplateSubTypeTypeMeta is the meta config for the type field
+func getRetroTemplateSubTypeTypeMeta() admin.Meta {
+	return admin.Meta{
+		Name: "Type",
+		Type: "select_one",
+		Valuer: func(value interface{}, context *qor.Context) interface{} {
+			subType := value.(*RetroTemplateSubType)
+			return strconv.Itoa(int(subType.Type))
+		},
+		Setter: func(resource interface{}, metaValue *resource.MetaValue, context *qor.Context) {
+			subType := resource.(*RetroTemplateSubType)
+			value, err := strconv.Atoi(metaValue.Value.([]string)[0])
+			if err != nil {
+				logrus.Error("Cannot convert string to int")
+				return
+			}
+			subType.Type = RetrospectiveFeedbackType(value)
+		},
+		Collection: func(value interface{}, context *qor.Context) (results [][]string) {
+			for index, value := range RetrospectiveFeedbackTypeValues {
+				results = append(results, []string{strconv.Itoa(index), value})
+			}
+			return
+		},
+		FormattedValuer: func(value interface{}, context *qor.Context) interface{} {
+			subType := value.(*RetroTemplateSubType)
+			return subType.Type.GetStringValue()
+		},
+	}
+}
@@ -3,12 +3,15 @@ package models
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/qor/admin"
 	"github.com/qor/qor"
 	"github.com/qor/qor/resource"
+	"github.com/sirupsen/logrus"
 
 	"github.com/iReflect/reflect-app/apps/tasktracker"
 	"github.com/iReflect/reflect-app/apps/timetracker"
@@ -17,19 +20,34 @@ import (
 	"github.com/iReflect/reflect-app/libs/utils"
 )
 
-// Retrospective represents a retrospective of a team
+// Retrospective represents a retrospective of a team. With TrackerWriteBack the edits to the estimate,
+// resolution and done status of the tasks are pushed to the task tracker. PointsAllocationStrategy decides
+// how the points of the done tasks are split among the members, ReviewerPointsWeight is only used by the
+// role weighted strategy. With a SprintLengthDays the next draft sprint is created when a sprint is frozen,
+// see GetNextSprintDates. With RollUpSubtasks the subtasks are synced as a part of their parent task, i.e. their
+// estimates and time logs are added to the ones of the parent.
 type Retrospective struct {
 	gorm.Model
-	Title              string       `gorm:"type:varchar(255); not null"`
-	ProjectName        string       `gorm:"type:varchar(255); not null"`
-	TaskProviderConfig fields.JSONB `gorm:"type:jsonb; not null; default:'[]'::jsonb"`
-	TimeProviderName   string       `gorm:"not null"`
-	Team               userModels.Team
-	TeamID             uint `gorm:"not null"`
-	Sprints            []Sprint
-	StoryPointPerWeek  float64 `gorm:"not null"`
-	CreatedBy          userModels.User
-	CreatedByID        uint `gorm:"not null"`
+	Title                    string       `gorm:"type:varchar(255); not null"`
+	ProjectName              string       `gorm:"type:varchar(255); not null"`
+	TaskProviderConfig       fields.JSONB `gorm:"type:jsonb; not null; default:'[]'::jsonb"`
+	TimeProviderName         string       `gorm:"not null"`
+	Team                     userModels.Team
+	TeamID                   uint `gorm:"not null"`
+	Sprints                  []Sprint
+	StoryPointPerWeek        float64 `gorm:"not null"`
+	AnonymousFeedback        bool    `gorm:"not null; default:false"`
+	Template                 *RetroTemplate
+	TemplateID               *uint
+	TrackerWriteBack         bool                     `gorm:"not null; default:false"`
+	PointsAllocationStrategy PointsAllocationStrategy `gorm:"default:0; not null"`
+	ReviewerPointsWeight     float64                  `gorm:"not null; default:0.5"`
+	SprintLengthDays         int                      `gorm:"not null; default:0"`
+	SprintStartWeekday       int8                     `gorm:"not null; default:1"`
+	SprintTitlePattern       string                   `gorm:"type:varchar(255); not null; default:''"`
+	RollUpSubtasks           bool                     `gorm:"not null; default:false"`
+	CreatedBy                userModels.User
+	CreatedByID              uint `gorm:"not null"`
 }
 
 // Validate ...
@@ -41,6 +59,19 @@ func (retrospective *Retrospective) Validate(db *gorm.DB) (err error) {
 	if _, exists := timetracker.TimeProvidersDisplayNameMap[retrospective.TimeProviderName]; !exists {
 		return errors.New("Invalid time provider name")
 	}
+	if retrospective.PointsAllocationStrategy < 0 ||
+		int(retrospective.PointsAllocationStrategy) >= len(PointsAllocationStrategyValues) {
+		return errors.New("please select a valid points allocation strategy")
+	}
+	if retrospective.ReviewerPointsWeight < 0 || retrospective.ReviewerPointsWeight > 1 {
+		return errors.New("reviewer points weight should be between 0 and 1")
+	}
+	if retrospective.SprintLengthDays < 0 || retrospective.SprintLengthDays > MaxSprintLengthDays {
+		return fmt.Errorf("sprint length should be between 0 and %d days", MaxSprintLengthDays)
+	}
+	if retrospective.SprintStartWeekday < int8(time.Sunday) || retrospective.SprintStartWeekday > int8(time.Saturday) {
+		return errors.New("please select a valid sprint start weekday")
+	}
 	return
 }
 
@@ -62,8 +93,10 @@ func RegisterRetrospectiveToAdmin(Admin *admin.Admin, config admin.Config) {
 	taskProviderConfigMeta := getTaskProviderConfigMetaFieldMeta()
 	createdByMeta := userModels.GetUserFieldMeta("CreatedBy")
 	providerNameMeta := getTimeProviderMeta()
+	pointsAllocationStrategyMeta := getPointsAllocationStrategyMeta()
 
 	retrospective.Meta(&providerNameMeta)
+	retrospective.Meta(&pointsAllocationStrategyMeta)
 	retrospective.Meta(&taskProviderConfigMeta)
 	retrospective.Meta(&createdByMeta)
 
@@ -116,6 +149,37 @@ func getTimeProviderMeta() admin.Meta {
 	}
 }
 
+// getPointsAllocationStrategyMeta is the meta config for the points allocation strategy field
+func getPointsAllocationStrategyMeta() admin.Meta {
+	return admin.Meta{
+		Name: "PointsAllocationStrategy",
+		Type: "select_one",
+		Valuer: func(value interface{}, context *qor.Context) interface{} {
+			retrospective := value.(*Retrospective)
+			return strconv.Itoa(int(retrospective.PointsAllocationStrategy))
+		},
+		Setter: func(resource interface{}, metaValue *resource.MetaValue, context *qor.Context) {
+			retrospective := resource.(*Retrospective)
+			value, err := strconv.Atoi(metaValue.Value.([]string)[0])
+			if err != nil {
+				logrus.Error("Cannot convert string to int")
+				return
+			}
+			retrospective.PointsAllocationStrategy = PointsAllocationStrategy(value)
+		},
+		Collection: func(value interface{}, context *qor.Context) (results [][]string) {
+			for index, value := range PointsAllocationStrategyValues {
+				results = append(results, []string{strconv.Itoa(index), value})
+			}
+			return
+		},
+		FormattedValuer: func(value interface{}, context *qor.Context) interface{} {
+			retrospective := value.(*Retrospective)
+			return retrospective.PointsAllocationStrategy.GetStringValue()
+		},
+	}
+}
+
 // RetroJoinSprints ...
 func RetroJoinSprints(db *gorm.DB) *gorm.DB {
 	return db.Joins("JOIN sprints ON retrospectives.id = sprints.retrospective_id AND sprints.deleted_at IS NULL")
@@ -156,3 +220,43 @@ func GetTaskTrackerConnectionFromRetro(db *gorm.DB, retroID string) (tasktracker
 	}
 	return connection, nil
 }
+
+// getRetroTeam returns the team of the retrospective
+func getRetroTeam(db *gorm.DB, retroID interface{}) (team userModels.Team, err error) {
+	err = db.Model(&userModels.Team{}).
+		Joins("JOIN retrospectives ON retrospectives.team_id = teams.id").
+		Where("retrospectives.id = ?", retroID).
+		Select("teams.*").
+		First(&team).Error
+	return team, err
+}
+
+// GetWorkingCalendarFromRetro returns the working calendar of the team of the retrospective,
+// the default calendar is the fallback
+func GetWorkingCalendarFromRetro(db *gorm.DB, retroID interface{}) utils.WorkingCalendar {
+	team, err := getRetroTeam(db, retroID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return utils.GetDefaultWorkingCalendar()
+	}
+	return team.GetWorkingCalendar()
+}
+
+// GetTeamLocationFromRetro returns the time zone the team of the retrospective has set, nil when the team has
+// none so that the time zone of the time tracker is used
+func GetTeamLocationFromRetro(db *gorm.DB, retroID interface{}) *time.Location {
+	team, err := getRetroTeam(db, retroID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	if team.TimeZone == "" {
+		return nil
+	}
+	location, err := time.LoadLocation(team.TimeZone)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	return location
+}
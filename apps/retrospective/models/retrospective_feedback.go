@@ -56,26 +56,62 @@ const (
 	GoalType
 )
 
-// RetrospectiveFeedback represent Goals, Highlights and Notes of a sprint
+// GoalProgressValues ...
+var GoalProgressValues = [...]string{
+	"Not Started",
+	"In Progress",
+	"Blocked",
+}
+
+// GoalProgress ...
+type GoalProgress int8
+
+// GetStringValue ...
+func (progress GoalProgress) GetStringValue() string {
+	return GoalProgressValues[progress]
+}
+
+// GoalProgress
+const (
+	NotStartedGoal GoalProgress = iota
+	InProgressGoal
+	BlockedGoal
+)
+
+// RetrospectiveFeedback represent Goals, Highlights and Notes of a sprint. The authors of the anonymous
+// feedbacks are only visible to the admins through the admin panel. Progress, CarryOverCount and the tracker task
+// fields are only used by the goals, CarryOverCount is the number of sprints the goal has been carried over into.
+// TrackerTaskStatus is the status of the linked ticket as of the last sprint sync.
 type RetrospectiveFeedback struct {
 	gorm.Model
-	SubType         string                    `gorm:"type:varchar(30); not null"`
-	Type            RetrospectiveFeedbackType `gorm:"default:0; not null"`
-	Retrospective   Retrospective
-	RetrospectiveID uint                       `gorm:"not null"`
-	Text            string                     `gorm:"type:text; not null"`
-	Scope           RetrospectiveFeedbackScope `gorm:"default:0; not null"`
-	AssigneeID      *uint
-	Assignee        userModels.User
-	AddedAt         *time.Time
-	ResolvedAt      *time.Time
-	ExpectedAt      *time.Time
-	CreatedByID     uint `gorm:"not null"`
-	CreatedBy       userModels.User
+	SubType           string                    `gorm:"type:varchar(30); not null"`
+	Type              RetrospectiveFeedbackType `gorm:"default:0; not null"`
+	Retrospective     Retrospective
+	RetrospectiveID   uint                       `gorm:"not null"`
+	Text              string                     `gorm:"type:text; not null"`
+	Scope             RetrospectiveFeedbackScope `gorm:"default:0; not null"`
+	AssigneeID        *uint
+	Assignee          userModels.User
+	AddedAt           *time.Time
+	ResolvedAt        *time.Time
+	ExpectedAt        *time.Time
+	CreatedByID       uint `gorm:"not null"`
+	CreatedBy         userModels.User
+	Anonymous         bool         `gorm:"not null; default:false"`
+	Progress          GoalProgress `gorm:"default:0; not null"`
+	CarryOverCount    uint         `gorm:"not null; default:0"`
+	TrackerTaskKey    *string      `gorm:"type:varchar(255)"`
+	TrackerTaskStatus string       `gorm:"type:varchar(50); not null; default:''"`
+	// TrackerTaskPending is set while a ticket is created for the goal, and stays set when the created ticket
+	// could not be linked to the goal, until a ticket is linked
+	TrackerTaskPending bool `gorm:"not null; default:false"`
 }
 
 // Validate ...
 func (feedback *RetrospectiveFeedback) Validate(db *gorm.DB) (err error) {
+	if feedback.Progress < 0 || int(feedback.Progress) >= len(GoalProgressValues) {
+		return errors.New("please select a valid goal progress")
+	}
 	if feedback.ExpectedAt != nil && feedback.ExpectedAt.Before(*feedback.AddedAt) {
 		err = errors.New("expected_at can not be before added at")
 		return err
@@ -112,6 +148,19 @@ func (feedback *RetrospectiveFeedback) BeforeUpdate(db *gorm.DB) (err error) {
 	return feedback.Validate(db)
 }
 
+// UpdateGoalCarryOverCounts recounts the sprints each goal of the retrospective has been carried over into,
+// i.e. the started sprints after the one the goal was added in, up to the one it was resolved in.
+func UpdateGoalCarryOverCounts(db *gorm.DB, retroID interface{}) error {
+	return db.Exec(`UPDATE retrospective_feedbacks SET carry_over_count = (
+		SELECT COUNT(*) FROM sprints WHERE sprints.retrospective_id = retrospective_feedbacks.retrospective_id
+		AND sprints.deleted_at IS NULL AND sprints.status IN (?)
+		AND sprints.start_date > retrospective_feedbacks.added_at
+		AND (retrospective_feedbacks.resolved_at IS NULL OR sprints.start_date <= retrospective_feedbacks.resolved_at))
+		WHERE retrospective_feedbacks.retrospective_id = ? AND retrospective_feedbacks.type = ?
+		AND retrospective_feedbacks.deleted_at IS NULL`,
+		[]SprintStatus{ActiveSprint, CompletedSprint}, retroID, GoalType).Error
+}
+
 // RegisterRetrospectiveFeedbackToAdmin ...
 func RegisterRetrospectiveFeedbackToAdmin(Admin *admin.Admin, config admin.Config) {
 	retroFeedback := Admin.AddResource(&RetrospectiveFeedback{}, &config)
@@ -119,11 +168,13 @@ func RegisterRetrospectiveFeedbackToAdmin(Admin *admin.Admin, config admin.Confi
 	scopeMeta := getRetrospectiveFeedbackScopeFieldMeta()
 	assigneeMeta := userModels.GetUserFieldMeta("Assignee")
 	createdByMeta := userModels.GetUserFieldMeta("CreatedBy")
+	progressMeta := getRetrospectiveFeedbackProgressFieldMeta()
 
 	retroFeedback.Meta(&typeMeta)
 	retroFeedback.Meta(&scopeMeta)
 	retroFeedback.Meta(&assigneeMeta)
 	retroFeedback.Meta(&createdByMeta)
+	retroFeedback.Meta(&progressMeta)
 }
 
 // getRetrospectiveFeedbackTypeFieldMeta is the meta config for the type field
@@ -187,3 +238,34 @@ func getRetrospectiveFeedbackScopeFieldMeta() admin.Meta {
 		},
 	}
 }
+
+// getRetrospectiveFeedbackProgressFieldMeta is the meta config for the progress field
+func getRetrospectiveFeedbackProgressFieldMeta() admin.Meta {
+	return admin.Meta{
+		Name: "Progress",
+		Type: "select_one",
+		Valuer: func(value interface{}, context *qor.Context) interface{} {
+			retroFeedback := value.(*RetrospectiveFeedback)
+			return strconv.Itoa(int(retroFeedback.Progress))
+		},
+		Setter: func(resource interface{}, metaValue *resource.MetaValue, context *qor.Context) {
+			retroFeedback := resource.(*RetrospectiveFeedback)
+			value, err := strconv.Atoi(metaValue.Value.([]string)[0])
+			if err != nil {
+				logrus.Error("Cannot convert string to int")
+				return
+			}
+			retroFeedback.Progress = GoalProgress(value)
+		},
+		Collection: func(value interface{}, context *qor.Context) (results [][]string) {
+			for index, value := range GoalProgressValues {
+				results = append(results, []string{strconv.Itoa(index), value})
+			}
+			return
+		},
+		FormattedValuer: func(value interface{}, context *qor.Context) interface{} {
+			retroFeedback := value.(*RetrospectiveFeedback)
+			return retroFeedback.Progress.GetStringValue()
+		},
+	}
+}
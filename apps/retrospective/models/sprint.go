@@ -1,7 +1,6 @@
 package models
 
 import (
-	"log"
 	"strconv"
 	"strings"
 	"time"
@@ -13,7 +12,6 @@ import (
 	"github.com/sirupsen/logrus"
 
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
-	"github.com/iReflect/reflect-app/config"
 	customErrors "github.com/iReflect/reflect-app/libs"
 	"github.com/iReflect/reflect-app/libs/utils"
 )
@@ -42,10 +40,12 @@ const (
 	DeletedSprint
 )
 
-// Sprint represents a sprint of a retrospective
+// Sprint represents a sprint of a retrospective, the Number of a sprint follows the one of the last sprint of
+// the retrospective when it is created
 type Sprint struct {
 	gorm.Model
 	Title           string `gorm:"type:varchar(255); not null"`
+	Number          int    `gorm:"not null; default:0"`
 	SprintID        string `gorm:"type:varchar(30); not null"`
 	Retrospective   Retrospective
 	RetrospectiveID uint         `gorm:"not null"`
@@ -77,6 +77,8 @@ func (sprint *Sprint) Validate(db *gorm.DB) (err error) {
 		Where("deleted_at IS NULL").
 		Where("retrospective_id = ?", retroID).Scopes(NotDeletedSprint)
 
+	calendar := GetWorkingCalendarFromRetro(db, retroID)
+
 	if sprint.Status == DraftSprint {
 		// More than one entries with status draft for given retro should not be allowed
 		err = sprint.validateConcurrency(baseQuery, "another sprint is currently in draft")
@@ -84,7 +86,7 @@ func (sprint *Sprint) Validate(db *gorm.DB) (err error) {
 			return err
 		}
 		// Draft sprint must begin exactly 1 day after last frozen/active sprint
-		err = sprint.validateDateContinuity(baseQuery, []SprintStatus{CompletedSprint, ActiveSprint}, "sprint must begin the day after the last completed/activated sprint ended")
+		err = sprint.validateDateContinuity(baseQuery, calendar, []SprintStatus{CompletedSprint, ActiveSprint}, "sprint must begin the day after the last completed/activated sprint ended")
 		if err != nil {
 			return err
 		}
@@ -97,7 +99,7 @@ func (sprint *Sprint) Validate(db *gorm.DB) (err error) {
 			return err
 		}
 		// Active sprint must begin exactly 1 day after last completed sprint
-		err = sprint.validateDateContinuity(baseQuery, []SprintStatus{CompletedSprint}, "sprint must begin the day after the last completed sprint ended")
+		err = sprint.validateDateContinuity(baseQuery, calendar, []SprintStatus{CompletedSprint}, "sprint must begin the day after the last completed sprint ended")
 		if err != nil {
 			return err
 		}
@@ -116,26 +118,14 @@ func (sprint *Sprint) validateConcurrency(baseQuery *gorm.DB, errorMessage strin
 	return
 }
 
-func (sprint *Sprint) validateDateContinuity(baseQuery *gorm.DB, statuses []SprintStatus, errorMessage string) (err error) {
-
-	serverConf := config.GetConfig().Server
-	location, err := time.LoadLocation(serverConf.TimeZone)
-
-	if err != nil {
-		log.Println("Invalid Timezone: ", err)
-		utils.LogToSentry(err)
-	}
+// validateDateContinuity compares the days in the time zone of the team of the retrospective
+func (sprint *Sprint) validateDateContinuity(baseQuery *gorm.DB, calendar utils.WorkingCalendar, statuses []SprintStatus, errorMessage string) (err error) {
 
 	lastSprint := Sprint{}
 	if err := baseQuery.Where("status IN (?)", statuses).
 		Order("end_date desc").First(&lastSprint).Error; err == nil {
-		expectedDate := lastSprint.EndDate.AddDate(0, 0, 1)
-		startDate := *(sprint.StartDate)
-		if location != nil {
-			expectedDate = expectedDate.In(location)
-			startDate = startDate.In(location)
-		}
-		if expectedDate.Year() != startDate.Year() || expectedDate.YearDay() != startDate.YearDay() {
+		expectedDate := calendar.In(*lastSprint.EndDate).AddDate(0, 0, 1)
+		if !calendar.IsSameDay(expectedDate, *sprint.StartDate) {
 			return &customErrors.ModelError{Message: errorMessage}
 		}
 	}
@@ -154,6 +144,19 @@ func (sprint *Sprint) BeforeUpdate(db *gorm.DB) (err error) {
 	return sprint.Validate(db)
 }
 
+// BeforeCreate numbers the sprint unless it already has a number
+func (sprint *Sprint) BeforeCreate(db *gorm.DB) (err error) {
+	if sprint.Number != 0 {
+		return nil
+	}
+	retroID := sprint.RetrospectiveID
+	if retroID == 0 {
+		retroID = sprint.Retrospective.ID
+	}
+	sprint.Number, err = GetNextSprintNumber(db, retroID)
+	return err
+}
+
 // RegisterSprintToAdmin ...
 func RegisterSprintToAdmin(Admin *admin.Admin, config admin.Config) {
 	sprint := Admin.AddResource(&Sprint{}, &config)
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// DefaultSprintTitlePattern is the title of the sprints created as per the cadence of a retrospective
+// when it has no title pattern of its own
+const DefaultSprintTitlePattern = "Sprint {number}"
+
+// MaxSprintLengthDays ...
+const MaxSprintLengthDays = 90
+
+// HasSprintCadence checks if the next sprint of the retrospective is to be created when a sprint is frozen
+func (retrospective Retrospective) HasSprintCadence() bool {
+	return retrospective.SprintLengthDays > 0
+}
+
+// GetNextSprintDates returns the dates of the sprint beginning the day after the last sprint ended. The sprint
+// lasts for SprintLengthDays and then up to the day before the SprintStartWeekday, so that the sprints keep
+// beginning on the same weekday.
+func (retrospective Retrospective) GetNextSprintDates(lastSprintEndDate time.Time,
+	calendar utils.WorkingCalendar) (time.Time, time.Time) {
+	startDate := utils.GetStartOfDay(calendar.In(lastSprintEndDate)).AddDate(0, 0, 1)
+	nextStartDate := startDate.AddDate(0, 0, retrospective.SprintLengthDays)
+	for nextStartDate.Weekday() != time.Weekday(retrospective.SprintStartWeekday) {
+		nextStartDate = nextStartDate.AddDate(0, 0, 1)
+	}
+	endDate := nextStartDate.Add(-time.Second)
+	return startDate, endDate
+}
+
+// GetNextSprintNumber returns the number following the one of the last sprint of the retrospective, so the
+// numbers stay unique when a sprint in between is deleted
+func GetNextSprintNumber(db *gorm.DB, retroID uint) (int, error) {
+	var lastNumber int
+	err := db.Model(&Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("retrospective_id = ?", retroID).
+		Scopes(NotDeletedSprint).
+		Select("COALESCE(MAX(number), 0)").
+		Row().
+		Scan(&lastNumber)
+	if err != nil {
+		return 0, err
+	}
+	return lastNumber + 1, nil
+}
+
+// GetSprintTitle returns the title of a sprint as per the title pattern of the retrospective, the pattern may
+// have the {number} of the sprint and its {start} and {end} dates, i.e. "Sprint {number} ({start})"
+func (retrospective Retrospective) GetSprintTitle(number int, startDate time.Time, endDate time.Time) string {
+	pattern := strings.TrimSpace(retrospective.SprintTitlePattern)
+	if pattern == "" {
+		pattern = DefaultSprintTitlePattern
+	}
+	return strings.NewReplacer(
+		"{number}", strconv.Itoa(number),
+		"{start}", startDate.Format(constants.CustomDateFormat),
+		"{end}", endDate.Format(constants.CustomDateFormat),
+	).Replace(pattern)
+}
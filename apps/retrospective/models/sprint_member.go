@@ -12,10 +12,10 @@ import (
 
 	"github.com/iReflect/reflect-app/apps/retrospective"
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
-	"github.com/iReflect/reflect-app/libs/utils"
 )
 
-// SprintMember represents a member of a particular sprint
+// SprintMember represents a member of a particular sprint, the Vacations are the days of the Leaves of the member
+// along with the UndatedVacations, the days of vacation entered without dates
 type SprintMember struct {
 	gorm.Model
 	Sprint             Sprint
@@ -25,7 +25,9 @@ type SprintMember struct {
 	AllocationPercent  float64 `gorm:"not null;default:100"`
 	ExpectationPercent float64 `gorm:"not null;default:100"`
 	Tasks              []SprintMemberTask
+	Leaves             []SprintMemberLeave
 	Vacations          float64              `gorm:"not null;default:0"`
+	UndatedVacations   float64              `gorm:"not null;default:0"`
 	Rating             retrospective.Rating `gorm:"default:2; not null"`
 	Comment            string               `gorm:"type:text"`
 }
@@ -42,13 +44,14 @@ func (sprintMember *SprintMember) Validate(db *gorm.DB) (err error) {
 	}
 	// Vacations should not be longer than sprint duration
 	if sprint.StartDate != nil && sprint.EndDate != nil {
-		sprintWorkingDays := utils.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate)
+		sprintWorkingDays := GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID).
+			GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate)
 		if sprintMember.Vacations > float64(sprintWorkingDays) {
 			err = errors.New("vacations cannot be longer than sprint duration")
 			return err
 		}
 	}
-	if sprintMember.Vacations < 0 {
+	if sprintMember.Vacations < 0 || sprintMember.UndatedVacations < 0 {
 		err = errors.New("vacations cannot be negative")
 		return err
 	}
@@ -0,0 +1,183 @@
+package models
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/qor/admin"
+	"github.com/qor/qor"
+	"github.com/qor/qor/resource"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iReflect/reflect-app/constants"
+	customErrors "github.com/iReflect/reflect-app/libs"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// LeaveTypeValues ...
+var LeaveTypeValues = [...]string{
+	"Vacation",
+	"Sick Leave",
+	"Training",
+	"On-call Compensation",
+}
+
+// LeaveType ...
+type LeaveType int8
+
+// GetStringValue ...
+func (leaveType LeaveType) GetStringValue() string {
+	return LeaveTypeValues[leaveType]
+}
+
+// LeaveType
+const (
+	VacationLeave LeaveType = iota
+	SickLeave
+	TrainingLeave
+	OnCallCompensationLeave
+)
+
+// SprintMemberLeave is a day of leave of a sprint member, the vacations of the sprint member are the sum of
+// its leaves, where a HalfDay leave counts as half a day, and of its undated vacations
+type SprintMemberLeave struct {
+	gorm.Model
+	SprintMember   SprintMember
+	SprintMemberID uint      `gorm:"not null"`
+	Date           time.Time `gorm:"type:date; not null"`
+	Type           LeaveType `gorm:"default:0; not null"`
+	HalfDay        bool      `gorm:"not null; default:false"`
+	Note           string    `gorm:"type:text"`
+}
+
+// GetDays returns the days of leave
+func (leave SprintMemberLeave) GetDays() float64 {
+	if leave.HalfDay {
+		return 0.5
+	}
+	return 1
+}
+
+// Validate ...
+func (leave *SprintMemberLeave) Validate(db *gorm.DB) (err error) {
+	if leave.Type < 0 || int(leave.Type) >= len(LeaveTypeValues) {
+		return &customErrors.ModelError{Message: "please select a valid leave type"}
+	}
+
+	var sprint Sprint
+	if err = db.Model(&Sprint{}).
+		Scopes(SprintJoinSM).
+		Where("sprint_members.id = ?", leave.SprintMemberID).
+		Select("sprints.*").
+		First(&sprint).Error; err != nil {
+		return &customErrors.ModelError{Message: "cannot find sprint"}
+	}
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return &customErrors.ModelError{Message: "the sprint has no start/end date"}
+	}
+
+	// The leave is a calendar day of the team, so is compared with the days of the sprint in the team's time zone
+	calendar := GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID)
+	year, month, day := leave.Date.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, calendar.Location)
+	if date.Before(utils.GetStartOfDay(calendar.In(*sprint.StartDate))) ||
+		date.After(utils.GetStartOfDay(calendar.In(*sprint.EndDate))) {
+		return &customErrors.ModelError{Message: "leave must be within the sprint dates"}
+	}
+	if !calendar.IsWorkingDay(date) {
+		return &customErrors.ModelError{Message: "leave must be on a working day of the team"}
+	}
+
+	var count int
+	db.Model(&SprintMemberLeave{}).
+		Where("sprint_member_leaves.deleted_at IS NULL").
+		Where("sprint_member_id = ? AND date = ? AND id <> ?", leave.SprintMemberID,
+			leave.Date.Format(constants.CustomDateFormat), leave.ID).
+		Count(&count)
+	if count > 0 {
+		return &customErrors.ModelError{Message: "member is already on leave on the day"}
+	}
+	return nil
+}
+
+// BeforeSave ...
+func (leave *SprintMemberLeave) BeforeSave(db *gorm.DB) (err error) {
+	return leave.Validate(db)
+}
+
+// BeforeUpdate ...
+func (leave *SprintMemberLeave) BeforeUpdate(db *gorm.DB) (err error) {
+	return leave.Validate(db)
+}
+
+// AfterSave ...
+func (leave *SprintMemberLeave) AfterSave(db *gorm.DB) (err error) {
+	return UpdateSprintMemberVacations(db, leave.SprintMemberID)
+}
+
+// AfterDelete ...
+func (leave *SprintMemberLeave) AfterDelete(db *gorm.DB) (err error) {
+	return UpdateSprintMemberVacations(db, leave.SprintMemberID)
+}
+
+// UpdateSprintMemberVacations sets the vacations of the sprint member to the sum of its leaves and of its
+// undated vacations
+func UpdateSprintMemberVacations(db *gorm.DB, sprintMemberID uint) error {
+	return db.Exec(`UPDATE sprint_members SET vacations = sprint_members.undated_vacations + (
+			SELECT COALESCE(SUM(CASE WHEN half_day THEN 0.5 ELSE 1 END), 0) FROM sprint_member_leaves
+			WHERE sprint_member_leaves.deleted_at IS NULL AND sprint_member_leaves.sprint_member_id = sprint_members.id
+		), updated_at = NOW() WHERE id = ?`, sprintMemberID).Error
+}
+
+// RegisterSprintMemberLeaveToAdmin ...
+func RegisterSprintMemberLeaveToAdmin(Admin *admin.Admin, config admin.Config) {
+	sprintMemberLeave := Admin.AddResource(&SprintMemberLeave{}, &config)
+
+	sprintMemberMeta := getSprintMemberMeta()
+	leaveTypeMeta := getLeaveTypeFieldMeta()
+
+	sprintMemberLeave.Meta(&sprintMemberMeta)
+	sprintMemberLeave.Meta(&leaveTypeMeta)
+}
+
+// getLeaveTypeFieldMeta is the meta config for the leave type field
+func getLeaveTypeFieldMeta() admin.Meta {
+	return admin.Meta{
+		Name: "Type",
+		Type: "select_one",
+		Valuer: func(value interface{}, context *qor.Context) interface{} {
+			leave := value.(*SprintMemberLeave)
+			return strconv.Itoa(int(leave.Type))
+		},
+		Setter: func(resource interface{}, metaValue *resource.MetaValue, context *qor.Context) {
+			leave := resource.(*SprintMemberLeave)
+			value, err := strconv.Atoi(metaValue.Value.([]string)[0])
+			if err != nil {
+				logrus.Error("Cannot convert string to int")
+				return
+			}
+			leave.Type = LeaveType(value)
+		},
+		Collection: func(value interface{}, context *qor.Context) (results [][]string) {
+			for index, value := range LeaveTypeValues {
+				results = append(results, []string{strconv.Itoa(index), value})
+			}
+			return
+		},
+		FormattedValuer: func(value interface{}, context *qor.Context) interface{} {
+			leave := value.(*SprintMemberLeave)
+			return leave.Type.GetStringValue()
+		},
+	}
+}
+
+// SMJoinLeave ...
+func SMJoinLeave(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN sprint_member_leaves ON sprint_members.id = sprint_member_leaves.sprint_member_id AND sprint_member_leaves.deleted_at IS NULL")
+}
+
+// LeaveJoinSM ...
+func LeaveJoinSM(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN sprint_members ON sprint_member_leaves.sprint_member_id = sprint_members.id AND sprint_members.deleted_at IS NULL")
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/iReflect/reflect-app/libs/utils"
 	"strconv"
+	"time"
 
 	"github.com/jinzhu/gorm"
 	"github.com/qor/admin"
@@ -13,6 +14,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/iReflect/reflect-app/apps/retrospective"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
 )
 
 // MemberTaskRoleValues ...
@@ -35,7 +37,8 @@ const (
 	Reviewer
 )
 
-// SprintMemberTask represents a task for a member for a particular sprint
+// SprintMemberTask represents a task for a member for a particular sprint. The points of a task member with
+// PointsLocked set are kept as they are by the points allocation.
 type SprintMemberTask struct {
 	gorm.Model
 	SprintMember     SprintMember
@@ -45,9 +48,15 @@ type SprintMemberTask struct {
 	TimeSpentMinutes uint                 `gorm:"not null"`
 	PointsEarned     float64              `gorm:"default:0; not null"`
 	PointsAssigned   float64              `gorm:"default:0; not null"`
+	PlannedPoints    float64              `gorm:"default:0; not null"`
 	Rating           retrospective.Rating `gorm:"default:2; not null"`
 	Comment          string               `gorm:"type:text"`
 	Role             MemberTaskRole       `gorm:"default:0; not null"`
+	PointsLocked     bool                 `gorm:"not null; default:false"`
+	PointsLockedBy   *userModels.User
+	PointsLockedByID *uint
+	PointsLockReason string `gorm:"type:text"`
+	PointsLockedAt   *time.Time
 }
 
 // Validate ...
@@ -113,6 +122,24 @@ func (sprintMemberTask *SprintMemberTask) BeforeUpdate(db *gorm.DB) (err error)
 	return sprintMemberTask.Validate(db)
 }
 
+// Lock locks the points of the sprint member task against the points allocation
+func (sprintMemberTask *SprintMemberTask) Lock(userID uint, reason string) {
+	now := time.Now()
+	sprintMemberTask.PointsLocked = true
+	sprintMemberTask.PointsLockedByID = &userID
+	sprintMemberTask.PointsLockReason = reason
+	sprintMemberTask.PointsLockedAt = &now
+}
+
+// Unlock ...
+func (sprintMemberTask *SprintMemberTask) Unlock() {
+	sprintMemberTask.PointsLocked = false
+	sprintMemberTask.PointsLockedBy = nil
+	sprintMemberTask.PointsLockedByID = nil
+	sprintMemberTask.PointsLockReason = ""
+	sprintMemberTask.PointsLockedAt = nil
+}
+
 // RegisterSprintMemberTaskToAdmin ...
 func RegisterSprintMemberTaskToAdmin(Admin *admin.Admin, config admin.Config) {
 	sprintMemberTask := Admin.AddResource(&SprintMemberTask{}, &config)
@@ -121,11 +148,13 @@ func RegisterSprintMemberTaskToAdmin(Admin *admin.Admin, config admin.Config) {
 	roleMeta := getMemberTaskRoleFieldMeta()
 	sprintMembersMeta := getSprintMemberMeta()
 	ratingMeta := getSprintMemberTaskRatingMeta()
+	pointsLockedByMeta := userModels.GetUserFieldMeta("PointsLockedBy")
 
 	sprintMemberTask.Meta(&sprintTaskMeta)
 	sprintMemberTask.Meta(&roleMeta)
 	sprintMemberTask.Meta(&ratingMeta)
 	sprintMemberTask.Meta(&sprintMembersMeta)
+	sprintMemberTask.Meta(&pointsLockedByMeta)
 }
 
 // getSprintMemberTaskRatingMeta ...
@@ -9,12 +9,16 @@ import (
 )
 
 // SprintTask ...
+// PlannedEstimate overrides the estimate of the task in the plan of the sprint, InTrackerSprint is set once the
+// ticket of the task is synced as a part of the sprint in the task tracker
 type SprintTask struct {
 	gorm.Model
-	Sprint   Sprint
-	SprintID uint `gorm:"not null"`
-	Task     Task
-	TaskID   uint `gorm:"not null"`
+	Sprint          Sprint
+	SprintID        uint `gorm:"not null"`
+	Task            Task
+	TaskID          uint `gorm:"not null"`
+	PlannedEstimate *float64
+	InTrackerSprint bool `gorm:"not null; default:false"`
 }
 
 // RegisterSprintTaskToAdmin ...
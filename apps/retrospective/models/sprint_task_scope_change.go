@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+)
+
+// ScopeChangeTypeValues ...
+var ScopeChangeTypeValues = [...]string{
+	"Added",
+	"Removed",
+	"Re-estimated",
+}
+
+// ScopeChangeType ...
+type ScopeChangeType int8
+
+// GetStringValue ...
+func (changeType ScopeChangeType) GetStringValue() string {
+	return ScopeChangeTypeValues[changeType]
+}
+
+// ScopeChangeType
+const (
+	TaskAddedScopeChange ScopeChangeType = iota
+	TaskRemovedScopeChange
+	TaskReestimatedScopeChange
+)
+
+// ScopeChangeSourceValues ...
+var ScopeChangeSourceValues = [...]string{
+	"Sync",
+	"User",
+	"Webhook",
+}
+
+// ScopeChangeSource ...
+type ScopeChangeSource int8
+
+// GetStringValue ...
+func (source ScopeChangeSource) GetStringValue() string {
+	return ScopeChangeSourceValues[source]
+}
+
+// ScopeChangeSource
+const (
+	SyncScopeChangeSource ScopeChangeSource = iota
+	UserScopeChangeSource
+	WebhookScopeChangeSource
+)
+
+// SprintTaskScopeChange records a task entering, leaving or being re-estimated in a sprint
+type SprintTaskScopeChange struct {
+	gorm.Model
+	Sprint           Sprint
+	SprintID         uint `gorm:"not null"`
+	Task             Task
+	TaskID           uint              `gorm:"not null"`
+	Type             ScopeChangeType   `gorm:"default:0; not null"`
+	Source           ScopeChangeSource `gorm:"default:0; not null"`
+	PreviousEstimate float64           `gorm:"not null; default:0"`
+	Estimate         float64           `gorm:"not null; default:0"`
+	ChangedBy        *userModels.User
+	ChangedByID      *uint
+}
+
+// Validate ...
+func (scopeChange *SprintTaskScopeChange) Validate(db *gorm.DB) (err error) {
+	if scopeChange.Type < 0 || int(scopeChange.Type) >= len(ScopeChangeTypeValues) {
+		return errors.New("please select a valid scope change type")
+	}
+	if scopeChange.Source < 0 || int(scopeChange.Source) >= len(ScopeChangeSourceValues) {
+		return errors.New("please select a valid scope change source")
+	}
+	return
+}
+
+// BeforeSave ...
+func (scopeChange *SprintTaskScopeChange) BeforeSave(db *gorm.DB) (err error) {
+	return scopeChange.Validate(db)
+}
+
+// BeforeUpdate ...
+func (scopeChange *SprintTaskScopeChange) BeforeUpdate(db *gorm.DB) (err error) {
+	return scopeChange.Validate(db)
+}
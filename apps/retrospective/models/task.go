@@ -42,26 +42,37 @@ const (
 	CantReproduceResolution
 )
 
-// Task represents the tasks for retrospectives
+// Task represents the tasks for retrospectives. TrackerUpdatedAt is the last update time of the ticket
+// in the task tracker as of the last sync or write back, it is used to detect the conflicting edits.
+// A subtask has the ParentTrackerUniqueID of its parent ticket, the Parent is the task of the parent ticket
+// once it is synced in the retrospective, see LinkTaskParents. TrackerCreatedAt is the creation time of the ticket,
+// the start of the lead time of the task.
 type Task struct {
 	gorm.Model
-	Key               string `gorm:"type:varchar(30); not null"`
-	TrackerUniqueID   string `gorm:"type:varchar(255); not null"`
-	Retrospective     Retrospective
-	RetrospectiveID   uint                 `gorm:"not null"`
-	Summary           string               `gorm:"type:text; not null"`
-	Description       string               `gorm:"type:text; not null"`
-	Type              string               `gorm:"type:varchar(30); not null"`
-	Status            string               `gorm:"type:varchar(50); not null"`
-	Priority          string               `gorm:"type:varchar(50); not null"`
-	Assignee          string               `gorm:"type:varchar(100); not null"`
-	Estimate          float64              `gorm:"not null; default: 0"`
-	Fields            fields.JSONB         `gorm:"type:jsonb; not null; default:'{}'::jsonb"`
-	Rating            retrospective.Rating `gorm:"default:2; not null"`
-	DoneAt            *time.Time
-	IsTrackerTask     bool `gorm:"not null;default: false"`
-	SprintMemberTasks []SprintMemberTask
-	Resolution        Resolution `gorm:"default:0"`
+	Key                   string `gorm:"type:varchar(30); not null"`
+	TrackerUniqueID       string `gorm:"type:varchar(255); not null"`
+	Retrospective         Retrospective
+	RetrospectiveID       uint                 `gorm:"not null"`
+	Summary               string               `gorm:"type:text; not null"`
+	Description           string               `gorm:"type:text; not null"`
+	Type                  string               `gorm:"type:varchar(30); not null"`
+	Status                string               `gorm:"type:varchar(50); not null"`
+	Priority              string               `gorm:"type:varchar(50); not null"`
+	Assignee              string               `gorm:"type:varchar(100); not null"`
+	Estimate              float64              `gorm:"not null; default: 0"`
+	Fields                fields.JSONB         `gorm:"type:jsonb; not null; default:'{}'::jsonb"`
+	Rating                retrospective.Rating `gorm:"default:2; not null"`
+	DoneAt                *time.Time
+	IsTrackerTask         bool `gorm:"not null;default: false"`
+	SprintMemberTasks     []SprintMemberTask
+	Resolution            Resolution `gorm:"default:0"`
+	TrackerUpdatedAt      *time.Time
+	Parent                *Task
+	ParentID              *uint
+	ParentTrackerUniqueID string `gorm:"type:varchar(255); not null; default:''"`
+	EpicKey               string `gorm:"type:varchar(255); not null; default:''"`
+	TrackerCreatedAt      *time.Time
+	StatusTransitions     []TaskStatusTransition
 }
 
 // Stringify ...
@@ -95,10 +106,10 @@ func RegisterTaskToAdmin(Admin *admin.Admin, config admin.Config) {
 	task.Meta(&taskProviderConfigMeta)
 	task.Meta(&taskResolutionMeta)
 
-	task.IndexAttrs("-SprintMemberTasks")
-	task.NewAttrs("-SprintMemberTasks")
-	task.EditAttrs("-SprintMemberTasks")
-	task.ShowAttrs("-SprintMemberTasks")
+	task.IndexAttrs("-SprintMemberTasks", "-StatusTransitions")
+	task.NewAttrs("-SprintMemberTasks", "-StatusTransitions")
+	task.EditAttrs("-SprintMemberTasks", "-StatusTransitions")
+	task.ShowAttrs("-SprintMemberTasks", "-StatusTransitions")
 }
 func getTaskResolutionMeta() admin.Meta {
 	return admin.Meta{
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// LinkTaskParents links the subtasks of the retrospective to the tasks of their parent tickets, the subtasks
+// without an epic of their own belong to the epic of their parent
+func LinkTaskParents(db *gorm.DB, retroID uint) error {
+	if err := db.Exec(`UPDATE tasks SET parent_id = parents.id
+		FROM tasks AS parents
+		WHERE tasks.retrospective_id = ? AND tasks.deleted_at IS NULL AND tasks.parent_tracker_unique_id <> ''
+			AND parents.retrospective_id = tasks.retrospective_id AND parents.deleted_at IS NULL
+			AND parents.tracker_unique_id = tasks.parent_tracker_unique_id
+			AND parents.id <> tasks.id
+			AND tasks.parent_id IS DISTINCT FROM parents.id`, retroID).Error; err != nil {
+		return err
+	}
+
+	if err := db.Exec(`UPDATE tasks SET parent_id = NULL
+		WHERE tasks.retrospective_id = ? AND tasks.deleted_at IS NULL AND tasks.parent_id IS NOT NULL
+			AND tasks.parent_tracker_unique_id = ''`, retroID).Error; err != nil {
+		return err
+	}
+
+	return db.Exec(`UPDATE tasks SET epic_key = parents.epic_key
+		FROM tasks AS parents
+		WHERE tasks.retrospective_id = ? AND tasks.deleted_at IS NULL AND tasks.epic_key = ''
+			AND parents.id = tasks.parent_id AND parents.epic_key <> ''`, retroID).Error
+}
+
+// TaskLeftJoinParent ...
+func TaskLeftJoinParent(db *gorm.DB) *gorm.DB {
+	return db.Joins("LEFT JOIN tasks AS parents ON tasks.parent_id = parents.id AND parents.deleted_at IS NULL")
+}
@@ -11,3 +11,8 @@ type TaskKeyMap struct {
 	Task   Task
 	Key    string `gorm:"type:varchar(30); not null"`
 }
+
+// TaskKeyMapJoinTask ...
+func TaskKeyMapJoinTask(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN tasks ON task_key_maps.task_id = tasks.id AND tasks.deleted_at IS NULL")
+}
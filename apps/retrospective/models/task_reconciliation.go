@@ -0,0 +1,208 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/retrospective"
+)
+
+// TaskIdentity is a task of a retrospective as seen by the reconciliation of the task keys, the Keys are the ones
+// mapped to the task in the TaskKeyMap
+type TaskIdentity struct {
+	ID               uint
+	Key              string
+	TrackerUniqueID  string
+	IsTrackerTask    bool
+	TrackerUpdatedAt *time.Time
+	Keys             []string
+}
+
+// TaskKey is a key mapped to a task
+type TaskKey struct {
+	TaskID uint
+	Key    string
+}
+
+// TaskMerge merges the duplicate tasks into the task
+type TaskMerge struct {
+	TaskID       uint
+	DuplicateIDs []uint
+}
+
+// TaskReconciliation lists the tasks to merge and the stale keys to remove before merging them
+type TaskReconciliation struct {
+	Merges    []TaskMerge
+	StaleKeys []TaskKey
+}
+
+// reconciledTicket is a ticket of the tracker along with the keys of all of its tasks
+type reconciledTicket struct {
+	TaskIdentity
+	keys []TaskKey
+}
+
+// ownsKeyBefore checks if the ticket has a better claim than the other ticket on a key they share, the ticket
+// currently having the key wins over the ones it was an alias of, then the most recently updated one does
+func (ticket *reconciledTicket) ownsKeyBefore(other *reconciledTicket, key string) bool {
+	if (ticket.Key == key) != (other.Key == key) {
+		return ticket.Key == key
+	}
+	return ticket.TaskIdentity.updatedAfter(other.TaskIdentity)
+}
+
+// updatedAfter checks if the task was updated in the tracker after the other task, the later created task is
+// taken as the more recent one when the update times do not tell
+func (task TaskIdentity) updatedAfter(other TaskIdentity) bool {
+	if task.TrackerUpdatedAt != nil && other.TrackerUpdatedAt != nil {
+		if !task.TrackerUpdatedAt.Equal(*other.TrackerUpdatedAt) {
+			return task.TrackerUpdatedAt.After(*other.TrackerUpdatedAt)
+		}
+	} else if task.TrackerUpdatedAt != nil || other.TrackerUpdatedAt != nil {
+		return task.TrackerUpdatedAt != nil
+	}
+	return task.ID > other.ID
+}
+
+// getTaskKeys returns the distinct keys of the task, its current key being the first
+func getTaskKeys(task TaskIdentity) []string {
+	var keys []string
+	seen := make(map[string]bool)
+	for _, key := range append([]string{task.Key}, task.Keys...) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ReconcileTaskKeys finds the duplicate tasks of the tickets whose key changed, i.e. the tickets moved to another
+// project or renamed in the tracker.
+// The tasks synced from the tracker are the same ticket when they have the same TrackerUniqueID, the first synced
+// one is kept. A key is never shared by different tickets, when the tracker reuses the key of a moved ticket the
+// key belongs to the ticket currently having it and is stale for the other ones.
+// The tasks created from the time logs alone, whose ticket could not be fetched, are merged into the ticket their
+// key belongs to, preferably the one of their current key. The ones of the same key are merged together otherwise.
+func ReconcileTaskKeys(tasks []TaskIdentity) TaskReconciliation {
+	sortedTasks := append([]TaskIdentity{}, tasks...)
+	sort.Slice(sortedTasks, func(i, j int) bool {
+		return sortedTasks[i].ID < sortedTasks[j].ID
+	})
+
+	merges := make(map[uint][]uint)
+	var staleKeys []TaskKey
+
+	var tickets []*reconciledTicket
+	trackerTickets := make(map[string]*reconciledTicket)
+	var untrackedTasks []TaskIdentity
+	for _, task := range sortedTasks {
+		if !task.IsTrackerTask || task.TrackerUniqueID == "" {
+			untrackedTasks = append(untrackedTasks, task)
+			continue
+		}
+		var keys []TaskKey
+		for _, key := range getTaskKeys(task) {
+			keys = append(keys, TaskKey{TaskID: task.ID, Key: key})
+		}
+		ticket, exists := trackerTickets[task.TrackerUniqueID]
+		if !exists {
+			ticket = &reconciledTicket{TaskIdentity: task, keys: keys}
+			trackerTickets[task.TrackerUniqueID] = ticket
+			tickets = append(tickets, ticket)
+			continue
+		}
+		merges[ticket.ID] = append(merges[ticket.ID], task.ID)
+		ticket.keys = append(ticket.keys, keys...)
+		// The ticket is identified by the latest key and update time of its tasks
+		if task.updatedAfter(ticket.TaskIdentity) {
+			ticket.Key = task.Key
+			ticket.TrackerUpdatedAt = task.TrackerUpdatedAt
+		}
+	}
+
+	keyOwners := make(map[string]*reconciledTicket)
+	for _, ticket := range tickets {
+		for _, taskKey := range ticket.keys {
+			owner, exists := keyOwners[taskKey.Key]
+			if !exists || (owner != ticket && ticket.ownsKeyBefore(owner, taskKey.Key)) {
+				keyOwners[taskKey.Key] = ticket
+			}
+		}
+	}
+	for _, ticket := range tickets {
+		for _, taskKey := range ticket.keys {
+			if keyOwners[taskKey.Key] != ticket {
+				staleKeys = append(staleKeys, taskKey)
+			}
+		}
+	}
+
+	untrackedKeyTasks := make(map[string]uint)
+	for _, task := range untrackedTasks {
+		keys := getTaskKeys(task)
+		var owner *reconciledTicket
+		for _, key := range keys {
+			if owner = keyOwners[key]; owner != nil {
+				break
+			}
+		}
+		if owner != nil {
+			merges[owner.ID] = append(merges[owner.ID], task.ID)
+			for _, key := range keys {
+				if keyOwner, exists := keyOwners[key]; exists && keyOwner != owner {
+					staleKeys = append(staleKeys, TaskKey{TaskID: task.ID, Key: key})
+				}
+			}
+			continue
+		}
+		if taskID, exists := untrackedKeyTasks[task.Key]; exists && task.Key != "" {
+			merges[taskID] = append(merges[taskID], task.ID)
+			continue
+		}
+		untrackedKeyTasks[task.Key] = task.ID
+	}
+
+	reconciliation := TaskReconciliation{StaleKeys: staleKeys}
+	for taskID, duplicateIDs := range merges {
+		sort.Slice(duplicateIDs, func(i, j int) bool {
+			return duplicateIDs[i] < duplicateIDs[j]
+		})
+		reconciliation.Merges = append(reconciliation.Merges, TaskMerge{TaskID: taskID, DuplicateIDs: duplicateIDs})
+	}
+	sort.Slice(reconciliation.Merges, func(i, j int) bool {
+		return reconciliation.Merges[i].TaskID < reconciliation.Merges[j].TaskID
+	})
+	sort.Slice(reconciliation.StaleKeys, func(i, j int) bool {
+		if reconciliation.StaleKeys[i].TaskID != reconciliation.StaleKeys[j].TaskID {
+			return reconciliation.StaleKeys[i].TaskID < reconciliation.StaleKeys[j].TaskID
+		}
+		return reconciliation.StaleKeys[i].Key < reconciliation.StaleKeys[j].Key
+	})
+	return reconciliation
+}
+
+// MergeSprintMemberTasks merges the sprint member task of a duplicate task into the one of the task, for the same
+// sprint member. The time and the points are added up, the role of the task is kept and so is its rating unless
+// it is the default one. The comments are joined and the points stay locked when either of them is locked.
+func MergeSprintMemberTasks(smt SprintMemberTask, duplicate SprintMemberTask) SprintMemberTask {
+	smt.TimeSpentMinutes += duplicate.TimeSpentMinutes
+	smt.PointsEarned += duplicate.PointsEarned
+	smt.PointsAssigned += duplicate.PointsAssigned
+	smt.PlannedPoints += duplicate.PlannedPoints
+	if smt.Rating == retrospective.DecentRating {
+		smt.Rating = duplicate.Rating
+	}
+	comment := strings.TrimSpace(duplicate.Comment)
+	if comment != "" && !strings.Contains(smt.Comment, comment) {
+		smt.Comment = strings.TrimSpace(strings.Join([]string{smt.Comment, comment}, "\n"))
+	}
+	if !smt.PointsLocked && duplicate.PointsLocked {
+		smt.PointsLocked = true
+		smt.PointsLockedByID = duplicate.PointsLockedByID
+		smt.PointsLockReason = duplicate.PointsLockReason
+		smt.PointsLockedAt = duplicate.PointsLockedAt
+	}
+	return smt
+}
@@ -0,0 +1,172 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/apps/retrospective"
+)
+
+func trackerTask(id uint, trackerUniqueID string, key string, keys ...string) TaskIdentity {
+	return TaskIdentity{ID: id, Key: key, TrackerUniqueID: trackerUniqueID, IsTrackerTask: true, Keys: keys}
+}
+
+// timeLogTask is a task created from the time logs alone, its key is its TrackerUniqueID
+func timeLogTask(id uint, key string, keys ...string) TaskIdentity {
+	return TaskIdentity{ID: id, Key: key, TrackerUniqueID: key, Keys: keys}
+}
+
+func assertReconciliation(t *testing.T, expected TaskReconciliation, tasks ...TaskIdentity) {
+	reconciliation := ReconcileTaskKeys(tasks)
+	if !reflect.DeepEqual(expected, reconciliation) {
+		t.Fatalf("Reconciliation should be %+v, got %+v", expected, reconciliation)
+	}
+}
+
+func TestReconcileTaskKeysWithoutDuplicates(t *testing.T) {
+	assertReconciliation(t, TaskReconciliation{},
+		trackerTask(1, "10001", "PRJ-1", "PRJ-1"),
+		trackerTask(2, "10002", "PRJ-2", "PRJ-2"),
+		timeLogTask(3, "PRJ-3", "PRJ-3"),
+	)
+}
+
+func TestReconcileTaskKeysOfSameTicket(t *testing.T) {
+	assertReconciliation(t, TaskReconciliation{Merges: []TaskMerge{{TaskID: 1, DuplicateIDs: []uint{3}}}},
+		trackerTask(3, "10001", "NEW-1", "NEW-1"),
+		trackerTask(1, "10001", "OLD-1", "OLD-1"),
+		trackerTask(2, "10002", "OLD-2", "OLD-2"),
+	)
+}
+
+func TestReconcileTaskKeysOfMovedTicket(t *testing.T) {
+	// The time logged against the old key before the ticket could be fetched created a task of its own
+	assertReconciliation(t, TaskReconciliation{Merges: []TaskMerge{{TaskID: 2, DuplicateIDs: []uint{1}}}},
+		timeLogTask(1, "OLD-1", "OLD-1"),
+		trackerTask(2, "10001", "NEW-1", "NEW-1", "OLD-1"),
+	)
+}
+
+func TestReconcileTaskKeysOfReusedKey(t *testing.T) {
+	// OLD-1 was moved to NEW-1 and the key OLD-1 was then given to another ticket, the tickets are not merged
+	assertReconciliation(t, TaskReconciliation{
+		Merges:    []TaskMerge{{TaskID: 2, DuplicateIDs: []uint{3}}},
+		StaleKeys: []TaskKey{{TaskID: 1, Key: "OLD-1"}},
+	},
+		trackerTask(1, "10001", "NEW-1", "OLD-1", "NEW-1"),
+		trackerTask(2, "10002", "OLD-1", "OLD-1"),
+		timeLogTask(3, "OLD-1", "OLD-1"),
+	)
+}
+
+func TestReconcileTaskKeysOfAliasConflict(t *testing.T) {
+	// Neither of the tickets currently has the key, the most recently updated one keeps it
+	updatedAt := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	laterUpdatedAt := updatedAt.Add(time.Hour)
+	first := trackerTask(1, "10001", "NEW-1", "OLD-1", "NEW-1")
+	first.TrackerUpdatedAt = &laterUpdatedAt
+	second := trackerTask(2, "10002", "NEW-2", "OLD-1", "NEW-2")
+	second.TrackerUpdatedAt = &updatedAt
+
+	assertReconciliation(t, TaskReconciliation{StaleKeys: []TaskKey{{TaskID: 2, Key: "OLD-1"}}}, first, second)
+
+	second.TrackerUpdatedAt = &laterUpdatedAt
+	assertReconciliation(t, TaskReconciliation{StaleKeys: []TaskKey{{TaskID: 1, Key: "OLD-1"}}}, first, second)
+}
+
+func TestReconcileTaskKeysOfTimeLogTaskOfDifferentTickets(t *testing.T) {
+	// The task is merged into the ticket of its own key, the key of the other ticket is not carried over
+	assertReconciliation(t, TaskReconciliation{
+		Merges:    []TaskMerge{{TaskID: 2, DuplicateIDs: []uint{3}}},
+		StaleKeys: []TaskKey{{TaskID: 3, Key: "PRJ-1"}},
+	},
+		trackerTask(1, "10001", "PRJ-1", "PRJ-1"),
+		trackerTask(2, "10002", "PRJ-2", "PRJ-2"),
+		timeLogTask(3, "PRJ-2", "PRJ-2", "PRJ-1"),
+	)
+}
+
+func TestReconcileTaskKeysOfDuplicateWithReusedKey(t *testing.T) {
+	// The key carried by the duplicate of the first ticket now belongs to the second ticket
+	assertReconciliation(t, TaskReconciliation{
+		Merges:    []TaskMerge{{TaskID: 1, DuplicateIDs: []uint{2}}},
+		StaleKeys: []TaskKey{{TaskID: 2, Key: "PRJ-3"}},
+	},
+		trackerTask(1, "10001", "PRJ-1", "PRJ-1"),
+		trackerTask(2, "10001", "NEW-1", "NEW-1", "PRJ-3"),
+		trackerTask(3, "10003", "PRJ-3", "PRJ-3"),
+	)
+}
+
+func TestReconcileTaskKeysOfTimeLogTasks(t *testing.T) {
+	assertReconciliation(t, TaskReconciliation{Merges: []TaskMerge{{TaskID: 1, DuplicateIDs: []uint{2, 4}}}},
+		timeLogTask(4, "PRJ-1", "PRJ-1"),
+		timeLogTask(1, "PRJ-1", "PRJ-1"),
+		timeLogTask(3, "PRJ-3", "PRJ-3"),
+		timeLogTask(2, "PRJ-1"),
+	)
+}
+
+func TestMergeSprintMemberTasks(t *testing.T) {
+	lockedByID := uint(7)
+	smt := SprintMemberTask{
+		Model:            gorm.Model{ID: 1},
+		SprintMemberID:   3,
+		TimeSpentMinutes: 60,
+		PointsEarned:     1,
+		PointsAssigned:   1,
+		Rating:           retrospective.DecentRating,
+		Comment:          "Reviewed",
+		Role:             Reviewer,
+	}
+	duplicate := SprintMemberTask{
+		Model:            gorm.Model{ID: 2},
+		SprintMemberID:   3,
+		TimeSpentMinutes: 30,
+		PointsEarned:     0.5,
+		PlannedPoints:    2,
+		Rating:           retrospective.GoodRating,
+		Comment:          "Fixed the tests",
+		Role:             Developer,
+		PointsLocked:     true,
+		PointsLockedByID: &lockedByID,
+		PointsLockReason: "Agreed in the retro",
+	}
+
+	merged := MergeSprintMemberTasks(smt, duplicate)
+	expected := smt
+	expected.TimeSpentMinutes = 90
+	expected.PointsEarned = 1.5
+	expected.PlannedPoints = 2
+	expected.Rating = retrospective.GoodRating
+	expected.Comment = "Reviewed\nFixed the tests"
+	expected.PointsLocked = true
+	expected.PointsLockedByID = &lockedByID
+	expected.PointsLockReason = "Agreed in the retro"
+	if !reflect.DeepEqual(expected, merged) {
+		t.Fatalf("Merged sprint member task should be %+v, got %+v", expected, merged)
+	}
+}
+
+func TestMergeSprintMemberTasksKeepsRatingAndLock(t *testing.T) {
+	smt := SprintMemberTask{
+		Rating:           retrospective.NotableRating,
+		Comment:          "Fixed the tests",
+		PointsLocked:     true,
+		PointsLockReason: "Agreed in the retro",
+	}
+	duplicate := SprintMemberTask{
+		Rating:           retrospective.ConcernRating,
+		Comment:          "Fixed the tests",
+		PointsLocked:     true,
+		PointsLockReason: "Synced",
+	}
+
+	merged := MergeSprintMemberTasks(smt, duplicate)
+	if !reflect.DeepEqual(smt, merged) {
+		t.Fatalf("Merged sprint member task should be %+v, got %+v", smt, merged)
+	}
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// TaskStatusTransition is a change of the status of a task in the task tracker, captured during the sync
+type TaskStatusTransition struct {
+	gorm.Model
+	Task           Task
+	TaskID         uint      `gorm:"not null"`
+	FromStatus     string    `gorm:"type:varchar(50); not null; default:''"`
+	ToStatus       string    `gorm:"type:varchar(50); not null"`
+	TransitionedAt time.Time `gorm:"not null"`
+	TransitionedBy string    `gorm:"type:varchar(100); not null; default:''"`
+}
+
+// TaskJoinStatusTransitions ...
+func TaskJoinStatusTransitions(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN task_status_transitions ON tasks.id = task_status_transitions.task_id AND task_status_transitions.deleted_at IS NULL")
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/jinzhu/gorm"
 
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/db/models/fields"
 )
 
 // Trail represents an action on a retrospective item
@@ -13,7 +15,8 @@ type Trail struct {
 	ActionItem   string `gorm:"type:varchar(255); not null"`
 	ActionItemID uint   `gorm:"not null"`
 	ActionBy     userModels.User
-	ActionByID   uint `gorm:"not null"`
+	ActionByID   uint         `gorm:"not null"`
+	Changes      fields.JSONB `gorm:"type:jsonb"`
 }
 
 // TrailJoinSM ...
@@ -35,3 +38,36 @@ func TrailJoinSMT(db *gorm.DB) *gorm.DB {
 func TrailJoinFeedback(db *gorm.DB) *gorm.DB {
 	return db.Joins("JOIN retrospective_feedbacks ON trails.action_item_id = retrospective_feedbacks.id")
 }
+
+// TrailJoinActionItems joins the action items of all the types along with the sprint and the retrospective they
+// belong to, use TrailSprintID and TrailRetrospectiveID to refer to them
+func TrailJoinActionItems(db *gorm.DB) *gorm.DB {
+	return db.
+		Joins(`LEFT JOIN sprints AS trail_sprints ON trails.action_item = ?
+            AND trail_sprints.id = trails.action_item_id`,
+			constants.ActionItemTypeMap[constants.Sprint]).
+		Joins(`LEFT JOIN sprint_members AS trail_sprint_members ON trails.action_item = ?
+            AND trail_sprint_members.id = trails.action_item_id`,
+			constants.ActionItemTypeMap[constants.SprintMember]).
+		Joins(`LEFT JOIN sprint_tasks AS trail_sprint_tasks ON trails.action_item = ?
+            AND trail_sprint_tasks.id = trails.action_item_id`,
+			constants.ActionItemTypeMap[constants.SprintTask]).
+		Joins(`LEFT JOIN sprint_member_tasks AS trail_sprint_member_tasks ON trails.action_item = ?
+            AND trail_sprint_member_tasks.id = trails.action_item_id`,
+			constants.ActionItemTypeMap[constants.SprintMemberTask]).
+		Joins(`LEFT JOIN sprint_tasks AS trail_smt_sprint_tasks
+            ON trail_smt_sprint_tasks.id = trail_sprint_member_tasks.sprint_task_id`).
+		Joins(`LEFT JOIN retrospective_feedbacks AS trail_feedbacks ON trails.action_item = ?
+            AND trail_feedbacks.id = trails.action_item_id`,
+			constants.ActionItemTypeMap[constants.RetrospectiveFeedback]).
+		Joins(`LEFT JOIN sprints AS trail_item_sprints ON trail_item_sprints.id = ` + TrailSprintID).
+		Joins(`LEFT JOIN retrospectives AS trail_retrospectives ON trail_retrospectives.id = ` + TrailRetrospectiveID)
+}
+
+// TrailSprintID is the sprint of the action item of a trail, it requires TrailJoinActionItems
+const TrailSprintID = `COALESCE(trail_sprints.id, trail_sprint_members.sprint_id, trail_sprint_tasks.sprint_id,
+    trail_smt_sprint_tasks.sprint_id)`
+
+// TrailRetrospectiveID is the retrospective of the action item of a trail, it requires TrailJoinActionItems
+const TrailRetrospectiveID = `COALESCE(CASE WHEN trails.action_item = 'Retrospective' THEN trails.action_item_id END,
+    trail_item_sprints.retrospective_id, trail_feedbacks.retrospective_id)`
@@ -0,0 +1,28 @@
+package serializers
+
+import (
+	"time"
+
+	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
+)
+
+// GoalComment ...
+type GoalComment struct {
+	ID          uint
+	GoalID      uint
+	Text        string
+	CreatedByID uint
+	CreatedBy   userSerializers.User
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// GoalCommentListSerializer ...
+type GoalCommentListSerializer struct {
+	Comments []GoalComment
+}
+
+// GoalCommentSerializer is used to add or edit a comment
+type GoalCommentSerializer struct {
+	Text string `json:"Text" binding:"required"`
+}
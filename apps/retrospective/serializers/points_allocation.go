@@ -0,0 +1,46 @@
+package serializers
+
+// PointsAllocationMember is the share of a member in the points of a task, along with the time it is based upon
+type PointsAllocationMember struct {
+	SprintMemberTaskID uint
+	SprintMemberID     uint
+	MemberName         string
+	Role               int8
+	TimeSpentMinutes   uint
+	PointsAssigned     float64
+	PointsEarned       float64
+	PointsLocked       bool
+	PointsLockedBy     string
+	PointsLockReason   string
+	ProposedPoints     float64
+}
+
+// PointsAllocationTask explains the allocation of the points of a done task of the sprint.
+// RemainingPoints is the estimate less the points earned on the task in the other sprints, what is left of it
+// after the LockedPoints of the members with locked points is split among the other members.
+type PointsAllocationTask struct {
+	SprintTaskID             uint
+	TaskID                   uint
+	Key                      string
+	Summary                  string
+	Assignee                 string
+	Estimate                 float64
+	TotalTimeSpentMinutes    uint
+	OtherSprintsPointsEarned float64
+	RemainingPoints          float64
+	LockedPoints             float64
+	Members                  []*PointsAllocationMember
+}
+
+// PointsAllocationSerializer is the points allocation of the done tasks of a sprint
+type PointsAllocationSerializer struct {
+	Strategy             string
+	ReviewerPointsWeight float64
+	Tasks                []*PointsAllocationTask
+}
+
+// PointsAllocationApply serializer to apply the proposed points allocation, with OverrideLocks the locked points
+// are re-allocated as well and unlocked
+type PointsAllocationApply struct {
+	OverrideLocks bool `json:"OverrideLocks"`
+}
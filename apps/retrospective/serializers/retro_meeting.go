@@ -0,0 +1,80 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/retrospective/models"
+	userSerializer "github.com/iReflect/reflect-app/apps/user/serializers"
+)
+
+// Retro meeting timer actions
+const (
+	StartRetroMeetingTimer  = "start"
+	PauseRetroMeetingTimer  = "pause"
+	ResumeRetroMeetingTimer = "resume"
+	ResetRetroMeetingTimer  = "reset"
+)
+
+// RetroMeeting is the state of the retro meeting as seen by a user
+type RetroMeeting struct {
+	ID             uint
+	SprintID       uint
+	FacilitatorID  uint
+	Facilitator    userSerializer.User
+	Phase          models.RetroMeetingPhase
+	VotesPerUser   uint
+	VotesRemaining uint
+	Timer          RetroMeetingTimer
+	Groups         []RetroMeetingGroup
+	UngroupedItems []RetrospectiveFeedback
+}
+
+// RetroMeetingTimer ...
+type RetroMeetingTimer struct {
+	Duration  uint
+	Remaining uint
+	Running   bool
+	EndsAt    *time.Time
+}
+
+// RetroMeetingGroup ...
+type RetroMeetingGroup struct {
+	ID            uint
+	Title         string
+	Items         []RetrospectiveFeedback
+	VoteCount     uint
+	UserVoteCount uint
+	GoalID        *uint
+}
+
+// RetroMeetingUpdateSerializer ...
+type RetroMeetingUpdateSerializer struct {
+	Phase         *int8 `json:"Phase"`
+	VotesPerUser  *uint `json:"VotesPerUser"`
+	FacilitatorID *uint `json:"FacilitatorID"`
+}
+
+// RetroMeetingTimerSerializer ...
+type RetroMeetingTimerSerializer struct {
+	Action   string `json:"Action" binding:"required"`
+	Duration uint   `json:"Duration"`
+}
+
+// RetroMeetingGroupSerializer ...
+type RetroMeetingGroupSerializer struct {
+	Title       string `json:"Title" binding:"required"`
+	FeedbackIDs []uint `json:"FeedbackIDs"`
+}
+
+// RetroMeetingGroupItemSerializer ...
+type RetroMeetingGroupItemSerializer struct {
+	FeedbackID uint `json:"FeedbackID" binding:"required"`
+}
+
+// RetroMeetingGoalSerializer is the outcome of discussing a group
+type RetroMeetingGoalSerializer struct {
+	SubType    string     `json:"SubType" binding:"required"`
+	Text       string     `json:"Text" binding:"required"`
+	AssigneeID *uint      `json:"AssigneeID"`
+	ExpectedAt *time.Time `json:"ExpectedAt"`
+}
@@ -0,0 +1,26 @@
+package serializers
+
+import (
+	"github.com/iReflect/reflect-app/apps/retrospective/models"
+)
+
+// RetroTemplate ...
+type RetroTemplate struct {
+	ID          uint
+	Name        string
+	Description string
+	SubTypes    []RetroTemplateSubType `gorm:"foreignkey:TemplateID"`
+}
+
+// RetroTemplateSubType ...
+type RetroTemplateSubType struct {
+	ID         uint
+	TemplateID uint
+	Type       models.RetrospectiveFeedbackType
+	Name       string
+}
+
+// RetroTemplateListSerializer ...
+type RetroTemplateListSerializer struct {
+	Templates []RetroTemplate
+}
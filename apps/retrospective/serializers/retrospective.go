@@ -9,18 +9,27 @@ import (
 
 // Retrospective ...
 type Retrospective struct {
-	ID                 uint
-	Title              string
-	ProjectName        string
-	Team               userSerializer.Team
-	TeamID             uint
-	UpdatedAt          time.Time
-	CreatedBy          userSerializer.User
-	CreatedByID        uint
-	CreatedAt          time.Time
-	TaskProviderConfig fields.JSONB
-	TimeProviderName   string
-	StoryPointPerWeek  float64
+	ID                       uint
+	Title                    string
+	ProjectName              string
+	Team                     userSerializer.Team
+	TeamID                   uint
+	UpdatedAt                time.Time
+	CreatedBy                userSerializer.User
+	CreatedByID              uint
+	CreatedAt                time.Time
+	TaskProviderConfig       fields.JSONB
+	TimeProviderName         string
+	StoryPointPerWeek        float64
+	AnonymousFeedback        bool
+	TemplateID               *uint
+	TrackerWriteBack         bool
+	PointsAllocationStrategy int8
+	ReviewerPointsWeight     float64
+	SprintLengthDays         int
+	SprintStartWeekday       int8
+	SprintTitlePattern       string
+	RollUpSubtasks           bool
 }
 
 // EditLevel ...
@@ -56,25 +65,43 @@ func (retroFieldsEditLevelMap RetroFieldsEditLevelMap) Validate() bool {
 
 // RetrospectiveCreateSerializer ...
 type RetrospectiveCreateSerializer struct {
-	Title              string                   `json:"title" binding:"required"`
-	ProjectName        string                   `json:"projectName" binding:"required"`
-	TaskProviderConfig []map[string]interface{} `json:"taskProvider" binding:"required,is_valid_task_provider_config"`
-	TeamID             uint                     `json:"team" binding:"required,is_valid_team"`
-	StoryPointPerWeek  float64                  `json:"storyPointPerWeek" binding:"required"`
-	TimeProviderName   string                   `json:"timeProviderKey" binding:"required"`
-	CreatedByID        uint
+	Title                    string                   `json:"title" binding:"required"`
+	ProjectName              string                   `json:"projectName" binding:"required"`
+	TaskProviderConfig       []map[string]interface{} `json:"taskProvider" binding:"required,is_valid_task_provider_config"`
+	TeamID                   uint                     `json:"team" binding:"required,is_valid_team"`
+	StoryPointPerWeek        float64                  `json:"storyPointPerWeek" binding:"required"`
+	TimeProviderName         string                   `json:"timeProviderKey" binding:"required"`
+	AnonymousFeedback        bool                     `json:"anonymousFeedback"`
+	TemplateID               *uint                    `json:"templateID" binding:"omitempty,is_valid_retro_template"`
+	TrackerWriteBack         bool                     `json:"trackerWriteBack"`
+	PointsAllocationStrategy int8                     `json:"pointsAllocationStrategy" binding:"is_valid_points_allocation_strategy"`
+	ReviewerPointsWeight     *float64                 `json:"reviewerPointsWeight" binding:"omitempty,min=0,max=1"`
+	SprintLengthDays         int                      `json:"sprintLengthDays" binding:"min=0,max=90"`
+	SprintStartWeekday       int8                     `json:"sprintStartWeekday" binding:"min=0,max=6"`
+	SprintTitlePattern       string                   `json:"sprintTitlePattern" binding:"max=255"`
+	RollUpSubtasks           bool                     `json:"rollUpSubtasks"`
+	CreatedByID              uint
 }
 
 // RetrospectiveUpdateSerializer ...
 type RetrospectiveUpdateSerializer struct {
-	RetroID            uint                     `json:"retroID" binding:"required"`
-	Title              string                   `json:"title" binding:"required"`
-	ProjectName        string                   `json:"projectName" binding:"required"`
-	TaskProviderConfig []map[string]interface{} `json:"taskProvider" binding:"required,is_valid_task_provider_config"`
-	TeamID             uint                     `json:"team" binding:"required,is_valid_team"`
-	StoryPointPerWeek  float64                  `json:"storyPointPerWeek" binding:"required"`
-	TimeProviderName   string                   `json:"timeProviderKey" binding:"required"`
-	CredentialsChanged bool                     `json:"credentialsChanged"`
+	RetroID                  uint                     `json:"retroID" binding:"required"`
+	Title                    string                   `json:"title" binding:"required"`
+	ProjectName              string                   `json:"projectName" binding:"required"`
+	TaskProviderConfig       []map[string]interface{} `json:"taskProvider" binding:"required,is_valid_task_provider_config"`
+	TeamID                   uint                     `json:"team" binding:"required,is_valid_team"`
+	StoryPointPerWeek        float64                  `json:"storyPointPerWeek" binding:"required"`
+	TimeProviderName         string                   `json:"timeProviderKey" binding:"required"`
+	CredentialsChanged       bool                     `json:"credentialsChanged"`
+	AnonymousFeedback        bool                     `json:"anonymousFeedback"`
+	TemplateID               *uint                    `json:"templateID" binding:"omitempty,is_valid_retro_template"`
+	TrackerWriteBack         bool                     `json:"trackerWriteBack"`
+	PointsAllocationStrategy int8                     `json:"pointsAllocationStrategy" binding:"is_valid_points_allocation_strategy"`
+	ReviewerPointsWeight     *float64                 `json:"reviewerPointsWeight" binding:"omitempty,min=0,max=1"`
+	SprintLengthDays         int                      `json:"sprintLengthDays" binding:"min=0,max=90"`
+	SprintStartWeekday       int8                     `json:"sprintStartWeekday" binding:"min=0,max=6"`
+	SprintTitlePattern       string                   `json:"sprintTitlePattern" binding:"max=255"`
+	RollUpSubtasks           bool                     `json:"rollUpSubtasks"`
 }
 
 // RetrospectiveListSerializer ...
@@ -0,0 +1,179 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/iReflect/reflect-app/apps/retrospective"
+	"github.com/iReflect/reflect-app/apps/retrospective/models"
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+// RetrospectiveExportVersion is the version of the export document, bump it on every incompatible change
+const RetrospectiveExportVersion = 1
+
+// RetrospectiveExport is a portable document of a whole retrospective. IDs in the document are only used
+// to reference the items within the document and the users are referenced by their email.
+// The secret credentials of the task providers are left out of the export, the ones of the importing server
+// are to be given in TaskProviderCredentials, in the order of the task providers.
+type RetrospectiveExport struct {
+	Version                 int
+	ExportedAt              time.Time
+	Retrospective           ExportedRetrospective
+	TaskProviderCredentials []map[string]interface{} `json:",omitempty"`
+}
+
+// ExportedRetrospective ...
+type ExportedRetrospective struct {
+	ID                       uint
+	Title                    string
+	ProjectName              string
+	TaskProviderConfig       fields.JSONB
+	TimeProviderName         string
+	TeamName                 string
+	StoryPointPerWeek        float64
+	AnonymousFeedback        bool
+	TrackerWriteBack         bool
+	PointsAllocationStrategy models.PointsAllocationStrategy
+	ReviewerPointsWeight     float64
+	SprintLengthDays         int
+	SprintStartWeekday       int8
+	SprintTitlePattern       string
+	RollUpSubtasks           bool
+	Template                 *string
+	CreatedBy                string
+	CreatedAt                time.Time
+	Tasks                    []ExportedTask
+	Sprints                  []ExportedSprint
+	Feedbacks                []ExportedRetrospectiveFeedback
+	Trails                   []ExportedTrail
+}
+
+// ExportedTask ...
+type ExportedTask struct {
+	ID                    uint
+	Key                   string
+	Keys                  []string
+	TrackerUniqueID       string
+	Summary               string
+	Description           string
+	Type                  string
+	Status                string
+	Priority              string
+	Assignee              string
+	Estimate              float64
+	Fields                fields.JSONB
+	Rating                retrospective.Rating
+	DoneAt                *time.Time
+	IsTrackerTask         bool
+	Resolution            models.Resolution
+	ParentTrackerUniqueID string
+	EpicKey               string
+	TrackerCreatedAt      *time.Time
+	Transitions           []ExportedTaskStatusTransition
+}
+
+// ExportedTaskStatusTransition ...
+type ExportedTaskStatusTransition struct {
+	FromStatus     string
+	ToStatus       string
+	TransitionedAt time.Time
+	TransitionedBy string
+}
+
+// ExportedSprint ...
+type ExportedSprint struct {
+	ID           uint
+	Title        string
+	SprintID     string
+	Status       models.SprintStatus
+	StartDate    *time.Time
+	EndDate      *time.Time
+	LastSyncedAt *time.Time
+	CreatedBy    string
+	CreatedAt    time.Time
+	Members      []ExportedSprintMember
+	Tasks        []ExportedSprintTask
+}
+
+// ExportedSprintMember ...
+type ExportedSprintMember struct {
+	ID                 uint
+	Member             string
+	AllocationPercent  float64
+	ExpectationPercent float64
+	Vacations          float64
+	Rating             retrospective.Rating
+	Comment            string
+	Leaves             []ExportedSprintMemberLeave
+}
+
+// ExportedSprintMemberLeave ...
+type ExportedSprintMemberLeave struct {
+	Date    time.Time
+	Type    models.LeaveType
+	HalfDay bool
+	Note    string
+}
+
+// ExportedSprintTask ...
+type ExportedSprintTask struct {
+	ID              uint
+	TaskID          uint
+	PlannedEstimate *float64
+	InTrackerSprint bool
+	MemberTasks     []ExportedSprintMemberTask
+}
+
+// ExportedSprintMemberTask is a sprint member task, the author of the points lock is not exported
+type ExportedSprintMemberTask struct {
+	ID               uint
+	SprintMemberID   uint
+	TimeSpentMinutes uint
+	PointsEarned     float64
+	PointsAssigned   float64
+	PlannedPoints    float64
+	Rating           retrospective.Rating
+	Comment          string
+	Role             models.MemberTaskRole
+	PointsLocked     bool
+	PointsLockReason string
+	PointsLockedAt   *time.Time
+}
+
+// ExportedRetrospectiveFeedback is a retrospective feedback, CreatedBy is empty for the anonymous ones
+type ExportedRetrospectiveFeedback struct {
+	ID                uint
+	SubType           string
+	Type              models.RetrospectiveFeedbackType
+	Text              string
+	Scope             models.RetrospectiveFeedbackScope
+	Assignee          *string
+	AddedAt           *time.Time
+	ResolvedAt        *time.Time
+	ExpectedAt        *time.Time
+	Anonymous         bool
+	Progress          models.GoalProgress
+	CarryOverCount    uint
+	TrackerTaskKey    *string
+	TrackerTaskStatus string
+	CreatedBy         string
+	CreatedAt         time.Time
+	Comments          []ExportedGoalComment
+}
+
+// ExportedGoalComment is a comment on a goal
+type ExportedGoalComment struct {
+	Text      string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// ExportedTrail is a trail, ActionBy is empty for the trails of the anonymous feedbacks
+type ExportedTrail struct {
+	Action       string
+	ActionItem   string
+	ActionItemID uint
+	ActionBy     string
+	Changes      fields.JSONB
+	CreatedAt    time.Time
+}
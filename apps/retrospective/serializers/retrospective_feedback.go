@@ -8,32 +8,45 @@ import (
 
 // RetrospectiveFeedback ...
 type RetrospectiveFeedback struct {
-	ID              uint
-	SubType         string
-	Type            models.RetrospectiveFeedbackType
-	RetrospectiveID uint
-	Text            string
-	Scope           models.RetrospectiveFeedbackScope
-	AssigneeID      *uint
-	Assignee        *serializers.User
-	AddedAt         *time.Time
-	ResolvedAt      *time.Time
-	ExpectedAt      *time.Time
-	CreatedByID     uint
-	CreatedBy       serializers.User
+	ID                 uint
+	SubType            string
+	Type               models.RetrospectiveFeedbackType
+	RetrospectiveID    uint
+	Text               string
+	Scope              models.RetrospectiveFeedbackScope
+	AssigneeID         *uint
+	Assignee           *serializers.User
+	AddedAt            *time.Time
+	ResolvedAt         *time.Time
+	ExpectedAt         *time.Time
+	CreatedByID        uint
+	CreatedBy          serializers.User
+	Anonymous          bool
+	Progress           models.GoalProgress
+	CarryOverCount     uint
+	TrackerTaskKey     *string
+	TrackerTaskStatus  string
+	TrackerTaskPending bool
 }
 
 // RetrospectiveFeedbackUpdateSerializer ...
+// Progress and TrackerTaskKey can be updated only for the goals, an empty TrackerTaskKey unlinks the ticket.
 type RetrospectiveFeedbackUpdateSerializer struct {
-	Text       *string    `json:"Text"`
-	Scope      *int8      `json:"Scope" binding:"omitempty,is_valid_retrospective_feedback_scope"`
-	AssigneeID *uint      `json:"AssigneeID"`
-	ExpectedAt *time.Time `json:"ExpectedAt"`
+	Text           *string    `json:"Text"`
+	Scope          *int8      `json:"Scope" binding:"omitempty,is_valid_retrospective_feedback_scope"`
+	AssigneeID     *uint      `json:"AssigneeID"`
+	ExpectedAt     *time.Time `json:"ExpectedAt"`
+	Progress       *int8      `json:"Progress" binding:"omitempty,is_valid_goal_progress"`
+	TrackerTaskKey *string    `json:"TrackerTaskKey"`
 }
 
 // RetrospectiveFeedbackCreateSerializer ...
+// RetrospectiveID and Type are set from the request's path, the sub-type is validated against them.
 type RetrospectiveFeedbackCreateSerializer struct {
-	SubType string `json:"subType" binding:"required"`
+	SubType         string                           `json:"subType" binding:"required,is_valid_feedback_sub_type"`
+	Anonymous       bool                             `json:"anonymous"`
+	RetrospectiveID string                           `json:"-"`
+	Type            models.RetrospectiveFeedbackType `json:"-"`
 }
 
 // RetrospectiveFeedbackListSerializer ...
@@ -43,7 +43,21 @@ type SprintSummary struct {
 	Holidays         float64
 	TotalVacations   float64
 	TargetSP         float64
+	TargetHours      float64
 	TaskSummary      map[string]SprintTaskSummary
+	ScopeSummary     SprintScopeSummary
+}
+
+// SprintScopeSummary compares the work committed at the start of the sprint with the work added mid-sprint
+type SprintScopeSummary struct {
+	CommittedAt        time.Time
+	CommittedTaskCount uint
+	CommittedEstimate  float64
+	AddedTaskCount     uint
+	AddedEstimate      float64
+	RemovedTaskCount   uint
+	RemovedEstimate    float64
+	ReestimatedCount   uint
 }
 
 // SprintTaskSummary ...
@@ -55,6 +69,21 @@ type SprintTaskSummary struct {
 	ActualHours       uint
 }
 
+// SprintEpicSummary is the summary of the tasks of an epic in the sprint, the tasks without an epic have no EpicKey
+type SprintEpicSummary struct {
+	EpicKey           string
+	Count             uint
+	TotalCount        uint
+	PointsEarned      float64
+	TotalPointsEarned float64
+	ActualHours       uint
+}
+
+// SprintEpicSummarySerializer ...
+type SprintEpicSummarySerializer struct {
+	Epics []SprintEpicSummary
+}
+
 // SprintsSerializer ...
 type SprintsSerializer struct {
 	Sprints []Sprint
@@ -0,0 +1,40 @@
+package serializers
+
+import (
+	"time"
+
+	userSerializer "github.com/iReflect/reflect-app/apps/user/serializers"
+)
+
+// SprintBurndownPoint is the progress of the sprint at the end of a day
+type SprintBurndownPoint struct {
+	Date              time.Time
+	TaskCount         uint
+	DoneTaskCount     uint
+	TotalEstimate     float64
+	DoneEstimate      float64
+	RemainingEstimate float64
+	PointsEarned      float64
+	IdealRemaining    float64
+}
+
+// SprintScopeChangeMarker marks a task entering, leaving or being re-estimated in the sprint
+type SprintScopeChangeMarker struct {
+	Date             time.Time
+	Change           string
+	Source           string
+	TaskID           uint
+	Key              string
+	Summary          string
+	PreviousEstimate float64
+	Estimate         float64
+	ChangedBy        *userSerializer.User
+}
+
+// SprintBurndownSerializer contains the burndown/burnup series of a sprint
+type SprintBurndownSerializer struct {
+	StartDate    time.Time
+	EndDate      time.Time
+	Series       []SprintBurndownPoint
+	ScopeChanges []SprintScopeChangeMarker
+}
@@ -0,0 +1,24 @@
+package serializers
+
+import (
+	"time"
+
+	"github.com/iReflect/reflect-app/constants"
+)
+
+// Sprint event types
+const (
+	SprintItemCreated = "created"
+	SprintItemUpdated = "updated"
+	SprintItemDeleted = "deleted"
+)
+
+// SprintEvent is broadcast to everyone following the sprint when one of its items changes
+type SprintEvent struct {
+	Type         string
+	ActionItem   constants.ActionItemType
+	ActionItemID uint
+	ActionByID   uint
+	Data         interface{}
+	CreatedAt    time.Time
+}
@@ -1,7 +1,10 @@
 package serializers
 
 import (
+	"time"
+
 	"github.com/iReflect/reflect-app/apps/retrospective/models"
+	userSerializer "github.com/iReflect/reflect-app/apps/user/serializers"
 	"github.com/iReflect/reflect-app/libs/utils"
 )
 
@@ -13,17 +16,24 @@ type SprintMemberSummary struct {
 	AllocationPercent   float64
 	ExpectationPercent  float64
 	Vacations           float64
+	UndatedVacations    float64
 	Rating              uint
 	Comment             string
 	ActualStoryPoint    float64
 	TotalTimeSpentInMin float64
 	ExpectedStoryPoint  float64
+	CapacityHours       float64
+	LeaveDaysByType     map[string]float64 `gorm:"-"`
 }
 
-// SetExpectedStoryPoint ...
-func (member *SprintMemberSummary) SetExpectedStoryPoint(sprint models.Sprint, retro models.Retrospective) {
-	member.ExpectedStoryPoint = utils.CalculateExpectedSP(*sprint.StartDate, *sprint.EndDate,
+// SetExpectedStoryPoint sets the expected story points and the working hours of the member as per the working
+// calendar of the team
+func (member *SprintMemberSummary) SetExpectedStoryPoint(sprint models.Sprint, retro models.Retrospective,
+	calendar utils.WorkingCalendar) {
+	member.ExpectedStoryPoint = calendar.CalculateExpectedSP(*sprint.StartDate, *sprint.EndDate,
 		member.Vacations, member.ExpectationPercent, member.AllocationPercent, retro.StoryPointPerWeek)
+	member.CapacityHours = calendar.CalculateCapacityHours(*sprint.StartDate, *sprint.EndDate,
+		member.Vacations, member.AllocationPercent)
 }
 
 // SprintMemberSummaryListSerializer ...
@@ -36,6 +46,59 @@ type SprintMemberUpdate struct {
 	BaseRating
 	AllocationPercent  *float64 `json:"AllocationPercent"`
 	ExpectationPercent *float64 `json:"ExpectationPercent"`
-	Vacations          *float64 `json:"Vacations"`
+	UndatedVacations   *float64 `json:"UndatedVacations"`
 	Comment            *string  `json:"Comment"`
 }
+
+// MemberSprintPerformance is the performance of a member in a single sprint
+type MemberSprintPerformance struct {
+	SprintMemberID      uint
+	SprintID            uint
+	SprintTitle         string
+	SprintStatus        models.SprintStatus
+	StartDate           time.Time
+	EndDate             time.Time
+	RetrospectiveID     uint
+	RetrospectiveTitle  string
+	StoryPointPerWeek   float64
+	AllocationPercent   float64
+	ExpectationPercent  float64
+	Vacations           float64
+	Rating              uint
+	Comment             string
+	ExpectedStoryPoint  float64
+	ActualStoryPoint    float64
+	TotalTimeSpentInMin float64
+	DeveloperTaskCount  uint
+	DeveloperStoryPoint float64
+	DeveloperTimeInMin  float64
+	ReviewerTaskCount   uint
+	ReviewerStoryPoint  float64
+	ReviewerTimeInMin   float64
+	AverageTaskRating   float64
+}
+
+// SetExpectedStoryPoint sets the expected story points as per the working calendar of the team
+func (performance *MemberSprintPerformance) SetExpectedStoryPoint(calendar utils.WorkingCalendar) {
+	performance.ExpectedStoryPoint = calendar.CalculateExpectedSP(performance.StartDate, performance.EndDate,
+		performance.Vacations, performance.ExpectationPercent, performance.AllocationPercent,
+		performance.StoryPointPerWeek)
+}
+
+// MemberPerformanceTotal ...
+type MemberPerformanceTotal struct {
+	SprintCount         int
+	ExpectedStoryPoint  float64
+	ActualStoryPoint    float64
+	TotalTimeSpentInMin float64
+	DeveloperStoryPoint float64
+	ReviewerStoryPoint  float64
+	AverageRating       float64
+}
+
+// MemberPerformanceHistorySerializer ...
+type MemberPerformanceHistorySerializer struct {
+	Member  userSerializer.User
+	Sprints []*MemberSprintPerformance
+	Total   MemberPerformanceTotal
+}
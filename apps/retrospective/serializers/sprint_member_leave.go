@@ -0,0 +1,42 @@
+package serializers
+
+import "time"
+
+// SprintMemberLeave ...
+type SprintMemberLeave struct {
+	ID             uint
+	SprintMemberID uint
+	Date           time.Time
+	Type           int8
+	HalfDay        bool
+	Note           string
+}
+
+// SprintMemberLeavesSerializer lists the leaves of a sprint member, DaysByType explains the drop in the capacity
+// of the member by the type of leave
+type SprintMemberLeavesSerializer struct {
+	Leaves     []SprintMemberLeave
+	Days       float64
+	DaysByType map[string]float64
+}
+
+// SprintMemberLeaveCreate serializer to add a leave for a sprint member, Date is in the YYYY-MM-DD format
+type SprintMemberLeaveCreate struct {
+	Date    string `json:"Date" binding:"required"`
+	Type    int8   `json:"Type" binding:"is_valid_leave_type"`
+	HalfDay bool   `json:"HalfDay"`
+	Note    string `json:"Note"`
+}
+
+// SprintMemberLeaveImport serializer to import the leaves of a sprint member from an ICS calendar or a CSV file.
+// The CSV file has a header row with the Date, Type, HalfDay and Note columns, only Date being required.
+type SprintMemberLeaveImport struct {
+	Format string `json:"Format" binding:"required,eq=ics|eq=csv"`
+	Data   string `json:"Data" binding:"required"`
+}
+
+// SprintMemberLeaveImportResult lists the imported leaves along with the entries which were skipped and why
+type SprintMemberLeaveImportResult struct {
+	Leaves  []SprintMemberLeave
+	Skipped []string
+}
@@ -0,0 +1,76 @@
+package serializers
+
+import (
+	"time"
+
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+)
+
+// SprintPlanMember is the capacity of a sprint member against the points planned for the member
+type SprintPlanMember struct {
+	ID                 uint
+	MemberID           uint
+	FirstName          string
+	LastName           string
+	AllocationPercent  float64
+	ExpectationPercent float64
+	Vacations          float64
+	Capacity           float64
+	PlannedPoints      float64
+	TaskCount          uint
+	OverCommitted      bool
+}
+
+// SprintPlanTask is a task planned for the sprint, PlannedEstimate overrides the estimate of the task in the plan
+type SprintPlanTask struct {
+	ID              uint
+	TaskID          uint
+	Key             string
+	Summary         string
+	Type            string
+	Status          string
+	Priority        string
+	IsTrackerTask   bool
+	Estimate        float64
+	PlannedEstimate *float64
+	PlannedPoints   float64
+}
+
+// SprintPlan is the plan of a draft sprint, Warnings lists the over-commitments of the plan
+type SprintPlan struct {
+	Capacity          float64
+	CommittedEstimate float64
+	PlannedPoints     float64
+	OverCommitted     bool
+	Members           []*SprintPlanMember
+	Tasks             []*SprintPlanTask
+	Warnings          []string
+}
+
+// SprintBacklogSerializer ...
+type SprintBacklogSerializer struct {
+	Tasks []taskTrackerSerializers.Task
+}
+
+// SprintPlanTaskCreate serializer to pull a task tracker ticket into the plan of a sprint
+type SprintPlanTaskCreate struct {
+	Key             string   `json:"Key" binding:"required"`
+	PlannedEstimate *float64 `json:"PlannedEstimate" binding:"omitempty,min=0"`
+}
+
+// SprintPlanTaskUpdate serializer to update the planned estimate of a task, a null estimate resets it
+type SprintPlanTaskUpdate struct {
+	PlannedEstimate *float64 `json:"PlannedEstimate" binding:"omitempty,min=0"`
+}
+
+// SprintCommitment is the scope a sprint was committed to when it was activated
+type SprintCommitment struct {
+	ID                uint
+	SprintID          uint
+	CreatedAt         time.Time
+	Capacity          float64
+	CommittedEstimate float64
+	PlannedPoints     float64
+	TaskCount         uint
+	Plan              SprintPlan
+}
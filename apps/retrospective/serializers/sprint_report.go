@@ -0,0 +1,32 @@
+package serializers
+
+import (
+	"github.com/iReflect/reflect-app/apps/retrospective"
+	"github.com/iReflect/reflect-app/apps/retrospective/models"
+)
+
+// SprintReportMemberTask is the contribution of a member on a task in the sprint
+type SprintReportMemberTask struct {
+	SprintTaskID     uint
+	FirstName        string
+	LastName         string
+	Role             models.MemberTaskRole
+	TimeSpentMinutes uint
+	PointsEarned     float64
+	PointsAssigned   float64
+	Rating           retrospective.Rating
+}
+
+// SprintReport collects everything that goes in an exported sprint report
+type SprintReport struct {
+	RetrospectiveTitle string
+	Sprint             *Sprint
+	Members            []*SprintMemberSummary
+	Tasks              []*SprintTask
+	MemberTasks        []SprintReportMemberTask
+	AddedGoals         []models.RetrospectiveFeedback
+	CompletedGoals     []models.RetrospectiveFeedback
+	PendingGoals       []models.RetrospectiveFeedback
+	Highlights         []models.RetrospectiveFeedback
+	Notes              []models.RetrospectiveFeedback
+}
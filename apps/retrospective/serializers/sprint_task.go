@@ -30,6 +30,9 @@ type SprintTask struct {
 	TotalTime               uint   // Total time spent on the task across the sprints
 	DoneAt                  *time.Time
 	Resolution              int8
+	ParentID                *uint  // Task of the parent ticket of a subtask
+	ParentKey               string // Key of the parent ticket of a subtask
+	EpicKey                 string // Key of the epic of the task
 }
 
 // SprintTasksSerializer ...
@@ -39,17 +42,22 @@ type SprintTasksSerializer struct {
 
 // TaskMember ...
 type TaskMember struct {
-	ID           uint
-	FirstName    string
-	LastName     string
-	TotalTime    uint
-	SprintTime   uint
-	TotalPoints  float64
-	SprintPoints float64
-	Rating       int8
-	Comment      string
-	Role         int8
-	Current      bool
+	ID               uint
+	FirstName        string
+	LastName         string
+	TotalTime        uint
+	SprintTime       uint
+	TotalPoints      float64
+	SprintPoints     float64
+	PlannedPoints    float64
+	Rating           int8
+	Comment          string
+	Role             int8
+	Current          bool
+	PointsLocked     bool
+	PointsLockedByID *uint
+	PointsLockReason string
+	PointsLockedAt   *time.Time
 }
 
 // TaskMembersSerializer ...
@@ -60,6 +68,7 @@ type TaskMembersSerializer struct {
 // SprintTaskUpdate ...
 type SprintTaskUpdate struct {
 	BaseRating
+	Estimate *float64 `json:"Estimate" binding:"omitempty,min=0"`
 }
 
 // SprintTaskDone ...
@@ -67,6 +76,11 @@ type SprintTaskDone struct {
 	Resolution *int8 `json:"Resolution" binding:"omitempty,is_valid_resolution"`
 }
 
+// SprintTaskMemberLock serializer to lock the points of a task member against the points allocation
+type SprintTaskMemberLock struct {
+	Reason string `json:"Reason"`
+}
+
 // AddSprintTaskMemberSerializer ...
 type AddSprintTaskMemberSerializer struct {
 	MemberID uint `json:"memberID" binding:"required"`
@@ -75,7 +89,10 @@ type AddSprintTaskMemberSerializer struct {
 // SprintTaskMemberUpdate serializer to update
 type SprintTaskMemberUpdate struct {
 	BaseRating
-	SprintPoints *float64 `json:"SprintPoints"`
-	Comment      *string  `json:"Comment"`
-	Role         *int8    `json:"Role" binding:"omitempty,is_valid_task_role"`
+	SprintPoints  *float64 `json:"SprintPoints"`
+	PlannedPoints *float64 `json:"PlannedPoints" binding:"omitempty,min=0"`
+	// Editing the sprint points locks them against the points allocation, with the given reason
+	PointsLockReason *string `json:"PointsLockReason"`
+	Comment          *string `json:"Comment"`
+	Role             *int8   `json:"Role" binding:"omitempty,is_valid_task_role"`
 }
@@ -0,0 +1,40 @@
+package serializers
+
+import "time"
+
+// TaskStatusTransition ...
+type TaskStatusTransition struct {
+	FromStatus     string
+	ToStatus       string
+	TransitionedAt time.Time
+	TransitionedBy string
+}
+
+// TaskFlowMetrics are the flow metrics of a task, the times are in hours. The cycle time is from the first
+// change of the status of the task until it is done, the lead time is from the creation of the task until it is
+// done. TimeInStatus is the time the task spent in each of the statuses, other than the done ones.
+type TaskFlowMetrics struct {
+	TaskID       uint
+	Key          string
+	Summary      string
+	Status       string
+	CreatedAt    time.Time
+	StartedAt    *time.Time
+	DoneAt       *time.Time
+	CycleTime    *float64
+	LeadTime     *float64
+	TimeInStatus map[string]float64
+	Transitions  []TaskStatusTransition
+}
+
+// FlowMetricsSerializer has the flow metrics of a set of tasks, the times are in hours. The average cycle and
+// lead times are the ones of the DoneCount tasks done in the period, TimeInStatus is the total time spent by
+// the tasks in each status during the period.
+type FlowMetricsSerializer struct {
+	TaskCount        uint
+	DoneCount        uint
+	AverageCycleTime *float64
+	AverageLeadTime  *float64
+	TimeInStatus     map[string]float64
+	Tasks            []TaskFlowMetrics
+}
@@ -4,19 +4,48 @@ import (
 	"time"
 
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/db/models/fields"
 )
 
 // Trail .......
 type Trail struct {
-	Action       string
-	ActionItem   string
-	ActionItemID uint
-	ActionBy     userModels.User
-	ActionByID   uint
-	CreatedAt    time.Time
+	ID              uint
+	Action          string
+	ActionItem      string
+	ActionItemID    uint
+	ActionBy        userModels.User
+	ActionByID      uint
+	Changes         fields.JSONB
+	SprintID        *uint
+	RetrospectiveID *uint
+	Anonymous       bool
+	CreatedAt       time.Time
+}
+
+// TrailChange is the value of a field before and after the action
+type TrailChange struct {
+	Before interface{}
+	After  interface{}
+}
+
+// TrailFilter is used to filter and paginate the trails, After is the ID of the last trail of the previous page.
+// Trails of a sprint include the trails of its retrospective and the retrospective feedbacks as well.
+// The trails of the anonymous feedbacks are never matched by the user who took the action.
+type TrailFilter struct {
+	SprintID        uint
+	RetrospectiveID uint
+	TeamID          uint
+	Action          string
+	ActionItem      string
+	ActionByID      uint
+	From            *time.Time
+	To              *time.Time
+	After           uint
+	Count           int
 }
 
 // TrailSerializer used to get trails ...
 type TrailSerializer struct {
 	Trails []Trail
+	Next   *uint
 }
@@ -0,0 +1,67 @@
+package validators
+
+import (
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+	"gopkg.in/go-playground/validator.v8"
+
+	"github.com/iReflect/reflect-app/apps/retrospective/models"
+	retrospectiveSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// IsValidRetroTemplate ...
+func IsValidRetroTemplate(db *gorm.DB) validator.Func {
+	return func(
+		v *validator.Validate,
+		topStruct reflect.Value,
+		currentStruct reflect.Value,
+		field reflect.Value,
+		fieldType reflect.Type,
+		fieldKind reflect.Kind,
+		param string,
+	) bool {
+		var templateID *uint
+
+		switch reflect.TypeOf(currentStruct.Interface()) {
+		case reflect.TypeOf(&retrospectiveSerializers.RetrospectiveCreateSerializer{}):
+			templateID = currentStruct.Interface().(*retrospectiveSerializers.RetrospectiveCreateSerializer).TemplateID
+		case reflect.TypeOf(&retrospectiveSerializers.RetrospectiveUpdateSerializer{}):
+			templateID = currentStruct.Interface().(*retrospectiveSerializers.RetrospectiveUpdateSerializer).TemplateID
+		}
+		if templateID == nil {
+			return true
+		}
+
+		if err := db.Model(&models.RetroTemplate{}).
+			Where("deleted_at IS NULL").
+			Where("id = ?", *templateID).
+			First(&models.RetroTemplate{}).Error; err != nil {
+			return false
+		}
+		return true
+	}
+}
+
+// IsValidFeedbackSubType validates the sub-type against the template of the retrospective
+func IsValidFeedbackSubType(db *gorm.DB) validator.Func {
+	return func(
+		v *validator.Validate,
+		topStruct reflect.Value,
+		currentStruct reflect.Value,
+		field reflect.Value,
+		fieldType reflect.Type,
+		fieldKind reflect.Kind,
+		param string,
+	) bool {
+		feedbackData := currentStruct.Interface().(*retrospectiveSerializers.RetrospectiveFeedbackCreateSerializer)
+		isAllowed, err := models.IsAllowedFeedbackSubType(db, feedbackData.RetrospectiveID, feedbackData.Type,
+			feedbackData.SubType)
+		if err != nil {
+			utils.LogToSentry(err)
+			return false
+		}
+		return isAllowed
+	}
+}
@@ -159,3 +159,18 @@ func IsValidTaskRole(
 	}
 	return false
 }
+
+// IsValidPointsAllocationStrategy ...
+//noinspection GoUnusedParameter
+func IsValidPointsAllocationStrategy(
+	v *validator.Validate,
+	topStruct reflect.Value,
+	currentStruct reflect.Value,
+	field reflect.Value,
+	fieldType reflect.Type,
+	fieldKind reflect.Kind,
+	param string,
+) bool {
+	strategy := field.Int()
+	return strategy >= 0 && int(strategy) < len(models.PointsAllocationStrategyValues)
+}
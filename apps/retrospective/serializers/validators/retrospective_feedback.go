@@ -24,3 +24,21 @@ func IsValidRetrospectiveFeedbackScope(
 	}
 	return false
 }
+
+// IsValidGoalProgress ...
+//noinspection GoUnusedParameter
+func IsValidGoalProgress(
+	v *validator.Validate,
+	topStruct reflect.Value,
+	currentStruct reflect.Value,
+	field reflect.Value,
+	fieldType reflect.Type,
+	fieldKind reflect.Kind,
+	param string,
+) bool {
+	progress := currentStruct.Interface().(*serializers.RetrospectiveFeedbackUpdateSerializer).Progress
+	if progress != nil && *progress >= 0 && int(*progress) < len(models.GoalProgressValues) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package validators
+
+import (
+	"reflect"
+
+	"gopkg.in/go-playground/validator.v8"
+
+	"github.com/iReflect/reflect-app/apps/retrospective/models"
+)
+
+// IsValidLeaveType ...
+//noinspection GoUnusedParameter
+func IsValidLeaveType(
+	v *validator.Validate,
+	topStruct reflect.Value,
+	currentStruct reflect.Value,
+	field reflect.Value,
+	fieldType reflect.Type,
+	fieldKind reflect.Kind,
+	param string,
+) bool {
+	leaveType := field.Int()
+	return leaveType >= 0 && int(leaveType) < len(models.LeaveTypeValues)
+}
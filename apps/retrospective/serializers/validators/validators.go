@@ -46,8 +46,32 @@ func (retroValidator RetrospectiveValidators) Register() {
 		logrus.Error(err.Error())
 	}
 
+	if err := validatorEngine.RegisterValidation("is_valid_points_allocation_strategy",
+		IsValidPointsAllocationStrategy); err != nil {
+		logrus.Error(err.Error())
+	}
+
+	if err := validatorEngine.RegisterValidation("is_valid_leave_type", IsValidLeaveType); err != nil {
+		logrus.Error(err.Error())
+	}
+
 	if err := validatorEngine.RegisterValidation("is_valid_retrospective_feedback_scope",
 		IsValidRetrospectiveFeedbackScope); err != nil {
 		logrus.Error(err.Error())
 	}
+
+	if err := validatorEngine.RegisterValidation("is_valid_goal_progress",
+		IsValidGoalProgress); err != nil {
+		logrus.Error(err.Error())
+	}
+
+	if err := validatorEngine.RegisterValidation("is_valid_retro_template",
+		IsValidRetroTemplate(retroValidator.DB)); err != nil {
+		logrus.Error(err.Error())
+	}
+
+	if err := validatorEngine.RegisterValidation("is_valid_feedback_sub_type",
+		IsValidFeedbackSubType(retroValidator.DB)); err != nil {
+		logrus.Error(err.Error())
+	}
 }
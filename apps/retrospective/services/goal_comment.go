@@ -0,0 +1,172 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/apps/retrospective/models"
+	retrospectiveSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// GoalCommentService ...
+type GoalCommentService struct {
+	DB *gorm.DB
+}
+
+// List returns the comment thread of the goal, oldest first
+func (service GoalCommentService) List(retroID string, goalID string) (
+	*retrospectiveSerializers.GoalCommentListSerializer, int, error) {
+	db := service.DB
+
+	if status, err := service.checkGoal(retroID, goalID); err != nil {
+		return nil, status, err
+	}
+
+	commentList := new(retrospectiveSerializers.GoalCommentListSerializer)
+	if err := db.Model(&models.GoalComment{}).
+		Where("goal_comments.deleted_at IS NULL").
+		Where("goal_id = ?", goalID).
+		Preload("CreatedBy").
+		Order("created_at, id").
+		Find(&commentList.Comments).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get comments")
+	}
+
+	return commentList, http.StatusOK, nil
+}
+
+// Add adds a comment to the goal
+func (service GoalCommentService) Add(userID uint, retroID string, goalID string,
+	commentData *retrospectiveSerializers.GoalCommentSerializer) (
+	*retrospectiveSerializers.GoalComment, int, error) {
+	db := service.DB
+
+	goal := models.RetrospectiveFeedback{}
+	if err := db.Model(&models.RetrospectiveFeedback{}).
+		Where("retrospective_feedbacks.deleted_at IS NULL").
+		Where("id = ? AND retrospective_id = ? AND type = ?", goalID, retroID, models.GoalType).
+		First(&goal).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("goal not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get goal")
+	}
+
+	comment := models.GoalComment{
+		GoalID:      goal.ID,
+		Text:        commentData.Text,
+		CreatedByID: userID,
+	}
+	if err := db.Create(&comment).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add comment")
+	}
+
+	return service.getGoalComment(comment.ID)
+}
+
+// Update edits a comment, only the author of the comment can edit it
+func (service GoalCommentService) Update(userID uint, retroID string, goalID string, commentID string,
+	commentData *retrospectiveSerializers.GoalCommentSerializer) (
+	*retrospectiveSerializers.GoalComment, int, error) {
+	db := service.DB
+
+	comment, status, err := service.getUserComment(userID, retroID, goalID, commentID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	comment.Text = commentData.Text
+	if err := db.Set("gorm:save_associations", false).Save(&comment).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update comment")
+	}
+
+	return service.getGoalComment(comment.ID)
+}
+
+// Delete deletes a comment, only the author of the comment can delete it
+func (service GoalCommentService) Delete(userID uint, retroID string, goalID string, commentID string) (int, error) {
+	db := service.DB
+
+	comment, status, err := service.getUserComment(userID, retroID, goalID, commentID)
+	if err != nil {
+		return status, err
+	}
+
+	if err := db.Delete(&comment).Error; err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to delete comment")
+	}
+	return http.StatusNoContent, nil
+}
+
+// checkGoal checks that the goal exists in the retrospective
+func (service GoalCommentService) checkGoal(retroID string, goalID string) (int, error) {
+	db := service.DB
+
+	var count uint
+	if err := db.Model(&models.RetrospectiveFeedback{}).
+		Where("retrospective_feedbacks.deleted_at IS NULL").
+		Where("id = ? AND retrospective_id = ? AND type = ?", goalID, retroID, models.GoalType).
+		Count(&count).Error; err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to get goal")
+	}
+	if count == 0 {
+		return http.StatusNotFound, errors.New("goal not found")
+	}
+	return http.StatusOK, nil
+}
+
+// getUserComment returns the user's comment on the goal
+func (service GoalCommentService) getUserComment(userID uint, retroID string, goalID string,
+	commentID string) (models.GoalComment, int, error) {
+	db := service.DB
+
+	comment := models.GoalComment{}
+	if err := db.Model(&models.GoalComment{}).
+		Joins("JOIN retrospective_feedbacks ON retrospective_feedbacks.id = goal_comments.goal_id").
+		Where("goal_comments.deleted_at IS NULL").
+		Where("retrospective_feedbacks.deleted_at IS NULL").
+		Where("goal_comments.id = ? AND goal_comments.goal_id = ?", commentID, goalID).
+		Where("retrospective_feedbacks.retrospective_id = ?", retroID).
+		Select("goal_comments.*").
+		First(&comment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return comment, http.StatusNotFound, errors.New("comment not found")
+		}
+		utils.LogToSentry(err)
+		return comment, http.StatusInternalServerError, errors.New("failed to get comment")
+	}
+
+	if comment.CreatedByID != userID {
+		return comment, http.StatusForbidden, errors.New("only the author can edit or delete a comment")
+	}
+	return comment, http.StatusOK, nil
+}
+
+func (service GoalCommentService) getGoalComment(commentID uint) (
+	*retrospectiveSerializers.GoalComment, int, error) {
+	db := service.DB
+
+	comment := retrospectiveSerializers.GoalComment{}
+	if err := db.Model(&models.GoalComment{}).
+		Where("goal_comments.deleted_at IS NULL").
+		Where("id = ?", commentID).
+		Preload("CreatedBy").
+		First(&comment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("comment not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get comment")
+	}
+
+	return &comment, http.StatusOK, nil
+}
@@ -162,3 +162,40 @@ func (service PermissionService) IsUserAdmin(userID uint) bool {
 		Error
 	return err == nil
 }
+
+// UserCanAccessMemberHistory checks whether the user is the member himself or a manager of any of the
+// teams the member is a part of
+func (service PermissionService) UserCanAccessMemberHistory(memberID uint, userID uint) bool {
+	if service.IsUserAdmin(userID) || memberID == userID {
+		return true
+	}
+
+	db := service.DB
+	err := db.Model(&userModels.UserTeam{}).
+		Where("user_teams.deleted_at IS NULL").
+		Joins(`JOIN user_teams AS member_teams ON member_teams.team_id = user_teams.team_id
+            AND member_teams.deleted_at IS NULL`).
+		Where("member_teams.user_id = ?", memberID).
+		Where("user_teams.user_id = ?", userID).
+		Where("user_teams.leaved_at IS NULL").
+		Where("user_teams.role IN (?)", []userModels.TeamRole{userModels.ManagerRole, userModels.AdminRole}).
+		Find(&userModels.UserTeam{}).
+		Error
+	return err == nil
+}
+
+// UserCanManageTeam checks whether the user is a manager or an admin of the team
+func (service PermissionService) UserCanManageTeam(teamID string, userID uint) bool {
+	if service.IsUserAdmin(userID) {
+		return true
+	}
+
+	db := service.DB
+	err := db.Model(&userModels.UserTeam{}).
+		Where("user_teams.deleted_at IS NULL").
+		Where("team_id = ? AND user_id = ? AND leaved_at IS NULL", teamID, userID).
+		Where("role IN (?)", []userModels.TeamRole{userModels.ManagerRole, userModels.AdminRole}).
+		Find(&userModels.UserTeam{}).
+		Error
+	return err == nil
+}
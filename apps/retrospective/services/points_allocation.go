@@ -0,0 +1,247 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// annotatedSprintMemberTask is a sprint member task of a done task along with the inputs of the points allocation
+type annotatedSprintMemberTask struct {
+	retroModels.PointsAllocationShare
+	SprintTaskID             uint
+	SprintMemberID           uint
+	PointsAssigned           float64
+	PointsEarned             float64
+	TaskID                   uint
+	Key                      string
+	Summary                  string
+	Assignee                 string
+	Estimate                 float64
+	SprintTaskTotalTimeSpent uint
+	OtherSprintsPointsEarned float64
+	PointsLocked             bool
+	PointsLockedBy           string
+	PointsLockReason         string
+}
+
+// PreviewPointsAllocation returns the points allocation of the done tasks of the sprint, or of a single sprint task,
+// without applying it. With overrideLocks the locked points are re-allocated as well.
+func (service SprintService) PreviewPointsAllocation(sprintID string,
+	sprintTaskID *string, overrideLocks bool) (*retroSerializers.PointsAllocationSerializer, int, error) {
+	sprint, status, err := service.getPointsAllocationSprint(sprintID, false)
+	if err != nil {
+		return nil, status, err
+	}
+
+	allocation, err := service.computePointsAllocation(*sprint, sprintTaskID, overrideLocks)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get points allocation")
+	}
+	return allocation, http.StatusOK, nil
+}
+
+// ApplyPointsAllocation applies the points allocation of the done tasks of the sprint, or of a single sprint task,
+// the locked points are kept unless overrideLocks is set
+func (service SprintService) ApplyPointsAllocation(sprintID string, sprintTaskID *string,
+	overrideLocks bool) (*retroSerializers.PointsAllocationSerializer, int, error) {
+	sprint, status, err := service.getPointsAllocationSprint(sprintID, true)
+	if err != nil {
+		return nil, status, err
+	}
+
+	allocation, err := service.computePointsAllocation(*sprint, sprintTaskID, overrideLocks)
+	if err == nil {
+		err = service.applyPointsAllocation(allocation, overrideLocks)
+	}
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to apply points allocation")
+	}
+
+	allocation, err = service.computePointsAllocation(*sprint, sprintTaskID, false)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get points allocation")
+	}
+	return allocation, http.StatusOK, nil
+}
+
+// getPointsAllocationSprint returns the sprint along with its retrospective, the points can only be applied
+// to the active sprints
+func (service SprintService) getPointsAllocationSprint(sprintID string,
+	apply bool) (*retroModels.Sprint, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.NotDeletedSprint).
+		Where("sprints.id = ?", sprintID).
+		Preload("Retrospective").
+		First(&sprint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint")
+	}
+
+	if apply && sprint.Status != retroModels.ActiveSprint {
+		return nil, http.StatusBadRequest, errors.New("points can only be allocated in an active sprint")
+	}
+	return &sprint, http.StatusOK, nil
+}
+
+// computePointsAllocation computes the points allocation of the done tasks of the sprint with the points
+// allocation strategy of the retrospective. The remaining points of a task, i.e. its estimate less the points
+// earned on it in the other sprints and the locked points, are split among the members of the task in the sprint
+// whose points are not locked. With ignoreLocks all the members share the remaining points.
+func (service SprintService) computePointsAllocation(sprint retroModels.Sprint,
+	sprintTaskID *string, ignoreLocks bool) (*retroSerializers.PointsAllocationSerializer, error) {
+	db := service.DB
+
+	annotatedSMTExpr := db.Model(retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM, retroModels.SMTJoinST, retroModels.STJoinTask, retroModels.SMJoinSprint,
+			retroModels.SMLeftJoinMember).
+		Joins("LEFT JOIN users AS lockers ON sprint_member_tasks.points_locked_by_id = lockers.id").
+		Where("(sprints.status <> ? OR sprints.id = ?)", retroModels.DraftSprint, sprint.ID).
+		Scopes(retroModels.NotDeletedSprint).
+		Where("tasks.retrospective_id = ?", sprint.RetrospectiveID).
+		Where("tasks.done_at IS NOT NULL").
+		Select(`
+            sprint_member_tasks.id,
+            sprint_member_tasks.sprint_task_id,
+            sprint_member_tasks.sprint_member_id,
+            sprint_member_tasks.role,
+            sprint_member_tasks.time_spent_minutes,
+            sprint_member_tasks.points_assigned,
+            sprint_member_tasks.points_earned,
+            sprint_member_tasks.points_locked,
+            sprint_member_tasks.points_lock_reason,
+            CONCAT_WS(' ', lockers.first_name, lockers.last_name) AS points_locked_by,
+            sprint_members.sprint_id,
+            CONCAT_WS(' ', users.first_name, users.last_name) AS member_name,
+            tasks.id AS task_id,
+            tasks.key,
+            tasks.summary,
+            tasks.assignee,
+            tasks.estimate,
+            (SUM(sprint_member_tasks.time_spent_minutes) OVER (PARTITION BY sprint_tasks.id))
+				AS sprint_task_total_time_spent,
+            ((SUM(sprint_member_tasks.points_earned) OVER (PARTITION BY sprint_tasks.task_id))
+				- (SUM(sprint_member_tasks.points_earned) OVER (PARTITION BY sprint_tasks.id)))
+				AS other_sprints_points_earned
+        `).QueryExpr()
+
+	selectSQL := "SELECT s1.* FROM (?) AS s1 WHERE s1.sprint_id = ?"
+	sqlValues := []interface{}{annotatedSMTExpr, sprint.ID}
+
+	if sprintTaskID != nil {
+		selectSQL = fmt.Sprintf("%s AND s1.sprint_task_id = ?", selectSQL)
+		sqlValues = append(sqlValues, *sprintTaskID)
+	}
+
+	var annotatedSMTs []annotatedSprintMemberTask
+	if err := db.Raw(selectSQL, sqlValues...).
+		Order("s1.key, s1.sprint_task_id, s1.id").
+		Scan(&annotatedSMTs).Error; err != nil {
+		return nil, err
+	}
+
+	allocation := new(retroSerializers.PointsAllocationSerializer)
+	allocation.Strategy = sprint.Retrospective.PointsAllocationStrategy.GetStringValue()
+	allocation.ReviewerPointsWeight = sprint.Retrospective.ReviewerPointsWeight
+	allocation.Tasks = []*retroSerializers.PointsAllocationTask{}
+
+	// The task details are the same for all the SMTs of a sprint task
+	var allocationTasks []retroModels.PointsAllocationTask
+	for index, smt := range annotatedSMTs {
+		if index == 0 || smt.SprintTaskID != annotatedSMTs[index-1].SprintTaskID {
+			remainingPoints := smt.Estimate - smt.OtherSprintsPointsEarned
+			allocation.Tasks = append(allocation.Tasks, &retroSerializers.PointsAllocationTask{
+				SprintTaskID:             smt.SprintTaskID,
+				TaskID:                   smt.TaskID,
+				Key:                      smt.Key,
+				Summary:                  smt.Summary,
+				Assignee:                 smt.Assignee,
+				Estimate:                 smt.Estimate,
+				TotalTimeSpentMinutes:    smt.SprintTaskTotalTimeSpent,
+				OtherSprintsPointsEarned: smt.OtherSprintsPointsEarned,
+				RemainingPoints:          remainingPoints,
+			})
+			allocationTasks = append(allocationTasks, retroModels.PointsAllocationTask{
+				Points:   remainingPoints,
+				Assignee: smt.Assignee,
+			})
+		}
+		task := allocation.Tasks[len(allocation.Tasks)-1]
+		task.Members = append(task.Members, &retroSerializers.PointsAllocationMember{
+			SprintMemberTaskID: smt.ID,
+			SprintMemberID:     smt.SprintMemberID,
+			MemberName:         smt.MemberName,
+			Role:               int8(smt.Role),
+			TimeSpentMinutes:   smt.TimeSpentMinutes,
+			PointsAssigned:     smt.PointsAssigned,
+			PointsEarned:       smt.PointsEarned,
+			PointsLocked:       smt.PointsLocked,
+			PointsLockedBy:     smt.PointsLockedBy,
+			PointsLockReason:   smt.PointsLockReason,
+		})
+
+		// The locked points are left out of the points to be split among the other members
+		allocationTask := &allocationTasks[len(allocationTasks)-1]
+		if smt.PointsLocked && !ignoreLocks {
+			task.LockedPoints += smt.PointsEarned
+			allocationTask.Points = math.Max(task.RemainingPoints-task.LockedPoints, 0)
+			continue
+		}
+		allocationTask.Shares = append(allocationTask.Shares, smt.PointsAllocationShare)
+	}
+
+	allocator := retroModels.GetPointsAllocator(sprint.Retrospective.PointsAllocationStrategy,
+		sprint.Retrospective.ReviewerPointsWeight)
+	for index, allocationTask := range allocationTasks {
+		proposedPoints := allocator.Allocate(allocationTask)
+		for _, member := range allocation.Tasks[index].Members {
+			if member.PointsLocked && !ignoreLocks {
+				member.ProposedPoints = member.PointsEarned
+				continue
+			}
+			member.ProposedPoints = proposedPoints[member.SprintMemberTaskID]
+		}
+	}
+	return allocation, nil
+}
+
+// applyPointsAllocation sets the points assigned and earned of the sprint member tasks to the proposed points,
+// the locked points are skipped unless overrideLocks is set, in which case they are unlocked
+func (service SprintService) applyPointsAllocation(allocation *retroSerializers.PointsAllocationSerializer,
+	overrideLocks bool) error {
+	tx := service.DB.Begin()
+	for _, task := range allocation.Tasks {
+		for _, member := range task.Members {
+			if member.PointsLocked && !overrideLocks {
+				continue
+			}
+			err := tx.Exec(`UPDATE sprint_member_tasks
+				SET points_assigned = ?, points_earned = ?, points_locked = FALSE, points_locked_by_id = NULL,
+					points_lock_reason = '', points_locked_at = NULL, updated_at = NOW()
+				WHERE id = ?`, member.ProposedPoints, member.ProposedPoints, member.SprintMemberTaskID).Error
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	return tx.Commit().Error
+}
@@ -0,0 +1,728 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// RetroMeetingService runs the facilitated retro meeting of a sprint, the sprint's notes and highlights
+// are grouped, dot-voted upon and the outcomes of discussing the groups are recorded as goals
+type RetroMeetingService struct {
+	DB *gorm.DB
+}
+
+// Start the retro meeting of the sprint with the user as the facilitator
+func (service RetroMeetingService) Start(sprintID string, userID uint) (
+	*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	sprint := retroModels.Sprint{}
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("id = ?", sprintID).
+		First(&sprint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint")
+	}
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil, http.StatusBadRequest, errors.New("sprint dates must be set to start the retro meeting")
+	}
+
+	var count uint
+	if err := db.Model(&retroModels.RetroMeeting{}).
+		Where("retro_meetings.deleted_at IS NULL").
+		Where("sprint_id = ?", sprint.ID).
+		Count(&count).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to start the retro meeting")
+	}
+	if count > 0 {
+		return nil, http.StatusBadRequest, errors.New("retro meeting has already started")
+	}
+
+	meeting := retroModels.RetroMeeting{
+		SprintID:      sprint.ID,
+		FacilitatorID: userID,
+		Phase:         retroModels.GatherPhase,
+		VotesPerUser:  3,
+	}
+	if err := db.Create(&meeting).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to start the retro meeting")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// Get the retro meeting of the sprint as seen by the user
+func (service RetroMeetingService) Get(sprintID string, userID uint) (
+	*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	response := &retroSerializers.RetroMeeting{
+		ID:            meeting.ID,
+		SprintID:      meeting.SprintID,
+		FacilitatorID: meeting.FacilitatorID,
+		Phase:         meeting.Phase,
+		VotesPerUser:  meeting.VotesPerUser,
+		Timer:         getRetroMeetingTimer(meeting, time.Now()),
+		Groups:        []retroSerializers.RetroMeetingGroup{},
+	}
+	if err := db.Model(&userModels.User{}).
+		Where("id = ?", meeting.FacilitatorID).
+		First(&response.Facilitator).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the retro meeting")
+	}
+
+	var groups []retroModels.RetroMeetingGroup
+	if err := db.Model(&retroModels.RetroMeetingGroup{}).
+		Where("retro_meeting_groups.deleted_at IS NULL").
+		Where("meeting_id = ?", meeting.ID).
+		Order("id").
+		Find(&groups).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the retro meeting groups")
+	}
+
+	var votes []struct {
+		GroupID       uint
+		VoteCount     uint
+		UserVoteCount uint
+	}
+	if err := db.Model(&retroModels.RetroMeetingVote{}).
+		Joins("JOIN retro_meeting_groups ON retro_meeting_groups.id = retro_meeting_votes.group_id").
+		Where("retro_meeting_votes.deleted_at IS NULL").
+		Where("retro_meeting_groups.deleted_at IS NULL").
+		Where("retro_meeting_groups.meeting_id = ?", meeting.ID).
+		Select("retro_meeting_votes.group_id, COUNT(*) AS vote_count, "+
+			"COUNT(*) FILTER (WHERE retro_meeting_votes.voter_id = ?) AS user_vote_count", userID).
+		Group("retro_meeting_votes.group_id").
+		Scan(&votes).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the retro meeting votes")
+	}
+
+	var votesUsed uint
+	for _, group := range groups {
+		groupResponse := retroSerializers.RetroMeetingGroup{
+			ID:     group.ID,
+			Title:  group.Title,
+			GoalID: group.GoalID,
+			Items:  []retroSerializers.RetrospectiveFeedback{},
+		}
+		for _, vote := range votes {
+			if vote.GroupID == group.ID {
+				groupResponse.VoteCount = vote.VoteCount
+				groupResponse.UserVoteCount = vote.UserVoteCount
+				votesUsed += vote.UserVoteCount
+			}
+		}
+
+		if err := db.Model(&retroModels.RetrospectiveFeedback{}).
+			Joins("JOIN retro_meeting_group_items "+
+				"ON retro_meeting_group_items.feedback_id = retrospective_feedbacks.id").
+			Where("retrospective_feedbacks.deleted_at IS NULL").
+			Where("retro_meeting_group_items.deleted_at IS NULL").
+			Where("retro_meeting_group_items.group_id = ?", group.ID).
+			Select("retrospective_feedbacks.*").
+			Preload("Assignee").
+			Preload("CreatedBy").
+			Order("retro_meeting_group_items.id").
+			Find(&groupResponse.Items).Error; err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to get the retro meeting groups")
+		}
+		for index := range groupResponse.Items {
+			hideAnonymousAuthor(&groupResponse.Items[index])
+		}
+		response.Groups = append(response.Groups, groupResponse)
+	}
+	if votesUsed < meeting.VotesPerUser {
+		response.VotesRemaining = meeting.VotesPerUser - votesUsed
+	}
+
+	itemsQuery, status, err := service.getMeetingItems(db, meeting)
+	if err != nil {
+		return nil, status, err
+	}
+	response.UngroupedItems = []retroSerializers.RetrospectiveFeedback{}
+	if err := itemsQuery.
+		Where("retrospective_feedbacks.id NOT IN (?)", db.Model(&retroModels.RetroMeetingGroupItem{}).
+			Joins("JOIN retro_meeting_groups ON retro_meeting_groups.id = retro_meeting_group_items.group_id").
+			Where("retro_meeting_group_items.deleted_at IS NULL").
+			Where("retro_meeting_groups.deleted_at IS NULL").
+			Where("retro_meeting_groups.meeting_id = ?", meeting.ID).
+			Select("retro_meeting_group_items.feedback_id").
+			QueryExpr()).
+		Preload("Assignee").
+		Preload("CreatedBy").
+		Order("added_at DESC, created_at DESC").
+		Find(&response.UngroupedItems).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the retro meeting items")
+	}
+	for index := range response.UngroupedItems {
+		hideAnonymousAuthor(&response.UngroupedItems[index])
+	}
+
+	return response, http.StatusOK, nil
+}
+
+// Update the phase, the votes per user or the facilitator of the retro meeting
+func (service RetroMeetingService) Update(sprintID string, userID uint,
+	meetingData *retroSerializers.RetroMeetingUpdateSerializer) (*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.FacilitatorID != userID {
+		return nil, http.StatusForbidden, errors.New("only the facilitator can run the retro meeting")
+	}
+
+	if meetingData.Phase != nil {
+		if *meetingData.Phase < 0 || int(*meetingData.Phase) >= len(retroModels.RetroMeetingPhaseValues) {
+			return nil, http.StatusBadRequest, errors.New("invalid retro meeting phase")
+		}
+		meeting.Phase = retroModels.RetroMeetingPhase(*meetingData.Phase)
+	}
+
+	if meetingData.VotesPerUser != nil {
+		meeting.VotesPerUser = *meetingData.VotesPerUser
+	}
+
+	if meetingData.FacilitatorID != nil {
+		var count uint
+		if err := db.Model(&userModels.UserTeam{}).
+			Joins("JOIN retrospectives ON retrospectives.team_id = user_teams.team_id").
+			Joins("JOIN sprints ON sprints.retrospective_id = retrospectives.id").
+			Where("user_teams.deleted_at IS NULL").
+			Where("retrospectives.deleted_at IS NULL").
+			Where("sprints.id = ?", meeting.SprintID).
+			Where("user_teams.user_id = ?", *meetingData.FacilitatorID).
+			Count(&count).Error; err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to update the retro meeting")
+		}
+		if count == 0 {
+			return nil, http.StatusBadRequest, errors.New("facilitator must be a member of the team")
+		}
+		meeting.FacilitatorID = *meetingData.FacilitatorID
+	}
+
+	if err := db.Set("gorm:save_associations", false).Save(meeting).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update the retro meeting")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// Timer starts, pauses, resumes or resets the timer of the retro meeting
+func (service RetroMeetingService) Timer(sprintID string, userID uint,
+	timerData *retroSerializers.RetroMeetingTimerSerializer) (*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.FacilitatorID != userID {
+		return nil, http.StatusForbidden, errors.New("only the facilitator can run the retro meeting")
+	}
+
+	now := time.Now()
+	timer := getRetroMeetingTimer(meeting, now)
+	switch timerData.Action {
+	case retroSerializers.StartRetroMeetingTimer:
+		if timerData.Duration == 0 {
+			return nil, http.StatusBadRequest, errors.New("timer duration must be more than zero")
+		}
+		meeting.TimerDuration = timerData.Duration
+		meeting.TimerRemaining = timerData.Duration
+		meeting.TimerStartedAt = &now
+	case retroSerializers.PauseRetroMeetingTimer:
+		if !timer.Running {
+			return nil, http.StatusBadRequest, errors.New("timer is not running")
+		}
+		meeting.TimerRemaining = timer.Remaining
+		meeting.TimerStartedAt = nil
+	case retroSerializers.ResumeRetroMeetingTimer:
+		if timer.Running || timer.Remaining == 0 {
+			return nil, http.StatusBadRequest, errors.New("timer is not paused")
+		}
+		meeting.TimerStartedAt = &now
+	case retroSerializers.ResetRetroMeetingTimer:
+		meeting.TimerDuration = 0
+		meeting.TimerRemaining = 0
+		meeting.TimerStartedAt = nil
+	default:
+		return nil, http.StatusBadRequest, errors.New("invalid timer action")
+	}
+
+	if err := db.Set("gorm:save_associations", false).Save(meeting).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update the timer")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// AddGroup adds a group with the given notes and highlights, moving them out of their current groups
+func (service RetroMeetingService) AddGroup(sprintID string, userID uint,
+	groupData *retroSerializers.RetroMeetingGroupSerializer) (*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.GroupPhase {
+		return nil, http.StatusBadRequest, errors.New("items can only be grouped in the group phase")
+	}
+
+	tx := db.Begin()
+	group := retroModels.RetroMeetingGroup{MeetingID: meeting.ID, Title: groupData.Title}
+	if err := tx.Create(&group).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add the group")
+	}
+	for _, feedbackID := range groupData.FeedbackIDs {
+		if status, err := service.addGroupItem(tx, meeting, group.ID, feedbackID); err != nil {
+			tx.Rollback()
+			return nil, status, err
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add the group")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// UpdateGroup renames the group, and replaces its items when the items are given
+func (service RetroMeetingService) UpdateGroup(sprintID string, groupID string, userID uint,
+	groupData *retroSerializers.RetroMeetingGroupSerializer) (*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.GroupPhase {
+		return nil, http.StatusBadRequest, errors.New("items can only be grouped in the group phase")
+	}
+	group, status, err := service.getGroup(db, meeting, groupID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	tx := db.Begin()
+	group.Title = groupData.Title
+	if err := tx.Set("gorm:save_associations", false).Save(group).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update the group")
+	}
+	if groupData.FeedbackIDs != nil {
+		if err := tx.Where("group_id = ?", group.ID).Delete(&retroModels.RetroMeetingGroupItem{}).Error; err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to update the group")
+		}
+		for _, feedbackID := range groupData.FeedbackIDs {
+			if status, err := service.addGroupItem(tx, meeting, group.ID, feedbackID); err != nil {
+				tx.Rollback()
+				return nil, status, err
+			}
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update the group")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// DeleteGroup deletes the group along with its votes, its items become ungrouped
+func (service RetroMeetingService) DeleteGroup(sprintID string, groupID string, userID uint) (
+	*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.GroupPhase {
+		return nil, http.StatusBadRequest, errors.New("items can only be grouped in the group phase")
+	}
+	group, status, err := service.getGroup(db, meeting, groupID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	tx := db.Begin()
+	if err := tx.Where("group_id = ?", group.ID).Delete(&retroModels.RetroMeetingGroupItem{}).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to delete the group")
+	}
+	if err := tx.Where("group_id = ?", group.ID).Delete(&retroModels.RetroMeetingVote{}).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to delete the group")
+	}
+	if err := tx.Delete(group).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to delete the group")
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to delete the group")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// AddGroupItem moves a note or a highlight into the group
+func (service RetroMeetingService) AddGroupItem(sprintID string, groupID string, userID uint,
+	itemData *retroSerializers.RetroMeetingGroupItemSerializer) (*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.GroupPhase {
+		return nil, http.StatusBadRequest, errors.New("items can only be grouped in the group phase")
+	}
+	group, status, err := service.getGroup(db, meeting, groupID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	tx := db.Begin()
+	if status, err := service.addGroupItem(tx, meeting, group.ID, itemData.FeedbackID); err != nil {
+		tx.Rollback()
+		return nil, status, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add the item to the group")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// RemoveGroupItem takes a note or a highlight out of the group
+func (service RetroMeetingService) RemoveGroupItem(sprintID string, groupID string, feedbackID string,
+	userID uint) (*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.GroupPhase {
+		return nil, http.StatusBadRequest, errors.New("items can only be grouped in the group phase")
+	}
+	group, status, err := service.getGroup(db, meeting, groupID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	if err := db.Where("group_id = ? AND feedback_id = ?", group.ID, feedbackID).
+		Delete(&retroModels.RetroMeetingGroupItem{}).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to remove the item from the group")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// Vote gives one of the user's dots to the group
+func (service RetroMeetingService) Vote(sprintID string, groupID string, userID uint) (
+	*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	tx := db.Begin()
+	// The meeting is locked so that the concurrent votes of a user can not go over the limit
+	meeting, status, err := service.getMeeting(tx.Set("gorm:query_option", "FOR UPDATE"), sprintID)
+	if err != nil {
+		tx.Rollback()
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.VotePhase {
+		tx.Rollback()
+		return nil, http.StatusBadRequest, errors.New("votes can only be given in the vote phase")
+	}
+	group, status, err := service.getGroup(tx, meeting, groupID)
+	if err != nil {
+		tx.Rollback()
+		return nil, status, err
+	}
+
+	var votesUsed uint
+	if err := tx.Model(&retroModels.RetroMeetingVote{}).
+		Joins("JOIN retro_meeting_groups ON retro_meeting_groups.id = retro_meeting_votes.group_id").
+		Where("retro_meeting_votes.deleted_at IS NULL").
+		Where("retro_meeting_groups.deleted_at IS NULL").
+		Where("retro_meeting_groups.meeting_id = ?", meeting.ID).
+		Where("retro_meeting_votes.voter_id = ?", userID).
+		Count(&votesUsed).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to vote")
+	}
+	if votesUsed >= meeting.VotesPerUser {
+		tx.Rollback()
+		return nil, http.StatusBadRequest, errors.New("no votes left")
+	}
+
+	if err := tx.Create(&retroModels.RetroMeetingVote{GroupID: group.ID, VoterID: userID}).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to vote")
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to vote")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// Unvote takes back one of the user's dots from the group
+func (service RetroMeetingService) Unvote(sprintID string, groupID string, userID uint) (
+	*retroSerializers.RetroMeeting, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.VotePhase {
+		return nil, http.StatusBadRequest, errors.New("votes can only be taken back in the vote phase")
+	}
+	group, status, err := service.getGroup(db, meeting, groupID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	vote := retroModels.RetroMeetingVote{}
+	if err := db.Model(&retroModels.RetroMeetingVote{}).
+		Where("retro_meeting_votes.deleted_at IS NULL").
+		Where("group_id = ? AND voter_id = ?", group.ID, userID).
+		Order("id DESC").
+		First(&vote).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("vote not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to take back the vote")
+	}
+
+	if err := db.Delete(&vote).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to take back the vote")
+	}
+
+	return service.Get(sprintID, userID)
+}
+
+// ConvertToGoal records the outcome of discussing the group as a goal of the sprint
+func (service RetroMeetingService) ConvertToGoal(sprintID string, retroID string, groupID string, userID uint,
+	goalData *retroSerializers.RetroMeetingGoalSerializer) (*retroSerializers.RetrospectiveFeedback, int, error) {
+	db := service.DB
+
+	meeting, status, err := service.getMeeting(db, sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	if meeting.Phase != retroModels.DiscussPhase && meeting.Phase != retroModels.ActionItemsPhase {
+		return nil, http.StatusBadRequest, errors.New("goals can only be added in the discuss and " +
+			"the action items phases")
+	}
+	group, status, err := service.getGroup(db, meeting, groupID)
+	if err != nil {
+		return nil, status, err
+	}
+	if group.GoalID != nil {
+		return nil, http.StatusBadRequest, errors.New("group already has a goal")
+	}
+	isAllowed, err := retroModels.IsAllowedFeedbackSubType(db, retroID, retroModels.GoalType, goalData.SubType)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add the goal")
+	}
+	if !isAllowed {
+		return nil, http.StatusBadRequest, errors.New("sub-type is not allowed by the retrospective's template")
+	}
+
+	tx := db.Begin()
+	feedbackService := RetrospectiveFeedbackService{DB: tx}
+	goal, status, err := feedbackService.Add(userID, sprintID, retroID, retroModels.GoalType,
+		&retroSerializers.RetrospectiveFeedbackCreateSerializer{SubType: goalData.SubType})
+	if err != nil {
+		tx.Rollback()
+		return nil, status, err
+	}
+
+	goalUpdateData := retroSerializers.RetrospectiveFeedbackUpdateSerializer{
+		Text:       &goalData.Text,
+		AssigneeID: goalData.AssigneeID,
+		ExpectedAt: goalData.ExpectedAt,
+	}
+	if goalData.AssigneeID != nil {
+		scope := int8(retroModels.IndividualScope)
+		goalUpdateData.Scope = &scope
+	}
+	goal, status, err = feedbackService.Update(userID, retroID, strconv.Itoa(int(goal.ID)), &goalUpdateData)
+	if err != nil {
+		tx.Rollback()
+		return nil, status, err
+	}
+
+	if err := tx.Model(&retroModels.RetroMeetingGroup{}).
+		Where("id = ?", group.ID).
+		Update("goal_id", goal.ID).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add the goal")
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add the goal")
+	}
+
+	return goal, http.StatusOK, nil
+}
+
+func (service RetroMeetingService) getMeeting(db *gorm.DB, sprintID string) (
+	*retroModels.RetroMeeting, int, error) {
+	meeting := retroModels.RetroMeeting{}
+	if err := db.Model(&retroModels.RetroMeeting{}).
+		Where("retro_meetings.deleted_at IS NULL").
+		Where("sprint_id = ?", sprintID).
+		Preload("Sprint").
+		First(&meeting).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retro meeting not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the retro meeting")
+	}
+	return &meeting, http.StatusOK, nil
+}
+
+func (service RetroMeetingService) getGroup(db *gorm.DB, meeting *retroModels.RetroMeeting, groupID string) (
+	*retroModels.RetroMeetingGroup, int, error) {
+	group := retroModels.RetroMeetingGroup{}
+	if err := db.Model(&retroModels.RetroMeetingGroup{}).
+		Where("retro_meeting_groups.deleted_at IS NULL").
+		Where("meeting_id = ?", meeting.ID).
+		Where("id = ?", groupID).
+		First(&group).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("group not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the group")
+	}
+	return &group, http.StatusOK, nil
+}
+
+// getMeetingItems returns the query of the notes and highlights of the meeting's sprint
+func (service RetroMeetingService) getMeetingItems(db *gorm.DB, meeting *retroModels.RetroMeeting) (
+	*gorm.DB, int, error) {
+	sprint := meeting.Sprint
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil, http.StatusBadRequest, errors.New("sprint dates are not set")
+	}
+	return db.Model(&retroModels.RetrospectiveFeedback{}).
+		Where("retrospective_feedbacks.deleted_at IS NULL").
+		Where("retrospective_feedbacks.retrospective_id = ?", sprint.RetrospectiveID).
+		Where("retrospective_feedbacks.type IN (?)",
+			[]retroModels.RetrospectiveFeedbackType{retroModels.NoteType, retroModels.HighlightType}).
+		Where("retrospective_feedbacks.added_at >= ? AND retrospective_feedbacks.added_at <= ?",
+			*sprint.StartDate, *sprint.EndDate), http.StatusOK, nil
+}
+
+// addGroupItem moves the note or the highlight into the group, out of any other group of the meeting
+func (service RetroMeetingService) addGroupItem(tx *gorm.DB, meeting *retroModels.RetroMeeting, groupID uint,
+	feedbackID uint) (int, error) {
+	itemsQuery, status, err := service.getMeetingItems(tx, meeting)
+	if err != nil {
+		return status, err
+	}
+	var count uint
+	if err := itemsQuery.Where("retrospective_feedbacks.id = ?", feedbackID).Count(&count).Error; err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to add the item to the group")
+	}
+	if count == 0 {
+		return http.StatusBadRequest, errors.New("only the notes and the highlights of the sprint can be grouped")
+	}
+
+	if err := tx.Where("feedback_id = ?", feedbackID).
+		Where("group_id IN (?)", tx.Model(&retroModels.RetroMeetingGroup{}).
+			Where("meeting_id = ?", meeting.ID).
+			Select("id").
+			QueryExpr()).
+		Delete(&retroModels.RetroMeetingGroupItem{}).Error; err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to add the item to the group")
+	}
+
+	if err := tx.Create(&retroModels.RetroMeetingGroupItem{GroupID: groupID, FeedbackID: feedbackID}).
+		Error; err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to add the item to the group")
+	}
+	return http.StatusOK, nil
+}
+
+// getRetroMeetingTimer returns the state of the meeting's timer at the given time
+func getRetroMeetingTimer(meeting *retroModels.RetroMeeting, now time.Time) retroSerializers.RetroMeetingTimer {
+	timer := retroSerializers.RetroMeetingTimer{
+		Duration:  meeting.TimerDuration,
+		Remaining: meeting.TimerRemaining,
+	}
+	if meeting.TimerStartedAt == nil {
+		return timer
+	}
+
+	elapsed := uint(now.Sub(*meeting.TimerStartedAt) / time.Second)
+	if elapsed >= meeting.TimerRemaining {
+		timer.Remaining = 0
+		return timer
+	}
+	timer.Remaining = meeting.TimerRemaining - elapsed
+	timer.Running = true
+	endsAt := meeting.TimerStartedAt.Add(time.Duration(meeting.TimerRemaining) * time.Second)
+	timer.EndsAt = &endsAt
+	return timer
+}
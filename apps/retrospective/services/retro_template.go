@@ -0,0 +1,35 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// RetroTemplateService ...
+type RetroTemplateService struct {
+	DB *gorm.DB
+}
+
+// List all the retro templates along with their sub-types
+func (service RetroTemplateService) List() (*retroSerializers.RetroTemplateListSerializer, int, error) {
+	db := service.DB
+	templates := &retroSerializers.RetroTemplateListSerializer{Templates: []retroSerializers.RetroTemplate{}}
+
+	if err := db.Model(&retroModels.RetroTemplate{}).
+		Where("retro_templates.deleted_at IS NULL").
+		Preload("SubTypes", func(db *gorm.DB) *gorm.DB {
+			return db.Where("retro_template_sub_types.deleted_at IS NULL").Order("type, id")
+		}).
+		Order("name").
+		Find(&templates.Templates).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the retro templates")
+	}
+	return templates, http.StatusOK, nil
+}
@@ -190,6 +190,18 @@ func (service RetrospectiveService) Create(userID uint,
 	retro.ProjectName = retrospectiveData.ProjectName
 	retro.TimeProviderName = retrospectiveData.TimeProviderName
 	retro.StoryPointPerWeek = retrospectiveData.StoryPointPerWeek
+	retro.AnonymousFeedback = retrospectiveData.AnonymousFeedback
+	retro.TemplateID = retrospectiveData.TemplateID
+	retro.TrackerWriteBack = retrospectiveData.TrackerWriteBack
+	retro.PointsAllocationStrategy = retroModels.PointsAllocationStrategy(retrospectiveData.PointsAllocationStrategy)
+	retro.ReviewerPointsWeight = retroModels.DefaultReviewerPointsWeight
+	if retrospectiveData.ReviewerPointsWeight != nil {
+		retro.ReviewerPointsWeight = *retrospectiveData.ReviewerPointsWeight
+	}
+	retro.SprintLengthDays = retrospectiveData.SprintLengthDays
+	retro.SprintStartWeekday = retrospectiveData.SprintStartWeekday
+	retro.SprintTitlePattern = retrospectiveData.SprintTitlePattern
+	retro.RollUpSubtasks = retrospectiveData.RollUpSubtasks
 
 	if err := tasktracker.ValidateConfigs(retrospectiveData.TaskProviderConfig); err != nil {
 		return nil, http.StatusBadRequest, err
@@ -232,6 +244,17 @@ func (service RetrospectiveService) Update(userID uint,
 	retro.ProjectName = retrospectiveData.ProjectName
 	retro.TimeProviderName = retrospectiveData.TimeProviderName
 	retro.StoryPointPerWeek = retrospectiveData.StoryPointPerWeek
+	retro.AnonymousFeedback = retrospectiveData.AnonymousFeedback
+	retro.TemplateID = retrospectiveData.TemplateID
+	retro.TrackerWriteBack = retrospectiveData.TrackerWriteBack
+	retro.PointsAllocationStrategy = retroModels.PointsAllocationStrategy(retrospectiveData.PointsAllocationStrategy)
+	if retrospectiveData.ReviewerPointsWeight != nil {
+		retro.ReviewerPointsWeight = *retrospectiveData.ReviewerPointsWeight
+	}
+	retro.SprintLengthDays = retrospectiveData.SprintLengthDays
+	retro.SprintStartWeekday = retrospectiveData.SprintStartWeekday
+	retro.SprintTitlePattern = retrospectiveData.SprintTitlePattern
+	retro.RollUpSubtasks = retrospectiveData.RollUpSubtasks
 
 	if retrospectiveData.CredentialsChanged {
 		if err := tasktracker.ValidateConfigs(retrospectiveData.TaskProviderConfig); err != nil {
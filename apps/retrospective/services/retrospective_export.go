@@ -0,0 +1,857 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	"github.com/iReflect/reflect-app/constants"
+	customErrors "github.com/iReflect/reflect-app/libs"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// RetrospectiveExportService exports/imports a whole retrospective to/from a portable document
+type RetrospectiveExportService struct {
+	DB *gorm.DB
+}
+
+// Export serializes the retrospective along with its sprints, tasks, members, feedbacks and trails
+func (service RetrospectiveExportService) Export(retroID string) (*retroSerializers.RetrospectiveExport, int, error) {
+	db := service.DB
+	var retro retroModels.Retrospective
+	var sprints []retroModels.Sprint
+	var sprintMembers []retroModels.SprintMember
+	var sprintTasks []retroModels.SprintTask
+	var sprintMemberTasks []retroModels.SprintMemberTask
+	var sprintMemberLeaves []retroModels.SprintMemberLeave
+	var tasks []retroModels.Task
+	var taskStatusTransitions []retroModels.TaskStatusTransition
+	var taskKeyMaps []retroModels.TaskKeyMap
+	var feedbacks []retroModels.RetrospectiveFeedback
+	var goalComments []retroModels.GoalComment
+
+	if err := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		Preload("Team").
+		Preload("Template").
+		First(&retro).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to export retrospective")
+	}
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("retrospective_id = ?", retro.ID).
+		Scopes(retroModels.NotDeletedSprint).
+		Order("start_date, id").
+		Find(&sprints).Error
+	sprintIDs := make([]uint, len(sprints))
+	for index, sprint := range sprints {
+		sprintIDs[index] = sprint.ID
+	}
+
+	if err == nil {
+		err = db.Model(&retroModels.SprintMember{}).
+			Where("sprint_members.deleted_at IS NULL").
+			Where("sprint_id IN (?)", sprintIDs).
+			Order("id").
+			Find(&sprintMembers).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.SprintTask{}).
+			Where("sprint_tasks.deleted_at IS NULL").
+			Where("sprint_id IN (?)", sprintIDs).
+			Order("id").
+			Find(&sprintTasks).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.SprintMemberTask{}).
+			Where("sprint_member_tasks.deleted_at IS NULL").
+			Scopes(retroModels.SMTJoinSM).
+			Where("sprint_members.sprint_id IN (?)", sprintIDs).
+			Select("sprint_member_tasks.*").
+			Order("sprint_member_tasks.id").
+			Find(&sprintMemberTasks).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.SprintMemberLeave{}).
+			Where("sprint_member_leaves.deleted_at IS NULL").
+			Scopes(retroModels.LeaveJoinSM).
+			Where("sprint_members.sprint_id IN (?)", sprintIDs).
+			Select("sprint_member_leaves.*").
+			Order("sprint_member_leaves.date, sprint_member_leaves.id").
+			Find(&sprintMemberLeaves).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.TaskStatusTransition{}).
+			Where("task_status_transitions.deleted_at IS NULL").
+			Where("task_status_transitions.task_id IN (?)", db.Model(&retroModels.Task{}).
+				Where("tasks.deleted_at IS NULL").
+				Where("retrospective_id = ?", retro.ID).
+				Select("id").
+				QueryExpr()).
+			Order("task_status_transitions.transitioned_at, task_status_transitions.id").
+			Find(&taskStatusTransitions).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.Task{}).
+			Where("tasks.deleted_at IS NULL").
+			Where("retrospective_id = ?", retro.ID).
+			Order("id").
+			Find(&tasks).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.TaskKeyMap{}).
+			Where("task_key_maps.deleted_at IS NULL").
+			Scopes(retroModels.TaskKeyMapJoinTask).
+			Where("tasks.retrospective_id = ?", retro.ID).
+			Select("task_key_maps.*").
+			Order("task_key_maps.id").
+			Find(&taskKeyMaps).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.RetrospectiveFeedback{}).
+			Where("retrospective_feedbacks.deleted_at IS NULL").
+			Where("retrospective_id = ?", retro.ID).
+			Order("id").
+			Find(&feedbacks).Error
+	}
+	if err == nil {
+		err = db.Model(&retroModels.GoalComment{}).
+			Where("goal_comments.deleted_at IS NULL").
+			Joins("JOIN retrospective_feedbacks ON retrospective_feedbacks.id = goal_comments.goal_id").
+			Where("retrospective_feedbacks.deleted_at IS NULL").
+			Where("retrospective_feedbacks.retrospective_id = ?", retro.ID).
+			Select("goal_comments.*").
+			Order("goal_comments.created_at, goal_comments.id").
+			Find(&goalComments).Error
+	}
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to export retrospective")
+	}
+
+	itemIDs := map[constants.ActionItemType][]uint{
+		constants.Retrospective: {retro.ID},
+		constants.Sprint:        sprintIDs,
+	}
+	for _, sprintMember := range sprintMembers {
+		itemIDs[constants.SprintMember] = append(itemIDs[constants.SprintMember], sprintMember.ID)
+	}
+	for _, sprintTask := range sprintTasks {
+		itemIDs[constants.SprintTask] = append(itemIDs[constants.SprintTask], sprintTask.ID)
+	}
+	for _, sprintMemberTask := range sprintMemberTasks {
+		itemIDs[constants.SprintMemberTask] = append(itemIDs[constants.SprintMemberTask], sprintMemberTask.ID)
+	}
+	for _, feedback := range feedbacks {
+		itemIDs[constants.RetrospectiveFeedback] = append(itemIDs[constants.RetrospectiveFeedback], feedback.ID)
+	}
+	trails, err := service.getItemTrails(itemIDs)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to export retrospective")
+	}
+
+	userIDs := []uint{retro.CreatedByID}
+	for _, sprint := range sprints {
+		userIDs = append(userIDs, sprint.CreatedByID)
+	}
+	for _, sprintMember := range sprintMembers {
+		userIDs = append(userIDs, sprintMember.MemberID)
+	}
+	for _, feedback := range feedbacks {
+		userIDs = append(userIDs, feedback.CreatedByID)
+		if feedback.AssigneeID != nil {
+			userIDs = append(userIDs, *feedback.AssigneeID)
+		}
+	}
+	for _, goalComment := range goalComments {
+		userIDs = append(userIDs, goalComment.CreatedByID)
+	}
+	for _, trail := range trails {
+		userIDs = append(userIDs, trail.ActionByID)
+	}
+	emails, err := service.getUserEmails(userIDs)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to export retrospective")
+	}
+
+	// The secrets are encrypted with the key of this server, they are given again on import
+	taskProviderConfig, err := tasktracker.RemoveSecretCredentials(retro.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to export retrospective")
+	}
+
+	export := &retroSerializers.RetrospectiveExport{
+		Version:    retroSerializers.RetrospectiveExportVersion,
+		ExportedAt: time.Now(),
+		Retrospective: retroSerializers.ExportedRetrospective{
+			ID:                       retro.ID,
+			Title:                    retro.Title,
+			ProjectName:              retro.ProjectName,
+			TaskProviderConfig:       taskProviderConfig,
+			TimeProviderName:         retro.TimeProviderName,
+			TeamName:                 retro.Team.Name,
+			StoryPointPerWeek:        retro.StoryPointPerWeek,
+			AnonymousFeedback:        retro.AnonymousFeedback,
+			TrackerWriteBack:         retro.TrackerWriteBack,
+			PointsAllocationStrategy: retro.PointsAllocationStrategy,
+			ReviewerPointsWeight:     retro.ReviewerPointsWeight,
+			SprintLengthDays:         retro.SprintLengthDays,
+			SprintStartWeekday:       retro.SprintStartWeekday,
+			SprintTitlePattern:       retro.SprintTitlePattern,
+			RollUpSubtasks:           retro.RollUpSubtasks,
+			CreatedBy:                emails[retro.CreatedByID],
+			CreatedAt:                retro.CreatedAt,
+		},
+	}
+	if retro.Template != nil {
+		export.Retrospective.Template = &retro.Template.Name
+	}
+
+	taskKeys := make(map[uint][]string)
+	for _, taskKeyMap := range taskKeyMaps {
+		taskKeys[taskKeyMap.TaskID] = append(taskKeys[taskKeyMap.TaskID], taskKeyMap.Key)
+	}
+	taskTransitions := make(map[uint][]retroSerializers.ExportedTaskStatusTransition)
+	for _, transition := range taskStatusTransitions {
+		taskTransitions[transition.TaskID] = append(taskTransitions[transition.TaskID],
+			retroSerializers.ExportedTaskStatusTransition{
+				FromStatus:     transition.FromStatus,
+				ToStatus:       transition.ToStatus,
+				TransitionedAt: transition.TransitionedAt,
+				TransitionedBy: transition.TransitionedBy,
+			})
+	}
+	for _, task := range tasks {
+		export.Retrospective.Tasks = append(export.Retrospective.Tasks, retroSerializers.ExportedTask{
+			ID:                    task.ID,
+			Key:                   task.Key,
+			Keys:                  taskKeys[task.ID],
+			TrackerUniqueID:       task.TrackerUniqueID,
+			Summary:               task.Summary,
+			Description:           task.Description,
+			Type:                  task.Type,
+			Status:                task.Status,
+			Priority:              task.Priority,
+			Assignee:              task.Assignee,
+			Estimate:              task.Estimate,
+			Fields:                task.Fields,
+			Rating:                task.Rating,
+			DoneAt:                task.DoneAt,
+			IsTrackerTask:         task.IsTrackerTask,
+			Resolution:            task.Resolution,
+			ParentTrackerUniqueID: task.ParentTrackerUniqueID,
+			EpicKey:               task.EpicKey,
+			TrackerCreatedAt:      task.TrackerCreatedAt,
+			Transitions:           taskTransitions[task.ID],
+		})
+	}
+
+	memberLeaves := make(map[uint][]retroSerializers.ExportedSprintMemberLeave)
+	for _, leave := range sprintMemberLeaves {
+		memberLeaves[leave.SprintMemberID] = append(memberLeaves[leave.SprintMemberID],
+			retroSerializers.ExportedSprintMemberLeave{
+				Date:    leave.Date,
+				Type:    leave.Type,
+				HalfDay: leave.HalfDay,
+				Note:    leave.Note,
+			})
+	}
+
+	memberTasks := make(map[uint][]retroSerializers.ExportedSprintMemberTask)
+	for _, smt := range sprintMemberTasks {
+		memberTasks[smt.SprintTaskID] = append(memberTasks[smt.SprintTaskID], retroSerializers.ExportedSprintMemberTask{
+			ID:               smt.ID,
+			SprintMemberID:   smt.SprintMemberID,
+			TimeSpentMinutes: smt.TimeSpentMinutes,
+			PointsEarned:     smt.PointsEarned,
+			PointsAssigned:   smt.PointsAssigned,
+			PlannedPoints:    smt.PlannedPoints,
+			Rating:           smt.Rating,
+			Comment:          smt.Comment,
+			Role:             smt.Role,
+			PointsLocked:     smt.PointsLocked,
+			PointsLockReason: smt.PointsLockReason,
+			PointsLockedAt:   smt.PointsLockedAt,
+		})
+	}
+	for _, sprint := range sprints {
+		exportedSprint := retroSerializers.ExportedSprint{
+			ID:           sprint.ID,
+			Title:        sprint.Title,
+			SprintID:     sprint.SprintID,
+			Status:       sprint.Status,
+			StartDate:    sprint.StartDate,
+			EndDate:      sprint.EndDate,
+			LastSyncedAt: sprint.LastSyncedAt,
+			CreatedBy:    emails[sprint.CreatedByID],
+			CreatedAt:    sprint.CreatedAt,
+		}
+		for _, sprintMember := range sprintMembers {
+			if sprintMember.SprintID != sprint.ID {
+				continue
+			}
+			exportedSprint.Members = append(exportedSprint.Members, retroSerializers.ExportedSprintMember{
+				ID:                 sprintMember.ID,
+				Member:             emails[sprintMember.MemberID],
+				AllocationPercent:  sprintMember.AllocationPercent,
+				ExpectationPercent: sprintMember.ExpectationPercent,
+				Vacations:          sprintMember.Vacations,
+				Rating:             sprintMember.Rating,
+				Comment:            sprintMember.Comment,
+				Leaves:             memberLeaves[sprintMember.ID],
+			})
+		}
+		for _, sprintTask := range sprintTasks {
+			if sprintTask.SprintID != sprint.ID {
+				continue
+			}
+			exportedSprint.Tasks = append(exportedSprint.Tasks, retroSerializers.ExportedSprintTask{
+				ID:              sprintTask.ID,
+				TaskID:          sprintTask.TaskID,
+				PlannedEstimate: sprintTask.PlannedEstimate,
+				InTrackerSprint: sprintTask.InTrackerSprint,
+				MemberTasks:     memberTasks[sprintTask.ID],
+			})
+		}
+		export.Retrospective.Sprints = append(export.Retrospective.Sprints, exportedSprint)
+	}
+
+	// The authors of the anonymous feedbacks, and the users acting on them, are left out of the document
+	comments := make(map[uint][]retroSerializers.ExportedGoalComment)
+	for _, goalComment := range goalComments {
+		comments[goalComment.GoalID] = append(comments[goalComment.GoalID], retroSerializers.ExportedGoalComment{
+			Text:      goalComment.Text,
+			CreatedBy: emails[goalComment.CreatedByID],
+			CreatedAt: goalComment.CreatedAt,
+		})
+	}
+
+	anonymousFeedbacks := make(map[uint]bool)
+	for _, feedback := range feedbacks {
+		exportedFeedback := retroSerializers.ExportedRetrospectiveFeedback{
+			ID:                feedback.ID,
+			SubType:           feedback.SubType,
+			Type:              feedback.Type,
+			Text:              feedback.Text,
+			Scope:             feedback.Scope,
+			AddedAt:           feedback.AddedAt,
+			ResolvedAt:        feedback.ResolvedAt,
+			ExpectedAt:        feedback.ExpectedAt,
+			Anonymous:         feedback.Anonymous,
+			Progress:          feedback.Progress,
+			CarryOverCount:    feedback.CarryOverCount,
+			TrackerTaskKey:    feedback.TrackerTaskKey,
+			TrackerTaskStatus: feedback.TrackerTaskStatus,
+			CreatedBy:         emails[feedback.CreatedByID],
+			CreatedAt:         feedback.CreatedAt,
+			Comments:          comments[feedback.ID],
+		}
+		if feedback.Anonymous {
+			anonymousFeedbacks[feedback.ID] = true
+			exportedFeedback.CreatedBy = ""
+		}
+		if feedback.AssigneeID != nil {
+			assignee := emails[*feedback.AssigneeID]
+			exportedFeedback.Assignee = &assignee
+		}
+		export.Retrospective.Feedbacks = append(export.Retrospective.Feedbacks, exportedFeedback)
+	}
+
+	for _, trail := range trails {
+		exportedTrail := retroSerializers.ExportedTrail{
+			Action:       trail.Action,
+			ActionItem:   trail.ActionItem,
+			ActionItemID: trail.ActionItemID,
+			ActionBy:     emails[trail.ActionByID],
+			Changes:      trail.Changes,
+			CreatedAt:    trail.CreatedAt,
+		}
+		if trail.ActionItem == constants.ActionItemTypeMap[constants.RetrospectiveFeedback] &&
+			anonymousFeedbacks[trail.ActionItemID] {
+			exportedTrail.ActionBy = ""
+		}
+		export.Retrospective.Trails = append(export.Retrospective.Trails, exportedTrail)
+	}
+
+	return export, http.StatusOK, nil
+}
+
+// Import creates a new retrospective for the given team from an exported document, all the IDs are remapped and
+// the users are matched by their email
+func (service RetrospectiveExportService) Import(teamID uint,
+	export *retroSerializers.RetrospectiveExport) (*retroModels.Retrospective, int, error) {
+	db := service.DB
+
+	if export.Version != retroSerializers.RetrospectiveExportVersion {
+		return nil, http.StatusBadRequest, fmt.Errorf("unsupported export version %d", export.Version)
+	}
+	exported := export.Retrospective
+
+	if err := db.Model(&userModels.Team{}).
+		Where("teams.deleted_at IS NULL").
+		Where("id = ?", teamID).
+		First(&userModels.Team{}).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("team not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import retrospective")
+	}
+
+	users, err := service.getUsersByEmail(exported)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	taskProviderConfig, err := tasktracker.SetCredentials(exported.TaskProviderConfig, export.TaskProviderCredentials)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	if err = tasktracker.ValidateConfigs(taskProviderConfig); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	taskProviders, err := json.Marshal(taskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import retrospective")
+	}
+	if exported.TaskProviderConfig, err = tasktracker.EncryptTaskProviders(taskProviders); err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to import retrospective")
+	}
+
+	// The template is matched by its name, the retrospective is imported without one if it is not found
+	var templateID *uint
+	if exported.Template != nil {
+		template := retroModels.RetroTemplate{}
+		err = db.Model(&retroModels.RetroTemplate{}).
+			Where("retro_templates.deleted_at IS NULL").
+			Where("name = ?", *exported.Template).
+			First(&template).Error
+		if err == nil {
+			templateID = &template.ID
+		} else if err != gorm.ErrRecordNotFound {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to import retrospective")
+		}
+	}
+
+	tx := db.Begin()
+	retro, err := importRetrospective(tx, teamID, templateID, exported, users)
+	if err != nil {
+		tx.Rollback()
+		if customErrors.IsModelError(err) {
+			return nil, http.StatusBadRequest, err
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import retrospective")
+	}
+	if err = tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import retrospective")
+	}
+
+	return retro, http.StatusCreated, nil
+}
+
+func importRetrospective(tx *gorm.DB, teamID uint, templateID *uint,
+	exported retroSerializers.ExportedRetrospective, users map[string]uint) (*retroModels.Retrospective, error) {
+	// old id -> new id, per trail action item type
+	idMaps := map[string]map[uint]uint{}
+	for _, itemType := range constants.ActionItemTypeMap {
+		idMaps[itemType] = map[uint]uint{}
+	}
+	taskIDs := map[uint]uint{}
+
+	retro := retroModels.Retrospective{
+		Title:                    exported.Title,
+		ProjectName:              exported.ProjectName,
+		TaskProviderConfig:       exported.TaskProviderConfig,
+		TimeProviderName:         exported.TimeProviderName,
+		TeamID:                   teamID,
+		StoryPointPerWeek:        exported.StoryPointPerWeek,
+		AnonymousFeedback:        exported.AnonymousFeedback,
+		TrackerWriteBack:         exported.TrackerWriteBack,
+		PointsAllocationStrategy: exported.PointsAllocationStrategy,
+		ReviewerPointsWeight:     exported.ReviewerPointsWeight,
+		SprintLengthDays:         exported.SprintLengthDays,
+		SprintStartWeekday:       exported.SprintStartWeekday,
+		SprintTitlePattern:       exported.SprintTitlePattern,
+		RollUpSubtasks:           exported.RollUpSubtasks,
+		TemplateID:               templateID,
+		CreatedByID:              users[exported.CreatedBy],
+	}
+	retro.CreatedAt = exported.CreatedAt
+	if err := tx.Create(&retro).Error; err != nil {
+		return nil, err
+	}
+	idMaps[constants.ActionItemTypeMap[constants.Retrospective]][exported.ID] = retro.ID
+
+	for _, exportedTask := range exported.Tasks {
+		task := retroModels.Task{
+			Key:                   exportedTask.Key,
+			TrackerUniqueID:       exportedTask.TrackerUniqueID,
+			RetrospectiveID:       retro.ID,
+			Summary:               exportedTask.Summary,
+			Description:           exportedTask.Description,
+			Type:                  exportedTask.Type,
+			Status:                exportedTask.Status,
+			Priority:              exportedTask.Priority,
+			Assignee:              exportedTask.Assignee,
+			Estimate:              exportedTask.Estimate,
+			Fields:                exportedTask.Fields,
+			Rating:                exportedTask.Rating,
+			DoneAt:                exportedTask.DoneAt,
+			IsTrackerTask:         exportedTask.IsTrackerTask,
+			Resolution:            exportedTask.Resolution,
+			ParentTrackerUniqueID: exportedTask.ParentTrackerUniqueID,
+			EpicKey:               exportedTask.EpicKey,
+			TrackerCreatedAt:      exportedTask.TrackerCreatedAt,
+		}
+		if task.Fields.IsNull() {
+			task.Fields = []byte("{}")
+		}
+		if err := tx.Create(&task).Error; err != nil {
+			return nil, err
+		}
+		taskIDs[exportedTask.ID] = task.ID
+
+		for _, key := range exportedTask.Keys {
+			if err := tx.Create(&retroModels.TaskKeyMap{TaskID: task.ID, Key: key}).Error; err != nil {
+				return nil, err
+			}
+		}
+
+		for _, exportedTransition := range exportedTask.Transitions {
+			transition := retroModels.TaskStatusTransition{
+				TaskID:         task.ID,
+				FromStatus:     exportedTransition.FromStatus,
+				ToStatus:       exportedTransition.ToStatus,
+				TransitionedAt: exportedTransition.TransitionedAt,
+				TransitionedBy: exportedTransition.TransitionedBy,
+			}
+			if err := tx.Set("gorm:save_associations", false).Create(&transition).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := retroModels.LinkTaskParents(tx, retro.ID); err != nil {
+		return nil, err
+	}
+
+	// Sprints are created in the order of their start date so that the date continuity validations pass
+	exportedSprints := append([]retroSerializers.ExportedSprint{}, exported.Sprints...)
+	sort.SliceStable(exportedSprints, func(i, j int) bool {
+		if exportedSprints[i].StartDate == nil || exportedSprints[j].StartDate == nil {
+			return exportedSprints[j].StartDate == nil && exportedSprints[i].StartDate != nil
+		}
+		return exportedSprints[i].StartDate.Before(*exportedSprints[j].StartDate)
+	})
+
+	for _, exportedSprint := range exportedSprints {
+		sprint := retroModels.Sprint{
+			Title:           exportedSprint.Title,
+			SprintID:        exportedSprint.SprintID,
+			RetrospectiveID: retro.ID,
+			Status:          exportedSprint.Status,
+			StartDate:       exportedSprint.StartDate,
+			EndDate:         exportedSprint.EndDate,
+			LastSyncedAt:    exportedSprint.LastSyncedAt,
+			CreatedByID:     users[exportedSprint.CreatedBy],
+		}
+		sprint.CreatedAt = exportedSprint.CreatedAt
+		if err := tx.Create(&sprint).Error; err != nil {
+			return nil, err
+		}
+		idMaps[constants.ActionItemTypeMap[constants.Sprint]][exportedSprint.ID] = sprint.ID
+
+		sprintMemberIDs := idMaps[constants.ActionItemTypeMap[constants.SprintMember]]
+		for _, exportedMember := range exportedSprint.Members {
+			sprintMember := retroModels.SprintMember{
+				SprintID:           sprint.ID,
+				Sprint:             sprint,
+				MemberID:           users[exportedMember.Member],
+				AllocationPercent:  exportedMember.AllocationPercent,
+				ExpectationPercent: exportedMember.ExpectationPercent,
+				Vacations:          exportedMember.Vacations,
+				UndatedVacations:   exportedMember.Vacations,
+				Rating:             exportedMember.Rating,
+				Comment:            exportedMember.Comment,
+			}
+			// The vacations not covered by the leaves were entered without dates
+			for _, exportedLeave := range exportedMember.Leaves {
+				sprintMember.UndatedVacations -= retroModels.SprintMemberLeave{HalfDay: exportedLeave.HalfDay}.GetDays()
+			}
+			if sprintMember.UndatedVacations < 0 {
+				sprintMember.UndatedVacations = 0
+			}
+			if err := tx.Set("gorm:save_associations", false).Create(&sprintMember).Error; err != nil {
+				return nil, err
+			}
+			sprintMemberIDs[exportedMember.ID] = sprintMember.ID
+
+			for _, exportedLeave := range exportedMember.Leaves {
+				leave := retroModels.SprintMemberLeave{
+					SprintMemberID: sprintMember.ID,
+					Date:           exportedLeave.Date,
+					Type:           exportedLeave.Type,
+					HalfDay:        exportedLeave.HalfDay,
+					Note:           exportedLeave.Note,
+				}
+				if err := tx.Set("gorm:save_associations", false).Create(&leave).Error; err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for _, exportedSprintTask := range exportedSprint.Tasks {
+			taskID, ok := taskIDs[exportedSprintTask.TaskID]
+			if !ok {
+				return nil, &customErrors.ModelError{
+					Message: fmt.Sprintf("sprint task %d refers to an unknown task", exportedSprintTask.ID)}
+			}
+			sprintTask := retroModels.SprintTask{SprintID: sprint.ID, TaskID: taskID,
+				PlannedEstimate: exportedSprintTask.PlannedEstimate, InTrackerSprint: exportedSprintTask.InTrackerSprint}
+			if err := tx.Create(&sprintTask).Error; err != nil {
+				return nil, err
+			}
+			idMaps[constants.ActionItemTypeMap[constants.SprintTask]][exportedSprintTask.ID] = sprintTask.ID
+
+			for _, exportedMemberTask := range exportedSprintTask.MemberTasks {
+				sprintMemberID, ok := sprintMemberIDs[exportedMemberTask.SprintMemberID]
+				if !ok {
+					return nil, &customErrors.ModelError{
+						Message: fmt.Sprintf("sprint member task %d refers to an unknown sprint member",
+							exportedMemberTask.ID)}
+				}
+				sprintMemberTask := retroModels.SprintMemberTask{
+					SprintMemberID:   sprintMemberID,
+					SprintTaskID:     sprintTask.ID,
+					TimeSpentMinutes: exportedMemberTask.TimeSpentMinutes,
+					PointsEarned:     exportedMemberTask.PointsEarned,
+					PointsAssigned:   exportedMemberTask.PointsAssigned,
+					PlannedPoints:    exportedMemberTask.PlannedPoints,
+					Rating:           exportedMemberTask.Rating,
+					Comment:          exportedMemberTask.Comment,
+					Role:             exportedMemberTask.Role,
+					PointsLocked:     exportedMemberTask.PointsLocked,
+					PointsLockReason: exportedMemberTask.PointsLockReason,
+					PointsLockedAt:   exportedMemberTask.PointsLockedAt,
+				}
+				// The points were already validated on the source instance
+				if err := tx.Set("smt:disable_validate", true).Create(&sprintMemberTask).Error; err != nil {
+					return nil, err
+				}
+				idMaps[constants.ActionItemTypeMap[constants.SprintMemberTask]][exportedMemberTask.ID] = sprintMemberTask.ID
+			}
+		}
+	}
+
+	for _, exportedFeedback := range exported.Feedbacks {
+		feedback := retroModels.RetrospectiveFeedback{
+			SubType:           exportedFeedback.SubType,
+			Type:              exportedFeedback.Type,
+			RetrospectiveID:   retro.ID,
+			Text:              exportedFeedback.Text,
+			Scope:             exportedFeedback.Scope,
+			AddedAt:           exportedFeedback.AddedAt,
+			ResolvedAt:        exportedFeedback.ResolvedAt,
+			ExpectedAt:        exportedFeedback.ExpectedAt,
+			Anonymous:         exportedFeedback.Anonymous,
+			Progress:          exportedFeedback.Progress,
+			CarryOverCount:    exportedFeedback.CarryOverCount,
+			TrackerTaskKey:    exportedFeedback.TrackerTaskKey,
+			TrackerTaskStatus: exportedFeedback.TrackerTaskStatus,
+			CreatedByID:       users[exportedFeedback.CreatedBy],
+		}
+		// The authors of the anonymous feedbacks are not a part of the document
+		if exportedFeedback.CreatedBy == "" {
+			authorID, err := getImportedAuthorID(tx)
+			if err != nil {
+				return nil, err
+			}
+			feedback.CreatedByID = authorID
+		}
+		if exportedFeedback.Assignee != nil {
+			assigneeID := users[*exportedFeedback.Assignee]
+			feedback.AssigneeID = &assigneeID
+		}
+		feedback.CreatedAt = exportedFeedback.CreatedAt
+		if err := tx.Create(&feedback).Error; err != nil {
+			return nil, err
+		}
+		idMaps[constants.ActionItemTypeMap[constants.RetrospectiveFeedback]][exportedFeedback.ID] = feedback.ID
+		for _, exportedComment := range exportedFeedback.Comments {
+			comment := retroModels.GoalComment{
+				GoalID:      feedback.ID,
+				Text:        exportedComment.Text,
+				CreatedByID: users[exportedComment.CreatedBy],
+			}
+			comment.CreatedAt = exportedComment.CreatedAt
+			if err := tx.Create(&comment).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, exportedTrail := range exported.Trails {
+		actionItemID, ok := idMaps[exportedTrail.ActionItem][exportedTrail.ActionItemID]
+		if !ok {
+			// Trails of items which are not a part of the document can not be remapped
+			continue
+		}
+		trail := retroModels.Trail{
+			Action:       exportedTrail.Action,
+			ActionItem:   exportedTrail.ActionItem,
+			ActionItemID: actionItemID,
+			ActionByID:   users[exportedTrail.ActionBy],
+			Changes:      exportedTrail.Changes,
+		}
+		if exportedTrail.ActionBy == "" {
+			authorID, err := getImportedAuthorID(tx)
+			if err != nil {
+				return nil, err
+			}
+			trail.ActionByID = authorID
+		}
+		trail.CreatedAt = exportedTrail.CreatedAt
+		if err := tx.Create(&trail).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return &retro, nil
+}
+
+// getImportedAuthorID returns the ID of the inactive user the imported items without an author are attributed
+// to, so that the anonymous feedbacks are not shown as written by a member
+func getImportedAuthorID(tx *gorm.DB) (uint, error) {
+	author := userModels.User{}
+	err := tx.Where(userModels.User{Email: constants.ImportedAuthorEmail}).
+		Attrs(userModels.User{FirstName: "Imported", LastName: "Author", TimeProviderConfig: []byte("[]")}).
+		FirstOrCreate(&author).Error
+	if err != nil {
+		return 0, err
+	}
+	// Active defaults to true on create, the author must never be able to sign in
+	if author.Active {
+		err = tx.Model(&userModels.User{}).Where("id = ?", author.ID).UpdateColumn("active", false).Error
+	}
+	return author.ID, err
+}
+
+// getItemTrails returns the trails of the given items
+func (service RetrospectiveExportService) getItemTrails(
+	itemIDs map[constants.ActionItemType][]uint) (trails []retroModels.Trail, err error) {
+	db := service.DB
+	var conditions []string
+	var values []interface{}
+	for itemType, ids := range itemIDs {
+		if len(ids) == 0 {
+			continue
+		}
+		conditions = append(conditions, "(trails.action_item = ? AND trails.action_item_id IN (?))")
+		values = append(values, constants.ActionItemTypeMap[itemType], ids)
+	}
+
+	err = db.Model(&retroModels.Trail{}).
+		Where("trails.deleted_at IS NULL").
+		Where(strings.Join(conditions, " OR "), values...).
+		Order("created_at, id").
+		Find(&trails).Error
+	return trails, err
+}
+
+// getUserEmails returns the emails of the given users, see getImportedAuthorID
+func (service RetrospectiveExportService) getUserEmails(userIDs []uint) (map[uint]string, error) {
+	db := service.DB
+	var users []userModels.User
+	emails := make(map[uint]string)
+
+	if err := db.Model(&userModels.User{}).
+		Where("id IN (?)", userIDs).
+		Select("id, email").
+		Find(&users).Error; err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		// The items of the imported author are exported without an author, as they were imported
+		if user.Email != constants.ImportedAuthorEmail {
+			emails[user.ID] = user.Email
+		}
+	}
+	return emails, nil
+}
+
+// getUsersByEmail returns the ids of all the users referenced in the document, it fails if any of them is missing
+func (service RetrospectiveExportService) getUsersByEmail(
+	exported retroSerializers.ExportedRetrospective) (map[string]uint, error) {
+	db := service.DB
+	var users []userModels.User
+	userIDs := make(map[string]uint)
+
+	emails := []string{exported.CreatedBy}
+	for _, sprint := range exported.Sprints {
+		emails = append(emails, sprint.CreatedBy)
+		for _, member := range sprint.Members {
+			emails = append(emails, member.Member)
+		}
+	}
+	for _, feedback := range exported.Feedbacks {
+		if feedback.CreatedBy != "" {
+			emails = append(emails, feedback.CreatedBy)
+		}
+		if feedback.Assignee != nil {
+			emails = append(emails, *feedback.Assignee)
+		}
+		for _, comment := range feedback.Comments {
+			emails = append(emails, comment.CreatedBy)
+		}
+	}
+	for _, trail := range exported.Trails {
+		if trail.ActionBy != "" {
+			emails = append(emails, trail.ActionBy)
+		}
+	}
+
+	if err := db.Model(&userModels.User{}).
+		Where("users.deleted_at IS NULL").
+		Where("email IN (?)", emails).
+		Select("id, email").
+		Find(&users).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, errors.New("failed to get users")
+	}
+	for _, user := range users {
+		userIDs[user.Email] = user.ID
+	}
+
+	var missingEmails []string
+	for _, email := range utils.RemoveDuplicatesFromSlice(emails) {
+		if _, ok := userIDs[email]; !ok {
+			missingEmails = append(missingEmails, email)
+		}
+	}
+	if len(missingEmails) > 0 {
+		return nil, fmt.Errorf("users not found: %s", strings.Join(missingEmails, ", "))
+	}
+	return userIDs, nil
+}
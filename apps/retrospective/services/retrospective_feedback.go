@@ -1,12 +1,17 @@
 package services
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/iReflect/reflect-app/apps/retrospective/models"
 	retrospectiveSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
+	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
 	"github.com/iReflect/reflect-app/libs/utils"
 	"github.com/jinzhu/gorm"
 	"github.com/pkg/errors"
@@ -43,6 +48,18 @@ func (service RetrospectiveFeedbackService) Add(userID uint, sprintID string, re
 		return nil, http.StatusInternalServerError, errors.New("failed to get sprint")
 	}
 
+	retro := models.Retrospective{}
+	if err := db.Model(&models.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		First(&retro).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get retrospective")
+	}
+
 	retroFeedback := models.RetrospectiveFeedback{
 		RetrospectiveID: uint(retroIDInt),
 		SubType:         feedbackData.SubType,
@@ -52,6 +69,8 @@ func (service RetrospectiveFeedbackService) Add(userID uint, sprintID string, re
 		AssigneeID:      nil,
 		ExpectedAt:      nil,
 		ResolvedAt:      nil,
+		// Goals are never anonymous as they are assigned and followed up on
+		Anonymous: feedbackType != models.GoalType && (retro.AnonymousFeedback || feedbackData.Anonymous),
 	}
 
 	if feedbackType != models.GoalType {
@@ -77,6 +96,13 @@ func (service RetrospectiveFeedbackService) Add(userID uint, sprintID string, re
 		return nil, http.StatusInternalServerError, errors.New("failed to get sprint")
 	}
 
+	if feedbackType == models.GoalType {
+		// A goal added in a past sprint has already been carried over into the sprints after it
+		if err = models.UpdateGoalCarryOverCounts(db, retroFeedback.RetrospectiveID); err != nil {
+			utils.LogToSentry(err)
+		}
+	}
+
 	return service.getRetrospectiveFeedback(retroFeedback.ID)
 
 }
@@ -130,6 +156,25 @@ func (service RetrospectiveFeedbackService) Update(userID uint, retroID string,
 		retroFeedback.AssigneeID = feedbackData.AssigneeID
 	}
 
+	if feedbackData.Progress != nil || feedbackData.TrackerTaskKey != nil {
+		if retroFeedback.Type != models.GoalType {
+			return nil, http.StatusBadRequest, errors.New("progress and tracker task can be updated only for goal " +
+				"type retrospective feedback")
+		}
+		if feedbackData.Progress != nil {
+			retroFeedback.Progress = models.GoalProgress(*feedbackData.Progress)
+		}
+		if feedbackData.TrackerTaskKey != nil {
+			retroFeedback.TrackerTaskKey = feedbackData.TrackerTaskKey
+			retroFeedback.TrackerTaskPending = false
+			// The status of the newly linked ticket is fetched by the next sprint sync
+			retroFeedback.TrackerTaskStatus = ""
+			if *feedbackData.TrackerTaskKey == "" {
+				retroFeedback.TrackerTaskKey = nil
+			}
+		}
+	}
+
 	err := db.Save(&retroFeedback).Error
 	if err != nil {
 		utils.LogToSentry(err)
@@ -193,9 +238,117 @@ func (service RetrospectiveFeedbackService) Resolve(userID uint, sprintID string
 		return nil, http.StatusInternalServerError, errors.New("failed to resolve goal")
 	}
 
+	if err = models.UpdateGoalCarryOverCounts(db, retroFeedback.RetrospectiveID); err != nil {
+		utils.LogToSentry(err)
+	}
+
 	return service.getRetrospectiveFeedback(retroFeedback.ID)
 }
 
+// CreateTrackerTask creates a ticket for the goal in the retrospective's task tracker and links it to the goal
+func (service RetrospectiveFeedbackService) CreateTrackerTask(retroID string, goalID string) (
+	*retrospectiveSerializers.RetrospectiveFeedback,
+	int,
+	error) {
+	db := service.DB
+
+	// The goal is marked as pending before the ticket is created, so that neither concurrent requests nor the
+	// retries of a request whose ticket could not be linked create more than one ticket
+	tx := db.Begin()
+	goal := models.RetrospectiveFeedback{}
+	if err := tx.Set("gorm:query_option", "FOR UPDATE").
+		Model(&models.RetrospectiveFeedback{}).
+		Where("retrospective_feedbacks.deleted_at IS NULL").
+		Where("id = ? AND retrospective_id = ? AND type = ?", goalID, retroID, models.GoalType).
+		First(&goal).Error; err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("goal not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get goal")
+	}
+
+	if goal.ResolvedAt != nil {
+		tx.Rollback()
+		return nil, http.StatusBadRequest, errors.New("can not create a ticket for a resolved goal")
+	}
+	if goal.TrackerTaskKey != nil {
+		tx.Rollback()
+		return nil, http.StatusBadRequest, errors.New("goal is already linked to a ticket")
+	}
+	if goal.TrackerTaskPending {
+		tx.Rollback()
+		return nil, http.StatusConflict, errors.New("a ticket is already being created for the goal, " +
+			"if it was created please link it to the goal by its key")
+	}
+	if err := tx.Model(&goal).UpdateColumn("tracker_task_pending", true).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to create the ticket in the task tracker")
+	}
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to create the ticket in the task tracker")
+	}
+
+	task, status, err := service.createGoalTicket(retroID, goal)
+	if err != nil {
+		// No ticket was created, the goal can be retried
+		if clearErr := db.Model(&goal).UpdateColumn("tracker_task_pending", false).Error; clearErr != nil {
+			utils.LogToSentry(clearErr)
+		}
+		return nil, status, err
+	}
+
+	if err = db.Model(&goal).UpdateColumns(map[string]interface{}{
+		"tracker_task_key":     task.Key,
+		"tracker_task_status":  task.Status,
+		"tracker_task_pending": false,
+	}).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, fmt.Errorf("the ticket %s was created but failed to be "+
+			"linked to the goal, please link it by its key", task.Key)
+	}
+
+	return service.getRetrospectiveFeedback(goal.ID)
+}
+
+// createGoalTicket creates the ticket of the goal in the retrospective's task tracker
+func (service RetrospectiveFeedbackService) createGoalTicket(retroID string, goal models.RetrospectiveFeedback) (
+	*taskTrackerSerializers.Task,
+	int,
+	error) {
+	db := service.DB
+
+	if err := db.Model(&models.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		First(&goal.Retrospective).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get goal")
+	}
+
+	connection, err := models.GetTaskTrackerConnectionFromRetro(db, retroID)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid task tracker config")
+	}
+
+	// The first line of the goal, cut down to the usual limit of the trackers, makes the summary of the ticket
+	summary := []rune(strings.TrimSpace(strings.SplitN(strings.TrimSpace(goal.Text), "\n", 2)[0]))
+	if len(summary) > 255 {
+		summary = summary[:255]
+	}
+	task, err := connection.CreateTask(taskTrackerSerializers.Task{
+		Summary:     string(summary),
+		Description: fmt.Sprintf("%s\n\nAction item from the retrospective '%s'.", goal.Text, goal.Retrospective.Title),
+	})
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("failed to create the ticket in the task tracker")
+	}
+	return task, http.StatusOK, nil
+}
+
 // List ...
 func (service RetrospectiveFeedbackService) List(userID uint, sprintID string, retroID string,
 	feedbackType models.RetrospectiveFeedbackType) (
@@ -228,6 +381,7 @@ func (service RetrospectiveFeedbackService) List(userID uint, sprintID string, r
 		utils.LogToSentry(err)
 		return nil, http.StatusInternalServerError, errors.New("failed to get retrospective feedbacks")
 	}
+	hideAnonymousAuthors(feedbackList.Feedbacks)
 
 	return feedbackList, http.StatusOK, nil
 }
@@ -314,7 +468,26 @@ func (service RetrospectiveFeedbackService) getRetrospectiveFeedback(retroFeedba
 		utils.LogToSentry(err)
 		return nil, http.StatusInternalServerError, errors.New("failed to get sprint")
 	}
+	hideAnonymousAuthor(&feedback)
 
 	return &feedback, http.StatusOK, nil
 
 }
+
+// hideAnonymousAuthor hides the author of the feedback if it is anonymous
+func hideAnonymousAuthor(feedback *retrospectiveSerializers.RetrospectiveFeedback) {
+	if feedback.Anonymous {
+		feedback.CreatedByID = 0
+		feedback.CreatedBy = userSerializers.User{}
+	}
+}
+
+// hideAnonymousAuthors hides the authors of the anonymous feedbacks
+func hideAnonymousAuthors(feedbacks []models.RetrospectiveFeedback) {
+	for index := range feedbacks {
+		if feedbacks[index].Anonymous {
+			feedbacks[index].CreatedByID = 0
+			feedbacks[index].CreatedBy = userModels.User{}
+		}
+	}
+}
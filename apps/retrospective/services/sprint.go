@@ -114,6 +114,11 @@ func (service SprintService) DeleteSprint(sprintID string) (int, error) {
 		utils.LogToSentry(err)
 		return http.StatusInternalServerError, errors.New("sprint couldn't be deleted")
 	}
+	if err = retroModels.UpdateGoalCarryOverCounts(tx, sprint.RetrospectiveID); err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("sprint couldn't be deleted")
+	}
 	err = tx.Commit().Error
 	if err != nil {
 		utils.LogToSentry(err)
@@ -143,14 +148,22 @@ func (service SprintService) ActivateSprint(sprintID string, retroID string) (in
 		if rowsAffected := db.Save(&sprint).RowsAffected; rowsAffected == 0 {
 			return http.StatusInternalServerError, errors.New("sprint couldn't be activated")
 		}
+		if err := retroModels.UpdateGoalCarryOverCounts(db, sprint.RetrospectiveID); err != nil {
+			utils.LogToSentry(err)
+		}
+		// The plan is recorded before the sync changes the scope of the sprint
+		if err := (SprintPlanningService{DB: db}).RecordCommitment(sprintID); err != nil {
+			utils.LogToSentry(err)
+		}
 		service.QueueSprint(sprint.ID, true)
 		return http.StatusNoContent, nil
 	}
 	return http.StatusBadRequest, errors.New("cannot activate an invalid draft sprint")
 }
 
-// FreezeSprint freezes the given sprint
-func (service SprintService) FreezeSprint(sprintID string, retroID string) (int, error) {
+// FreezeSprint freezes the given sprint, the next sprint is created by a job as per the sprint cadence of
+// the retrospective
+func (service SprintService) FreezeSprint(sprintID string, retroID string, userID uint) (int, error) {
 	db := service.DB
 	var sprint retroModels.Sprint
 
@@ -169,6 +182,7 @@ func (service SprintService) FreezeSprint(sprintID string, retroID string) (int,
 		if rowsAffected := db.Save(&sprint).RowsAffected; rowsAffected == 0 {
 			return http.StatusInternalServerError, errors.New("sprint couldn't be frozen")
 		}
+		service.QueueNextSprint(sprint.ID, userID)
 		return http.StatusNoContent, nil
 	}
 	return http.StatusBadRequest, errors.New("can not freeze a invalid active sprint")
@@ -253,6 +267,10 @@ func (service SprintService) GetSprintSummary(
 		return nil, http.StatusInternalServerError, errors.New("failed to get sprint summary")
 	}
 
+	// The story points per week are spread over the working days of the team
+	calendar := retroModels.GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID)
+	sprintWorkingDays := calendar.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate)
+
 	err = db.Model(&retroModels.Sprint{}).
 		Where("sprints.deleted_at IS NULL").
 		Scopes(retroModels.SprintJoinSM).
@@ -262,10 +280,13 @@ func (service SprintService) GetSprintSummary(
             SUM(allocation_percent) AS total_allocation,
             SUM(expectation_percent) AS total_expectation,
             SUM((? - vacations) * expectation_percent / 100.0 * allocation_percent / 100.0 * ?) AS target_sp,
+            SUM((? - vacations) * allocation_percent / 100.0 * ?) AS target_hours,
             SUM(vacations) AS total_vacations,
             0 AS holidays`,
-			utils.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate),
-			sprint.Retrospective.StoryPointPerWeek/5).
+			sprintWorkingDays,
+			sprint.Retrospective.StoryPointPerWeek/float64(calendar.GetWorkingDaysPerWeek()),
+			sprintWorkingDays,
+			calendar.HoursPerDay).
 		Scan(&summary).Error
 
 	if err != nil {
@@ -280,6 +301,13 @@ func (service SprintService) GetSprintSummary(
 		return nil, status, errors.New("failed to get sprint")
 	}
 
+	scopeSummary, err := service.getSprintScopeSummary(sprint)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint summary")
+	}
+	summary.ScopeSummary = *scopeSummary
+
 	return &summary, http.StatusOK, nil
 }
 
@@ -354,6 +382,37 @@ func (service SprintService) getSprintTaskTypeSummary(
 	return &summary, http.StatusOK, nil
 }
 
+// GetSprintEpicSummary returns the summary of the tasks of the sprint grouped by their epics
+func (service SprintService) GetSprintEpicSummary(
+	sprintID string) (*retroSerializers.SprintEpicSummarySerializer, int, error) {
+	db := service.DB
+	summary := &retroSerializers.SprintEpicSummarySerializer{Epics: []retroSerializers.SprintEpicSummary{}}
+
+	err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Scopes(retroModels.SprintJoinSM, retroModels.SMJoinSMT, retroModels.SMTJoinST, retroModels.STJoinTask).
+		Where("sprints.id = ?", sprintID).
+		Group("tasks.epic_key").
+		Order("tasks.epic_key").
+		Select(`
+            tasks.epic_key,
+            COUNT(DISTINCT(CASE WHEN sprints.start_date <= tasks.done_at AND sprints.end_date >= tasks.done_at
+                THEN tasks.id END)) AS count,
+            COUNT(DISTINCT(tasks.id)) AS total_count,
+            COALESCE(SUM(CASE WHEN sprints.start_date <= tasks.done_at AND sprints.end_date >= tasks.done_at
+                THEN sprint_member_tasks.points_earned END),0) AS points_earned,
+            COALESCE(SUM(sprint_member_tasks.points_earned),0) AS total_points_earned,
+            COALESCE(SUM(sprint_member_tasks.time_spent_minutes),0) AS actual_hours`).
+		Scan(&summary.Epics).Error
+
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint epic summary")
+	}
+
+	return summary, http.StatusOK, nil
+}
+
 // GetSprintsList ...
 func (service SprintService) GetSprintsList(retrospectiveID string, userID uint, perPage int, after string) (*retroSerializers.SprintsSerializer, int, error) {
 	db := service.DB
@@ -437,7 +496,7 @@ func (service SprintService) Create(
 
 		var providerSprint *taskTrackerSerializers.Sprint
 
-		// ToDo: The form will take task provider specific sprint ids in the future
+		// The sprint IDs are picked from the sprints listed by the task tracker controller
 		providerSprint = connection.GetSprint(sprintData.SprintID)
 		if providerSprint != nil {
 			if sprint.StartDate == nil {
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// TakeActiveSprintSnapshots takes today's snapshot of all the active sprints
+func (service SprintService) TakeActiveSprintSnapshots() error {
+	db := service.DB
+	var sprints []retroModels.Sprint
+
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("status = ?", retroModels.ActiveSprint).
+		Find(&sprints).Error; err != nil {
+		utils.LogToSentry(err)
+		return errors.New("failed to get active sprints")
+	}
+
+	var failed bool
+	for _, sprint := range sprints {
+		if err := service.TakeSprintSnapshot(sprint.ID); err != nil {
+			log.Println("Failed to take snapshot of sprint ", sprint.ID, ": ", err)
+			failed = true
+		}
+	}
+	if failed {
+		return errors.New("failed to take snapshot of some of the sprints")
+	}
+	return nil
+}
+
+// TakeSprintSnapshot creates or updates today's snapshot of an active sprint
+func (service SprintService) TakeSprintSnapshot(sprintID uint) error {
+	db := service.DB
+	var sprint retroModels.Sprint
+	var snapshot retroModels.SprintSnapshot
+	var pointsEarned float64
+
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("id = ?", sprintID).
+		First(&sprint).Error; err != nil {
+		utils.LogToSentry(err)
+		return errors.New("failed to get sprint")
+	}
+
+	if sprint.Status != retroModels.ActiveSprint || sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil
+	}
+
+	location := retroModels.GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID).Location
+	date := utils.GetStartOfDay(time.Now().In(location))
+	startDate := utils.GetStartOfDay(sprint.StartDate.In(location))
+	endDate := utils.GetStartOfDay(sprint.EndDate.In(location))
+	if date.Before(startDate) {
+		return nil
+	}
+	// Active sprints which are not frozen yet keep updating their last snapshot
+	if date.After(endDate) {
+		date = endDate
+	}
+	endOfDay := date.AddDate(0, 0, 1)
+
+	if err := db.Model(&retroModels.SprintSnapshot{}).
+		Where("sprint_snapshots.deleted_at IS NULL").
+		Where("sprint_id = ? AND date = ?", sprint.ID, date.Format(constants.CustomDateFormat)).
+		FirstOrInit(&snapshot).Error; err != nil {
+		utils.LogToSentry(err)
+		return errors.New("failed to get sprint snapshot")
+	}
+
+	if err := db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Scopes(retroModels.STJoinTask).
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Select(`
+            COUNT(DISTINCT tasks.id) AS task_count,
+            COUNT(DISTINCT tasks.id) FILTER (WHERE tasks.done_at < ?) AS done_task_count,
+            COALESCE(SUM(tasks.estimate), 0) AS total_estimate,
+            COALESCE(SUM(tasks.estimate) FILTER (WHERE tasks.done_at < ?), 0) AS done_estimate`,
+			endOfDay, endOfDay).
+		Row().
+		Scan(&snapshot.TaskCount, &snapshot.DoneTaskCount, &snapshot.TotalEstimate, &snapshot.DoneEstimate); err != nil {
+		utils.LogToSentry(err)
+		return errors.New("failed to calculate sprint snapshot")
+	}
+
+	if err := db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM).
+		Where("sprint_members.sprint_id = ?", sprint.ID).
+		Select("COALESCE(SUM(sprint_member_tasks.points_earned), 0)").
+		Row().
+		Scan(&pointsEarned); err != nil {
+		utils.LogToSentry(err)
+		return errors.New("failed to calculate sprint snapshot")
+	}
+
+	snapshot.SprintID = sprint.ID
+	snapshot.Date = date
+	snapshot.PointsEarned = pointsEarned
+	snapshot.RemainingEstimate = snapshot.TotalEstimate - snapshot.DoneEstimate
+
+	if err := db.Set("gorm:save_associations", false).Save(&snapshot).Error; err != nil {
+		utils.LogToSentry(err)
+		return errors.New("failed to save sprint snapshot")
+	}
+	return nil
+}
+
+// GetSprintBurndown returns the burndown/burnup series of a sprint along with its scope changes
+func (service SprintService) GetSprintBurndown(sprintID string) (*retroSerializers.SprintBurndownSerializer, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+	var snapshots []retroModels.SprintSnapshot
+
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("id = ?", sprintID).
+		Scopes(retroModels.NotDeletedSprint).
+		First(&sprint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint burndown")
+	}
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil, http.StatusBadRequest, errors.New("sprint dates are not set")
+	}
+
+	if err := db.Model(&retroModels.SprintSnapshot{}).
+		Where("sprint_snapshots.deleted_at IS NULL").
+		Where("sprint_id = ?", sprint.ID).
+		Order("date").
+		Find(&snapshots).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint burndown")
+	}
+
+	calendar := retroModels.GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID)
+	burndown := &retroSerializers.SprintBurndownSerializer{
+		StartDate:    *sprint.StartDate,
+		EndDate:      *sprint.EndDate,
+		Series:       []retroSerializers.SprintBurndownPoint{},
+		ScopeChanges: []retroSerializers.SprintScopeChangeMarker{},
+	}
+
+	sprintWorkingDays := calendar.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, *sprint.EndDate)
+	for _, snapshot := range snapshots {
+		year, month, day := snapshot.Date.Date()
+		date := time.Date(year, month, day, 0, 0, 0, 0, calendar.Location)
+
+		point := retroSerializers.SprintBurndownPoint{
+			Date:              date,
+			TaskCount:         snapshot.TaskCount,
+			DoneTaskCount:     snapshot.DoneTaskCount,
+			TotalEstimate:     snapshot.TotalEstimate,
+			DoneEstimate:      snapshot.DoneEstimate,
+			RemainingEstimate: snapshot.RemainingEstimate,
+			PointsEarned:      snapshot.PointsEarned,
+		}
+		// The ideal line burns the scope of the first snapshot evenly by the end of each working day
+		if sprintWorkingDays > 0 {
+			elapsedDays := calendar.GetWorkingDaysBetweenTwoDates(*sprint.StartDate, date)
+			point.IdealRemaining = snapshots[0].TotalEstimate *
+				(1 - float64(elapsedDays)/float64(sprintWorkingDays))
+			if point.IdealRemaining < 0 {
+				point.IdealRemaining = 0
+			}
+		}
+		burndown.Series = append(burndown.Series, point)
+	}
+
+	committedAt, err := service.getSprintCommitmentTime(sprint)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint burndown")
+	}
+	scopeChanges, err := service.getSprintScopeChanges(sprint.ID, &committedAt)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint burndown")
+	}
+	burndown.ScopeChanges = append(burndown.ScopeChanges, scopeChanges...)
+
+	return burndown, http.StatusOK, nil
+}
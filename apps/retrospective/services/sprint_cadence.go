@@ -0,0 +1,128 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gocraft/work"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+	"github.com/iReflect/reflect-app/workers"
+)
+
+// QueueNextSprint queues the creation of the sprint following the given frozen sprint
+func (service SprintService) QueueNextSprint(sprintID uint, userID uint) {
+	workers.Enqueuer.EnqueueUnique("create_next_sprint",
+		work.Q{"sprintID": fmt.Sprint(sprintID), "userID": int64(userID)})
+}
+
+// CreateNextSprint creates the draft sprint following the given sprint as per the sprint cadence of the
+// retrospective. Nothing is created when the retrospective has no cadence or already has a draft sprint.
+func (service SprintService) CreateNextSprint(sprintID string, userID uint) (*retroSerializers.Sprint, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("id = ?", sprintID).
+		Preload("Retrospective").
+		First(&sprint).Error; err != nil {
+		return nil, err
+	}
+	retro := sprint.Retrospective
+	if !retro.HasSprintCadence() {
+		return nil, nil
+	}
+
+	baseQuery := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("retrospective_id = ?", retro.ID).
+		Scopes(retroModels.NotDeletedSprint)
+
+	var draftCount int
+	if err := baseQuery.Where("status = ?", retroModels.DraftSprint).Count(&draftCount).Error; err != nil {
+		return nil, err
+	}
+	if draftCount > 0 {
+		return nil, nil
+	}
+
+	// The draft sprint must begin the day after the last completed/active sprint ended
+	var lastSprint retroModels.Sprint
+	if err := baseQuery.
+		Where("status IN (?)", []retroModels.SprintStatus{retroModels.ActiveSprint, retroModels.CompletedSprint}).
+		Where("end_date IS NOT NULL").
+		Order("end_date DESC").
+		First(&lastSprint).Error; err != nil {
+		return nil, err
+	}
+
+	// The sprint is given the same number when it is created
+	sprintNumber, err := retroModels.GetNextSprintNumber(db, retro.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendar := retroModels.GetWorkingCalendarFromRetro(db, retro.ID)
+	startDate, endDate := retro.GetNextSprintDates(*lastSprint.EndDate, calendar)
+	sprintData := retroSerializers.CreateSprintSerializer{
+		Title:       retro.GetSprintTitle(sprintNumber, startDate, endDate),
+		StartDate:   &startDate,
+		EndDate:     &endDate,
+		CreatedByID: userID,
+	}
+	if trackerSprint := service.findNextTrackerSprint(retro, lastSprint, startDate, calendar); trackerSprint != nil {
+		sprintData.SprintID = trackerSprint.ID
+		if trackerSprint.ToDate != nil && trackerSprint.ToDate.After(startDate) {
+			sprintData.EndDate = trackerSprint.ToDate
+		}
+	}
+
+	createdSprint, _, err := service.Create(fmt.Sprint(retro.ID), userID, sprintData)
+	if err != nil {
+		return nil, err
+	}
+	return createdSprint, nil
+}
+
+// findNextTrackerSprint looks up the active or future sprint of the task tracker, on the board of the tracker
+// sprint of the last sprint, which begins on the start date of the next sprint
+func (service SprintService) findNextTrackerSprint(retro retroModels.Retrospective, lastSprint retroModels.Sprint,
+	startDate time.Time, calendar utils.WorkingCalendar) *taskTrackerSerializers.Sprint {
+	if lastSprint.SprintID == "" {
+		return nil
+	}
+
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(retro.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	connection := tasktracker.GetConnection(taskProviderConfig)
+	if connection == nil {
+		return nil
+	}
+
+	lastTrackerSprint := connection.GetSprint(lastSprint.SprintID)
+	if lastTrackerSprint == nil {
+		return nil
+	}
+	trackerSprints, err := connection.ListSprints(lastTrackerSprint.BoardID, tasktracker.DefaultSprintStates)
+	if err == tasktracker.ErrUnknownBoard {
+		// The sprint was created on a board the retrospective is not configured with
+		trackerSprints, err = connection.ListSprints("", tasktracker.DefaultSprintStates)
+	}
+	if err != nil {
+		return nil
+	}
+	for _, trackerSprint := range trackerSprints {
+		if trackerSprint.ID != lastTrackerSprint.ID && trackerSprint.FromDate != nil &&
+			calendar.IsSameDay(*trackerSprint.FromDate, startDate) {
+			return &trackerSprint
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/pubsub"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// SprintEventService broadcasts the changes of the sprint items to everyone following the sprint,
+// the events go through redis so that the followers connected to any of the server instances get them
+type SprintEventService struct {
+	DB *gorm.DB
+}
+
+func getSprintEventChannel(sprintID string) string {
+	return "ireflect:sprints:" + sprintID + ":events"
+}
+
+// Publish an event of the sprint, the failures are only logged as the events are not critical
+func (service SprintEventService) Publish(
+	sprintID string,
+	eventType string,
+	actionItem constants.ActionItemType,
+	actionItemID string,
+	actionByID uint,
+	data interface{}) {
+	intID, err := strconv.Atoi(actionItemID)
+	if err != nil {
+		return
+	}
+
+	// The users acting on the anonymous feedbacks are not broadcast as they are most likely their authors,
+	// the feedbacks are looked up along with the deleted ones to cover the deletions as well
+	if actionItem == constants.RetrospectiveFeedback {
+		var count uint
+		if err = service.DB.Unscoped().Model(&retroModels.RetrospectiveFeedback{}).
+			Where("id = ? AND anonymous", intID).
+			Count(&count).Error; err != nil || count > 0 {
+			actionByID = 0
+		}
+	}
+
+	message, err := json.Marshal(retroSerializers.SprintEvent{
+		Type:         eventType,
+		ActionItem:   actionItem,
+		ActionItemID: uint(intID),
+		ActionByID:   actionByID,
+		Data:         data,
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		utils.LogToSentry(err)
+		return
+	}
+
+	if err = pubsub.Publish(getSprintEventChannel(sprintID), message); err != nil {
+		utils.LogToSentry(err)
+	}
+}
+
+// Subscribe to the events of the sprint, the subscription must be closed once done with it
+func (service SprintEventService) Subscribe(sprintID string) (*pubsub.Subscription, error) {
+	return pubsub.Subscribe(getSprintEventChannel(sprintID))
+}
@@ -13,6 +13,7 @@ import (
 	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
 	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
 	timeTrackerSerializers "github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
 	userSerializers "github.com/iReflect/reflect-app/apps/user/serializers"
 	"github.com/iReflect/reflect-app/libs/utils"
 	"net/http"
@@ -92,7 +93,8 @@ func (service SprintService) AddSprintMember(
 	}
 
 	sprintMemberSummary.ActualStoryPoint = 0
-	sprintMemberSummary.SetExpectedStoryPoint(sprint, sprint.Retrospective)
+	sprintMemberSummary.SetExpectedStoryPoint(sprint, sprint.Retrospective,
+		retroModels.GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID))
 
 	return sprintMemberSummary, http.StatusOK, nil
 }
@@ -128,6 +130,13 @@ func (service SprintService) RemoveSprintMember(sprintID string, memberID string
 		}
 	}
 
+	err = tx.Where("sprint_member_id = ?", sprintMember.ID).Delete(&retroModels.SprintMemberLeave{}).Error
+	if err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to remove sprint member")
+	}
+
 	err = tx.Delete(&sprintMember).Error
 	if err != nil {
 		tx.Rollback()
@@ -180,8 +189,15 @@ func (service SprintService) GetSprintMembersSummary(
 		utils.LogToSentry(err)
 		return nil, http.StatusInternalServerError, errors.New("failed to get member summary")
 	}
+	leaveDays, err := service.getLeaveDaysByType(sprintID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get member summary")
+	}
+	calendar := retroModels.GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID)
 	for _, sprintMemberSummary := range sprintMemberSummaryList.Members {
-		sprintMemberSummary.SetExpectedStoryPoint(sprint, sprint.Retrospective)
+		sprintMemberSummary.SetExpectedStoryPoint(sprint, sprint.Retrospective, calendar)
+		sprintMemberSummary.LeaveDaysByType = leaveDays[sprintMemberSummary.ID]
 	}
 	return sprintMemberSummaryList, http.StatusOK, nil
 }
@@ -212,12 +228,10 @@ func (service SprintService) UpdateSprintMember(sprintID string, sprintMemberID
 	db := service.DB
 
 	var sprintMember retroModels.SprintMember
-	sprintMemberSummary := retroSerializers.SprintMemberSummary{}
 	if err := db.Model(&retroModels.SprintMember{}).
 		Where("sprint_members.deleted_at IS NULL").
 		Where("id = ?", sprintMemberID).
 		Where("sprint_id = ?", sprintID).
-		Preload("Sprint.Retrospective").
 		Find(&sprintMember).
 		Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -233,8 +247,10 @@ func (service SprintService) UpdateSprintMember(sprintID string, sprintMemberID
 	if memberData.ExpectationPercent != nil {
 		sprintMember.ExpectationPercent = *memberData.ExpectationPercent
 	}
-	if memberData.Vacations != nil {
-		sprintMember.Vacations = *memberData.Vacations
+	// The vacations are the sum of the leaves and the undated vacations
+	if memberData.UndatedVacations != nil {
+		sprintMember.Vacations += *memberData.UndatedVacations - sprintMember.UndatedVacations
+		sprintMember.UndatedVacations = *memberData.UndatedVacations
 	}
 	if memberData.Rating != nil {
 		sprintMember.Rating = retrospective.Rating(*memberData.Rating)
@@ -248,9 +264,33 @@ func (service SprintService) UpdateSprintMember(sprintID string, sprintMemberID
 		return nil, http.StatusInternalServerError, errors.New("failed to update sprint member")
 	}
 
+	return service.GetSprintMemberSummary(sprintID, sprintMemberID)
+}
+
+// GetSprintMemberSummary returns the summary of a sprint member
+func (service SprintService) GetSprintMemberSummary(sprintID string,
+	sprintMemberID string) (*retroSerializers.SprintMemberSummary, int, error) {
+	db := service.DB
+
+	var sprint retroModels.Sprint
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("id = ?", sprintID).
+		Preload("Retrospective").
+		Find(&sprint).
+		Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint member")
+	}
+
+	sprintMemberSummary := retroSerializers.SprintMemberSummary{}
 	if err := db.Model(&retroModels.SprintMember{}).
 		Where("sprint_members.deleted_at IS NULL").
 		Where("sprint_members.id = ?", sprintMemberID).
+		Where("sprint_members.sprint_id = ?", sprintID).
 		Scopes(retroModels.SMJoinMember, retroModels.SMLeftJoinSMT).
 		Select(`
             DISTINCT sprint_members.*,
@@ -258,11 +298,21 @@ func (service SprintService) UpdateSprintMember(sprintID string, sprintMemberID
             COALESCE(SUM(sprint_member_tasks.points_earned) OVER (PARTITION BY sprint_members.id), 0) AS actual_story_point,
             COALESCE(SUM(sprint_member_tasks.time_spent_minutes) OVER (PARTITION BY sprint_members.id), 0) AS total_time_spent_in_min`).
 		Scan(&sprintMemberSummary).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint member not found")
+		}
 		utils.LogToSentry(err)
-		return nil, http.StatusInternalServerError, errors.New("failed to update sprint member")
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint member")
 	}
 
-	sprintMemberSummary.SetExpectedStoryPoint(sprintMember.Sprint, sprintMember.Sprint.Retrospective)
+	leaveDays, err := service.getLeaveDaysByType(sprintID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint member")
+	}
+	sprintMemberSummary.LeaveDaysByType = leaveDays[sprintMemberSummary.ID]
+	sprintMemberSummary.SetExpectedStoryPoint(sprint, sprint.Retrospective,
+		retroModels.GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID))
 
 	return &sprintMemberSummary, http.StatusOK, nil
 }
@@ -304,3 +354,93 @@ func (service SprintService) addOrUpdateSMT(timeLog timeTrackerSerializers.TimeL
 
 	return db.Set("smt:disable_validate", true).Save(&sprintMemberTask).Error
 }
+
+// GetMemberPerformanceHistory returns the performance of a member across all the active/frozen sprints of
+// all the retrospectives, optionally restricted to a single retrospective
+func (service SprintService) GetMemberPerformanceHistory(memberID uint,
+	retroID string) (*retroSerializers.MemberPerformanceHistorySerializer, int, error) {
+	db := service.DB
+	history := new(retroSerializers.MemberPerformanceHistorySerializer)
+
+	if err := db.Model(&userModels.User{}).
+		Where("users.deleted_at IS NULL").
+		Where("users.id = ?", memberID).
+		Scan(&history.Member).
+		Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("member not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get member performance history")
+	}
+
+	query := db.Model(&retroModels.SprintMember{}).
+		Where("sprint_members.deleted_at IS NULL").
+		Where("sprint_members.member_id = ?", memberID).
+		Scopes(retroModels.SMJoinSprint, retroModels.SprintJoinRetro, retroModels.SMLeftJoinSMT).
+		Where("sprints.status IN (?)", []retroModels.SprintStatus{retroModels.ActiveSprint, retroModels.CompletedSprint}).
+		Scopes(retroModels.NotDeletedSprint)
+
+	if retroID != "" {
+		query = query.Where("retrospectives.id = ?", retroID)
+	}
+
+	if err := query.
+		Select(`
+            sprint_members.id AS sprint_member_id,
+            sprints.id AS sprint_id,
+            sprints.title AS sprint_title,
+            sprints.status AS sprint_status,
+            sprints.start_date,
+            sprints.end_date,
+            retrospectives.id AS retrospective_id,
+            retrospectives.title AS retrospective_title,
+            retrospectives.story_point_per_week,
+            sprint_members.allocation_percent,
+            sprint_members.expectation_percent,
+            sprint_members.vacations,
+            sprint_members.rating,
+            sprint_members.comment,
+            COALESCE(SUM(sprint_member_tasks.points_earned), 0) AS actual_story_point,
+            COALESCE(SUM(sprint_member_tasks.time_spent_minutes), 0) AS total_time_spent_in_min,
+            COUNT(sprint_member_tasks.id) FILTER (WHERE sprint_member_tasks.role = ?) AS developer_task_count,
+            COALESCE(SUM(sprint_member_tasks.points_earned) FILTER (WHERE sprint_member_tasks.role = ?), 0) AS developer_story_point,
+            COALESCE(SUM(sprint_member_tasks.time_spent_minutes) FILTER (WHERE sprint_member_tasks.role = ?), 0) AS developer_time_in_min,
+            COUNT(sprint_member_tasks.id) FILTER (WHERE sprint_member_tasks.role = ?) AS reviewer_task_count,
+            COALESCE(SUM(sprint_member_tasks.points_earned) FILTER (WHERE sprint_member_tasks.role = ?), 0) AS reviewer_story_point,
+            COALESCE(SUM(sprint_member_tasks.time_spent_minutes) FILTER (WHERE sprint_member_tasks.role = ?), 0) AS reviewer_time_in_min,
+            COALESCE(AVG(sprint_member_tasks.rating), 0) AS average_task_rating`,
+			retroModels.Developer, retroModels.Developer, retroModels.Developer,
+			retroModels.Reviewer, retroModels.Reviewer, retroModels.Reviewer).
+		Group("sprint_members.id, sprints.id, retrospectives.id").
+		Order("sprints.start_date DESC, sprints.id DESC").
+		Scan(&history.Sprints).
+		Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get member performance history")
+	}
+
+	var ratingSum float64
+	calendars := make(map[uint]utils.WorkingCalendar)
+	for _, sprintPerformance := range history.Sprints {
+		calendar, ok := calendars[sprintPerformance.RetrospectiveID]
+		if !ok {
+			calendar = retroModels.GetWorkingCalendarFromRetro(db, sprintPerformance.RetrospectiveID)
+			calendars[sprintPerformance.RetrospectiveID] = calendar
+		}
+		sprintPerformance.SetExpectedStoryPoint(calendar)
+
+		history.Total.ExpectedStoryPoint += sprintPerformance.ExpectedStoryPoint
+		history.Total.ActualStoryPoint += sprintPerformance.ActualStoryPoint
+		history.Total.TotalTimeSpentInMin += sprintPerformance.TotalTimeSpentInMin
+		history.Total.DeveloperStoryPoint += sprintPerformance.DeveloperStoryPoint
+		history.Total.ReviewerStoryPoint += sprintPerformance.ReviewerStoryPoint
+		ratingSum += float64(sprintPerformance.Rating)
+	}
+	history.Total.SprintCount = len(history.Sprints)
+	if history.Total.SprintCount > 0 {
+		history.Total.AverageRating = ratingSum / float64(history.Total.SprintCount)
+	}
+
+	return history, http.StatusOK, nil
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/constants"
+	customErrors "github.com/iReflect/reflect-app/libs"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// GetSprintMemberLeaves returns the leaves of a sprint member along with the days of leave by the type of leave
+func (service SprintService) GetSprintMemberLeaves(sprintID string,
+	sprintMemberID string) (*retroSerializers.SprintMemberLeavesSerializer, int, error) {
+	db := service.DB
+	sprintMember, status, err := service.getSprintMember(sprintID, sprintMemberID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var leaves []retroModels.SprintMemberLeave
+	if err := db.Model(&retroModels.SprintMemberLeave{}).
+		Where("sprint_member_leaves.deleted_at IS NULL").
+		Where("sprint_member_id = ?", sprintMember.ID).
+		Order("date").
+		Find(&leaves).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint member leaves")
+	}
+
+	leavesSerializer := &retroSerializers.SprintMemberLeavesSerializer{
+		Leaves:     []retroSerializers.SprintMemberLeave{},
+		DaysByType: make(map[string]float64),
+	}
+	for _, leave := range leaves {
+		leavesSerializer.Leaves = append(leavesSerializer.Leaves, serializeSprintMemberLeave(leave))
+		leavesSerializer.Days += leave.GetDays()
+		leavesSerializer.DaysByType[leave.Type.GetStringValue()] += leave.GetDays()
+	}
+	return leavesSerializer, http.StatusOK, nil
+}
+
+// AddSprintMemberLeave adds a leave for a sprint member
+func (service SprintService) AddSprintMemberLeave(sprintID string, sprintMemberID string,
+	leaveData retroSerializers.SprintMemberLeaveCreate) (*retroSerializers.SprintMemberLeave, int, error) {
+	db := service.DB
+	sprintMember, status, err := service.getSprintMember(sprintID, sprintMemberID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	date, err := time.Parse(constants.CustomDateFormat, leaveData.Date)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid date, the date should be in the YYYY-MM-DD format")
+	}
+
+	leave := retroModels.SprintMemberLeave{
+		SprintMemberID: sprintMember.ID,
+		Date:           date,
+		Type:           retroModels.LeaveType(leaveData.Type),
+		HalfDay:        leaveData.HalfDay,
+		Note:           leaveData.Note,
+	}
+	if err := db.Create(&leave).Error; err != nil {
+		if customErrors.IsModelError(err) {
+			return nil, http.StatusBadRequest, err
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add sprint member leave")
+	}
+
+	serializedLeave := serializeSprintMemberLeave(leave)
+	return &serializedLeave, http.StatusCreated, nil
+}
+
+// DeleteSprintMemberLeave deletes a leave of a sprint member
+func (service SprintService) DeleteSprintMemberLeave(sprintID string, sprintMemberID string,
+	leaveID string) (int, error) {
+	db := service.DB
+	sprintMember, status, err := service.getSprintMember(sprintID, sprintMemberID)
+	if err != nil {
+		return status, err
+	}
+
+	var leave retroModels.SprintMemberLeave
+	if err := db.Model(&retroModels.SprintMemberLeave{}).
+		Where("sprint_member_leaves.deleted_at IS NULL").
+		Where("sprint_member_id = ?", sprintMember.ID).
+		Where("id = ?", leaveID).
+		Find(&leave).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return http.StatusNotFound, errors.New("sprint member leave not found")
+		}
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to delete sprint member leave")
+	}
+
+	if err := db.Delete(&leave).Error; err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to delete sprint member leave")
+	}
+	return http.StatusNoContent, nil
+}
+
+// ImportSprintMemberLeaves imports the leaves of a sprint member from an ICS calendar or a CSV file, the days
+// already on leave and the days outside the sprint are skipped
+func (service SprintService) ImportSprintMemberLeaves(sprintID string, sprintMemberID string,
+	importData retroSerializers.SprintMemberLeaveImport) (*retroSerializers.SprintMemberLeaveImportResult, int, error) {
+	db := service.DB
+	sprintMember, status, err := service.getSprintMember(sprintID, sprintMemberID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var entries []leaveEntry
+	var skipped []string
+	if importData.Format == "ics" {
+		calendar := retroModels.GetWorkingCalendarFromRetro(db, sprintMember.Sprint.RetrospectiveID)
+		entries, skipped, err = parseLeaveICS(importData.Data, calendar)
+	} else {
+		entries, skipped, err = parseLeaveCSV(importData.Data)
+	}
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	result := &retroSerializers.SprintMemberLeaveImportResult{
+		Leaves:  []retroSerializers.SprintMemberLeave{},
+		Skipped: skipped,
+	}
+	tx := db.Begin()
+	for _, entry := range entries {
+		leave := retroModels.SprintMemberLeave{
+			SprintMemberID: sprintMember.ID,
+			Date:           entry.Date,
+			Type:           entry.Type,
+			HalfDay:        entry.HalfDay,
+			Note:           entry.Note,
+		}
+		if err := tx.Create(&leave).Error; err != nil {
+			if customErrors.IsModelError(err) {
+				result.Skipped = append(result.Skipped,
+					fmt.Sprintf("%s: %s", entry.Date.Format(constants.CustomDateFormat), err.Error()))
+				continue
+			}
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to import sprint member leaves")
+		}
+		result.Leaves = append(result.Leaves, serializeSprintMemberLeave(leave))
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to import sprint member leaves")
+	}
+	return result, http.StatusOK, nil
+}
+
+// getSprintMember returns the sprint member of the sprint along with the sprint
+func (service SprintService) getSprintMember(sprintID string,
+	sprintMemberID string) (*retroModels.SprintMember, int, error) {
+	db := service.DB
+	var sprintMember retroModels.SprintMember
+	if err := db.Model(&retroModels.SprintMember{}).
+		Where("sprint_members.deleted_at IS NULL").
+		Where("id = ?", sprintMemberID).
+		Where("sprint_id = ?", sprintID).
+		Preload("Sprint").
+		Find(&sprintMember).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint member not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint member")
+	}
+	return &sprintMember, http.StatusOK, nil
+}
+
+// getLeaveDaysByType returns the days of leave by the type of leave of each member of the sprint
+func (service SprintService) getLeaveDaysByType(sprintID string) (map[uint]map[string]float64, error) {
+	db := service.DB
+	rows, err := db.Model(&retroModels.SprintMember{}).
+		Where("sprint_members.deleted_at IS NULL").
+		Where("sprint_members.sprint_id = ?", sprintID).
+		Scopes(retroModels.SMJoinLeave).
+		Group("sprint_members.id, sprint_member_leaves.type").
+		Select(`sprint_members.id, sprint_member_leaves.type,
+			SUM(CASE WHEN sprint_member_leaves.half_day THEN 0.5 ELSE 1 END)`).
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	leaveDays := make(map[uint]map[string]float64)
+	for rows.Next() {
+		var sprintMemberID uint
+		var leaveType retroModels.LeaveType
+		var days float64
+		if err := rows.Scan(&sprintMemberID, &leaveType, &days); err != nil {
+			return nil, err
+		}
+		if leaveDays[sprintMemberID] == nil {
+			leaveDays[sprintMemberID] = make(map[string]float64)
+		}
+		leaveDays[sprintMemberID][leaveType.GetStringValue()] = days
+	}
+	return leaveDays, nil
+}
+
+func serializeSprintMemberLeave(leave retroModels.SprintMemberLeave) retroSerializers.SprintMemberLeave {
+	return retroSerializers.SprintMemberLeave{
+		ID:             leave.ID,
+		SprintMemberID: leave.SprintMemberID,
+		Date:           leave.Date,
+		Type:           int8(leave.Type),
+		HalfDay:        leave.HalfDay,
+		Note:           leave.Note,
+	}
+}
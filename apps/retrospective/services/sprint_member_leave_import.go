@@ -0,0 +1,220 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// leaveEntry is a day of leave read from an imported calendar or file
+type leaveEntry struct {
+	Date    time.Time
+	Type    retroModels.LeaveType
+	HalfDay bool
+	Note    string
+}
+
+// parseLeaveType returns the leave type named in the text, i.e. "Sick Leave" or "sick",
+// a vacation is the fallback
+func parseLeaveType(text string) retroModels.LeaveType {
+	text = strings.ToLower(strings.TrimSpace(text))
+	for index, value := range retroModels.LeaveTypeValues {
+		if text == strings.ToLower(value) {
+			return retroModels.LeaveType(index)
+		}
+	}
+	switch {
+	case strings.Contains(text, "sick"):
+		return retroModels.SickLeave
+	case strings.Contains(text, "training"):
+		return retroModels.TrainingLeave
+	case strings.Contains(text, "on-call"), strings.Contains(text, "on call"), strings.Contains(text, "oncall"):
+		return retroModels.OnCallCompensationLeave
+	}
+	return retroModels.VacationLeave
+}
+
+// parseLeaveCSV reads the leaves of a CSV file with a header row, the Type, HalfDay and Note columns are optional
+func parseLeaveCSV(data string) ([]leaveEntry, []string, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV file: %v", err)
+	}
+	columns := make(map[string]int)
+	for index, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = index
+	}
+	if _, ok := columns["date"]; !ok {
+		return nil, nil, fmt.Errorf("the CSV file has no Date column")
+	}
+	value := func(record []string, column string) string {
+		if index, ok := columns[column]; ok && index < len(record) {
+			return strings.TrimSpace(record[index])
+		}
+		return ""
+	}
+
+	var entries []leaveEntry
+	var skipped []string
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid CSV file: %v", err)
+		}
+
+		date, err := time.Parse(constants.CustomDateFormat, value(record, "date"))
+		if err != nil {
+			skipped = append(skipped, fmt.Sprintf("line %d: invalid date %q", line, value(record, "date")))
+			continue
+		}
+		halfDay := strings.ToLower(value(record, "halfday"))
+		entries = append(entries, leaveEntry{
+			Date:    date,
+			Type:    parseLeaveType(value(record, "type")),
+			HalfDay: halfDay == "true" || halfDay == "yes" || halfDay == "1",
+			Note:    value(record, "note"),
+		})
+	}
+	return entries, skipped, nil
+}
+
+// parseLeaveICS reads the leaves of the events of an ICS calendar. An all day event is a leave on each working
+// day it spans, a timed event within a day is a half day leave when it is no longer than half a working day.
+// The type of the leave is read from the categories, or else the summary, of the event.
+func parseLeaveICS(data string, calendar utils.WorkingCalendar) ([]leaveEntry, []string, error) {
+	// Long lines are folded, i.e. continued on the next line starting with a white space
+	data = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(data)
+
+	var entries []leaveEntry
+	var skipped []string
+	var event map[string]string
+	var eventParams map[string]string
+	eventCount := 0
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		separator := strings.Index(line, ":")
+		if separator < 0 {
+			continue
+		}
+		name, value := line[:separator], line[separator+1:]
+		params := ""
+		if index := strings.Index(name, ";"); index >= 0 {
+			name, params = name[:index], name[index+1:]
+		}
+		name = strings.ToUpper(name)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = make(map[string]string)
+			eventParams = make(map[string]string)
+		case name == "END" && value == "VEVENT" && event != nil:
+			eventCount++
+			eventEntries, err := getICSEventLeaves(event, eventParams, calendar)
+			if err != nil {
+				skipped = append(skipped, fmt.Sprintf("event %d (%s): %v", eventCount, event["SUMMARY"], err))
+			}
+			entries = append(entries, eventEntries...)
+			event = nil
+		case event != nil:
+			event[name] = value
+			eventParams[name] = params
+		}
+	}
+	if eventCount == 0 {
+		return nil, nil, fmt.Errorf("the calendar has no events")
+	}
+	return entries, skipped, nil
+}
+
+// getICSEventLeaves returns the leaves of an event of an ICS calendar
+func getICSEventLeaves(event map[string]string, params map[string]string,
+	calendar utils.WorkingCalendar) ([]leaveEntry, error) {
+	start, allDay, err := parseICSTime(event["DTSTART"], params["DTSTART"], calendar)
+	if err != nil {
+		return nil, err
+	}
+	end := start
+	if event["DTEND"] != "" {
+		if end, _, err = parseICSTime(event["DTEND"], params["DTEND"], calendar); err != nil {
+			return nil, err
+		}
+	} else if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+
+	leaveType := parseLeaveType(event["SUMMARY"])
+	if event["CATEGORIES"] != "" {
+		leaveType = parseLeaveType(event["CATEGORIES"])
+	}
+	note := strings.NewReplacer("\\,", ",", "\\;", ";", "\\n", " ", "\\N", " ").Replace(event["SUMMARY"])
+
+	// A timed event within a day
+	if !allDay && calendar.IsSameDay(start, end) {
+		return []leaveEntry{{
+			Date:    utils.GetStartOfDay(start),
+			Type:    leaveType,
+			HalfDay: end.Sub(start).Hours() <= calendar.HoursPerDay/2,
+			Note:    note,
+		}}, nil
+	}
+
+	// The end of an all day event is exclusive
+	lastDay := utils.GetStartOfDay(end)
+	if allDay || end.Equal(lastDay) {
+		lastDay = lastDay.AddDate(0, 0, -1)
+	}
+	var entries []leaveEntry
+	for day := utils.GetStartOfDay(start); !day.After(lastDay); day = day.AddDate(0, 0, 1) {
+		if calendar.IsWorkingDay(day) {
+			entries = append(entries, leaveEntry{Date: day, Type: leaveType, Note: note})
+		}
+	}
+	return entries, nil
+}
+
+// parseICSTime parses a date or a date-time of an ICS calendar in the time zone of the team,
+// the floating times are taken to be in the time zone of the team as well
+func parseICSTime(value string, params string, calendar utils.WorkingCalendar) (time.Time, bool, error) {
+	value = strings.TrimSpace(value)
+	location := calendar.Location
+	for _, param := range strings.Split(params, ";") {
+		if strings.HasPrefix(strings.ToUpper(param), "TZID=") {
+			if tzLocation, err := time.LoadLocation(strings.Trim(param[len("TZID="):], "\"")); err == nil {
+				location = tzLocation
+			}
+		}
+	}
+
+	if len(value) == len("20060102") {
+		parsed, err := time.ParseInLocation("20060102", value, calendar.Location)
+		if err != nil {
+			return parsed, true, fmt.Errorf("invalid date %q", value)
+		}
+		return parsed, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		parsed, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return parsed, false, fmt.Errorf("invalid date %q", value)
+		}
+		return calendar.In(parsed), false, nil
+	}
+	parsed, err := time.ParseInLocation("20060102T150405", value, location)
+	if err != nil {
+		return parsed, false, fmt.Errorf("invalid date %q", value)
+	}
+	return calendar.In(parsed), false, nil
+}
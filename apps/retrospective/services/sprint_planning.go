@@ -0,0 +1,364 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// SprintPlanningService plans the scope of draft sprints against the capacity of their members
+type SprintPlanningService struct {
+	DB *gorm.DB
+}
+
+// GetPlan returns the plan of a draft sprint
+func (service SprintPlanningService) GetPlan(sprintID string) (*retroSerializers.SprintPlan, int, error) {
+	sprint, status, err := service.getDraftSprint(sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	plan, err := service.buildPlan(*sprint)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint plan")
+	}
+	return plan, http.StatusOK, nil
+}
+
+// GetBacklog returns the tickets of the task tracker backlog which are not a part of the sprint yet
+func (service SprintPlanningService) GetBacklog(sprintID string) (*retroSerializers.SprintBacklogSerializer, int, error) {
+	db := service.DB
+	sprint, status, err := service.getDraftSprint(sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(sprint.Retrospective.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get backlog")
+	}
+
+	tickets, err := tasktracker.GetBacklogTaskList(taskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get backlog")
+	}
+
+	var plannedIDs []string
+	if err = db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Scopes(retroModels.STJoinTask).
+		Pluck("tasks.tracker_unique_id", &plannedIDs).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get backlog")
+	}
+	planned := make(map[string]bool)
+	for _, trackerUniqueID := range plannedIDs {
+		planned[trackerUniqueID] = true
+	}
+
+	backlog := new(retroSerializers.SprintBacklogSerializer)
+	backlog.Tasks = []taskTrackerSerializers.Task{}
+	for _, ticket := range tickets {
+		if !planned[ticket.TrackerUniqueID] {
+			backlog.Tasks = append(backlog.Tasks, ticket)
+		}
+	}
+	return backlog, http.StatusOK, nil
+}
+
+// AddTask pulls a ticket of the task tracker into the plan of a draft sprint
+func (service SprintPlanningService) AddTask(sprintID string,
+	data retroSerializers.SprintPlanTaskCreate) (*retroSerializers.SprintPlanTask, int, error) {
+	db := service.DB
+	sprint, status, err := service.getDraftSprint(sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(sprint.Retrospective.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add task to the sprint plan")
+	}
+
+	ticket, err := tasktracker.GetTaskDetails(taskProviderConfig, data.Key)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get task from the task tracker")
+	}
+	if ticket == nil {
+		return nil, http.StatusNotFound, errors.New("task not found in the task tracker")
+	}
+
+	if err = (SprintService{DB: db}).addOrUpdateTaskTrackerTask(*sprint, *ticket, sprint.RetrospectiveID,
+		""); err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add task to the sprint plan")
+	}
+
+	var sprintTask retroModels.SprintTask
+	if err = db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Scopes(retroModels.STJoinTask).
+		Where("tasks.retrospective_id = ?", sprint.RetrospectiveID).
+		Where("tasks.tracker_unique_id = ?", ticket.TrackerUniqueID).
+		Select("sprint_tasks.*").
+		First(&sprintTask).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to add task to the sprint plan")
+	}
+
+	if data.PlannedEstimate != nil {
+		if err = db.Model(&sprintTask).
+			UpdateColumn("planned_estimate", data.PlannedEstimate).Error; err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to add task to the sprint plan")
+		}
+	}
+
+	return service.getPlanTask(sprint.ID, sprintTask.ID)
+}
+
+// UpdateTask updates the planned estimate of a task of a draft sprint
+func (service SprintPlanningService) UpdateTask(sprintID string, sprintTaskID string,
+	data retroSerializers.SprintPlanTaskUpdate) (*retroSerializers.SprintPlanTask, int, error) {
+	db := service.DB
+	sprint, status, err := service.getDraftSprint(sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+
+	var sprintTask retroModels.SprintTask
+	if err = db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Where("sprint_tasks.id = ?", sprintTaskID).
+		First(&sprintTask).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint task not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update planned task")
+	}
+
+	if err = db.Model(&sprintTask).
+		UpdateColumn("planned_estimate", data.PlannedEstimate).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update planned task")
+	}
+
+	return service.getPlanTask(sprint.ID, sprintTask.ID)
+}
+
+// RecordCommitment records the plan of a sprint as the scope the sprint is committed to,
+// it is meant to be called on activating the sprint
+func (service SprintPlanningService) RecordCommitment(sprintID string) error {
+	db := service.DB
+	var sprint retroModels.Sprint
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("sprints.id = ?", sprintID).
+		Preload("Retrospective").
+		First(&sprint).Error; err != nil {
+		return err
+	}
+
+	plan, err := service.buildPlan(sprint)
+	if err != nil {
+		return err
+	}
+	planJSON, err := json.Marshal(plan)
+	if err != nil {
+		return err
+	}
+
+	return db.Where(retroModels.SprintCommitment{SprintID: sprint.ID}).
+		Where("sprint_commitments.deleted_at IS NULL").
+		Assign(retroModels.SprintCommitment{
+			Capacity:          plan.Capacity,
+			CommittedEstimate: plan.CommittedEstimate,
+			PlannedPoints:     plan.PlannedPoints,
+			TaskCount:         uint(len(plan.Tasks)),
+			Plan:              planJSON,
+		}).
+		FirstOrCreate(&retroModels.SprintCommitment{}).Error
+}
+
+// GetCommitment returns the scope the sprint was committed to on its activation
+func (service SprintPlanningService) GetCommitment(sprintID string) (*retroSerializers.SprintCommitment, int, error) {
+	db := service.DB
+	var commitment retroModels.SprintCommitment
+
+	if err := db.Model(&retroModels.SprintCommitment{}).
+		Where("sprint_commitments.deleted_at IS NULL").
+		Where("sprint_commitments.sprint_id = ?", sprintID).
+		First(&commitment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint commitment not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint commitment")
+	}
+
+	serializedCommitment := retroSerializers.SprintCommitment{
+		ID:                commitment.ID,
+		SprintID:          commitment.SprintID,
+		CreatedAt:         commitment.CreatedAt,
+		Capacity:          commitment.Capacity,
+		CommittedEstimate: commitment.CommittedEstimate,
+		PlannedPoints:     commitment.PlannedPoints,
+		TaskCount:         commitment.TaskCount,
+	}
+	if err := json.Unmarshal(commitment.Plan, &serializedCommitment.Plan); err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint commitment")
+	}
+	return &serializedCommitment, http.StatusOK, nil
+}
+
+// getDraftSprint returns the sprint along with its retrospective, only the draft sprints can be planned
+func (service SprintPlanningService) getDraftSprint(sprintID string) (*retroModels.Sprint, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("sprints.id = ?", sprintID).
+		Preload("Retrospective").
+		First(&sprint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint")
+	}
+
+	if sprint.Status != retroModels.DraftSprint {
+		return nil, http.StatusBadRequest, errors.New("only a draft sprint can be planned")
+	}
+	return &sprint, http.StatusOK, nil
+}
+
+// buildPlan computes the capacity of the sprint members against the points planned for them,
+// and the estimate the sprint is committed to
+func (service SprintPlanningService) buildPlan(sprint retroModels.Sprint) (*retroSerializers.SprintPlan, error) {
+	db := service.DB
+	plan := new(retroSerializers.SprintPlan)
+	plan.Members = []*retroSerializers.SprintPlanMember{}
+	plan.Tasks = []*retroSerializers.SprintPlanTask{}
+	plan.Warnings = []string{}
+
+	if err := db.Model(&retroModels.SprintMember{}).
+		Where("sprint_members.deleted_at IS NULL").
+		Where("sprint_members.sprint_id = ?", sprint.ID).
+		Scopes(retroModels.SMJoinMember, retroModels.SMLeftJoinSMT).
+		Select(`
+			sprint_members.id,
+			sprint_members.member_id,
+			users.first_name,
+			users.last_name,
+			sprint_members.allocation_percent,
+			sprint_members.expectation_percent,
+			sprint_members.vacations,
+			COALESCE(SUM(sprint_member_tasks.planned_points), 0) AS planned_points,
+			COUNT(sprint_member_tasks.id) AS task_count
+		`).
+		Group("sprint_members.id, users.id").
+		Order("users.first_name, users.last_name").
+		Scan(&plan.Members).Error; err != nil {
+		return nil, err
+	}
+
+	if err := service.planTasksQuery(sprint.ID).
+		Order("tasks.tracker_unique_id").
+		Scan(&plan.Tasks).Error; err != nil {
+		return nil, err
+	}
+
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		plan.Warnings = append(plan.Warnings, "the sprint has no start/end date, the capacity can not be computed")
+	}
+	calendar := retroModels.GetWorkingCalendarFromRetro(service.DB, sprint.RetrospectiveID)
+	for _, member := range plan.Members {
+		if sprint.StartDate != nil && sprint.EndDate != nil {
+			member.Capacity = calendar.CalculateExpectedSP(*sprint.StartDate, *sprint.EndDate, member.Vacations,
+				member.ExpectationPercent, member.AllocationPercent, sprint.Retrospective.StoryPointPerWeek)
+		}
+		plan.Capacity += member.Capacity
+		plan.PlannedPoints += member.PlannedPoints
+
+		// A margin of 0.05 points is allowed, as done for the points earned on a task
+		if member.PlannedPoints > member.Capacity+0.05 {
+			member.OverCommitted = true
+			plan.Warnings = append(plan.Warnings, fmt.Sprintf(
+				"%s %s is planned for %.2f points against a capacity of %.2f points",
+				member.FirstName, member.LastName, member.PlannedPoints, member.Capacity))
+		}
+	}
+
+	for _, task := range plan.Tasks {
+		if task.PlannedEstimate != nil {
+			plan.CommittedEstimate += *task.PlannedEstimate
+		} else {
+			plan.CommittedEstimate += task.Estimate
+		}
+	}
+
+	if plan.CommittedEstimate > plan.Capacity+0.05 {
+		plan.OverCommitted = true
+		plan.Warnings = append(plan.Warnings, fmt.Sprintf(
+			"the sprint is committed to %.2f points against a capacity of %.2f points",
+			plan.CommittedEstimate, plan.Capacity))
+	}
+	return plan, nil
+}
+
+// getPlanTask ...
+func (service SprintPlanningService) getPlanTask(sprintID uint,
+	sprintTaskID uint) (*retroSerializers.SprintPlanTask, int, error) {
+	task := new(retroSerializers.SprintPlanTask)
+
+	if err := service.planTasksQuery(sprintID).
+		Where("sprint_tasks.id = ?", sprintTaskID).
+		Scan(task).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get planned task")
+	}
+	return task, http.StatusOK, nil
+}
+
+// planTasksQuery ...
+func (service SprintPlanningService) planTasksQuery(sprintID uint) *gorm.DB {
+	return service.DB.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprintID).
+		Scopes(retroModels.STJoinTask, retroModels.STLeftJoinSMT).
+		Select(`
+			sprint_tasks.id,
+			sprint_tasks.task_id,
+			tasks.key,
+			tasks.summary,
+			tasks.type,
+			tasks.status,
+			tasks.priority,
+			tasks.is_tracker_task,
+			tasks.estimate,
+			sprint_tasks.planned_estimate,
+			COALESCE(SUM(sprint_member_tasks.planned_points), 0) AS planned_points
+		`).
+		Group("sprint_tasks.id, tasks.id")
+}
@@ -0,0 +1,448 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/apps/retrospective"
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/libs/pdf"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// SprintReportFormat ...
+type SprintReportFormat string
+
+// Supported sprint report formats
+const (
+	CSVReport      SprintReportFormat = "csv"
+	MarkdownReport SprintReportFormat = "md"
+	PDFReport      SprintReportFormat = "pdf"
+)
+
+// SprintReportContentTypes maps the report formats to their content type
+var SprintReportContentTypes = map[SprintReportFormat]string{
+	CSVReport:      "text/csv; charset=utf-8",
+	MarkdownReport: "text/markdown; charset=utf-8",
+	PDFReport:      "application/pdf",
+}
+
+const reportDateFormat = "02 Jan 2006"
+
+// SprintReportService ...
+type SprintReportService struct {
+	DB                           *gorm.DB
+	SprintService                SprintService
+	SprintTaskService            SprintTaskService
+	RetrospectiveFeedbackService RetrospectiveFeedbackService
+}
+
+// GetReport collects the sprint summary, member summary, tasks, goals, highlights and notes of a sprint
+func (service SprintReportService) GetReport(retroID string, sprintID string,
+	userID uint) (*retroSerializers.SprintReport, int, error) {
+	db := service.DB
+	report := new(retroSerializers.SprintReport)
+	var retro retroModels.Retrospective
+	var status int
+	var err error
+
+	if err = db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		First(&retro).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint report")
+	}
+	report.RetrospectiveTitle = retro.Title
+
+	if report.Sprint, status, err = service.SprintService.Get(sprintID, userID, true); err != nil {
+		return nil, status, err
+	}
+
+	memberSummary, status, err := service.SprintService.GetSprintMembersSummary(sprintID)
+	if err != nil {
+		return nil, status, err
+	}
+	report.Members = memberSummary.Members
+
+	taskList, status, err := service.SprintTaskService.List(retroID, sprintID, userID)
+	if err != nil {
+		return nil, status, err
+	}
+	report.Tasks = taskList.Tasks
+
+	if err = db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Scopes(retroModels.SMTJoinSM, retroModels.SMJoinMember, retroModels.SMTJoinST).
+		Where("sprint_members.sprint_id = ?", sprintID).
+		Select(`
+            sprint_member_tasks.sprint_task_id,
+            users.first_name,
+            users.last_name,
+            sprint_member_tasks.role,
+            sprint_member_tasks.time_spent_minutes,
+            sprint_member_tasks.points_earned,
+            sprint_member_tasks.points_assigned,
+            sprint_member_tasks.rating`).
+		Order("sprint_member_tasks.role, users.first_name, users.last_name").
+		Scan(&report.MemberTasks).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint report")
+	}
+
+	goalLists := map[string]*[]retroModels.RetrospectiveFeedback{
+		"added":     &report.AddedGoals,
+		"completed": &report.CompletedGoals,
+		"pending":   &report.PendingGoals,
+	}
+	for goalType, goals := range goalLists {
+		feedbackList, status, err := service.RetrospectiveFeedbackService.ListGoal(userID, sprintID, retroID, goalType)
+		if err != nil {
+			return nil, status, err
+		}
+		*goals = feedbackList.Feedbacks
+	}
+
+	feedbackLists := map[retroModels.RetrospectiveFeedbackType]*[]retroModels.RetrospectiveFeedback{
+		retroModels.HighlightType: &report.Highlights,
+		retroModels.NoteType:      &report.Notes,
+	}
+	for feedbackType, feedbacks := range feedbackLists {
+		feedbackList, status, err := service.RetrospectiveFeedbackService.List(userID, sprintID, retroID, feedbackType)
+		if err != nil {
+			return nil, status, err
+		}
+		*feedbacks = feedbackList.Feedbacks
+	}
+
+	return report, http.StatusOK, nil
+}
+
+// Export renders the sprint report in the given format
+func (service SprintReportService) Export(retroID string, sprintID string, userID uint,
+	format SprintReportFormat) (content []byte, fileName string, status int, err error) {
+	if _, ok := SprintReportContentTypes[format]; !ok {
+		return nil, "", http.StatusBadRequest, errors.New("invalid report format")
+	}
+
+	report, status, err := service.GetReport(retroID, sprintID, userID)
+	if err != nil {
+		return nil, "", status, err
+	}
+
+	switch format {
+	case CSVReport:
+		content, err = renderSprintReportCSV(report)
+	case MarkdownReport:
+		content = renderSprintReportMarkdown(report)
+	case PDFReport:
+		content = renderSprintReportPDF(report)
+	}
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, "", http.StatusInternalServerError, errors.New("failed to export sprint report")
+	}
+
+	fileName = fmt.Sprintf("%s-%s.%s", slugify(report.RetrospectiveTitle), slugify(report.Sprint.Title), format)
+	return content, fileName, http.StatusOK, nil
+}
+
+// renderSprintReportCSV renders a row per member task, tasks without any member task get a single row
+func renderSprintReportCSV(report *retroSerializers.SprintReport) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	memberTasks := make(map[uint][]retroSerializers.SprintReportMemberTask)
+	for _, memberTask := range report.MemberTasks {
+		memberTasks[memberTask.SprintTaskID] = append(memberTasks[memberTask.SprintTaskID], memberTask)
+	}
+
+	rows := [][]string{{
+		"Key", "Summary", "Type", "Status", "Priority", "Estimate", "Done At", "Resolution",
+		"Sprint Points Earned", "Total Points Earned", "Sprint Hours", "Total Hours",
+		"Member", "Role", "Member Hours", "Member Points Earned", "Member Points Assigned", "Member Rating",
+	}}
+	for _, task := range report.Tasks {
+		doneAt := ""
+		if task.DoneAt != nil {
+			doneAt = task.DoneAt.Format(reportDateFormat)
+		}
+		taskColumns := []string{
+			task.Key,
+			task.Summary,
+			task.Type,
+			task.Status,
+			task.Priority,
+			formatFloat(task.Estimate),
+			doneAt,
+			retroModels.Resolution(task.Resolution).GetStringValue(),
+			formatFloat(task.PointsEarned),
+			formatFloat(task.TotalPointsEarned),
+			formatHours(task.SprintTime),
+			formatHours(task.TotalTime),
+		}
+		if len(memberTasks[task.ID]) == 0 {
+			rows = append(rows, append(taskColumns, "", "", "", "", "", ""))
+			continue
+		}
+		for _, memberTask := range memberTasks[task.ID] {
+			rows = append(rows, append(append([]string{}, taskColumns...),
+				strings.TrimSpace(memberTask.FirstName+" "+memberTask.LastName),
+				memberTask.Role.GetStringValue(),
+				formatHours(memberTask.TimeSpentMinutes),
+				formatFloat(memberTask.PointsEarned),
+				formatFloat(memberTask.PointsAssigned),
+				memberTask.Rating.GetStringValue(),
+			))
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// reportSection is a titled table of the report, shared between the markdown and the pdf renderers
+type reportSection struct {
+	Title  string
+	Header []string
+	Rows   [][]string
+}
+
+func sprintReportSections(report *retroSerializers.SprintReport) []reportSection {
+	summary := report.Sprint.Summary
+	sections := []reportSection{{
+		Title:  "Summary",
+		Header: []string{"Metric", "Value"},
+		Rows: [][]string{
+			{"Members", fmt.Sprint(summary.MemberCount)},
+			{"Total Allocation (%)", formatFloat(summary.TotalAllocation)},
+			{"Total Expectation (%)", formatFloat(summary.TotalExpectation)},
+			{"Total Vacations (days)", formatFloat(summary.TotalVacations)},
+			{"Holidays (days)", formatFloat(summary.Holidays)},
+			{"Target Story Points", formatFloat(summary.TargetSP)},
+		},
+	}}
+
+	taskSummary := reportSection{
+		Title:  "Task Summary",
+		Header: []string{"Type", "Done", "Total", "Points Earned (Done)", "Points Earned (Total)", "Hours"},
+	}
+	for _, taskType := range sortedKeys(summary.TaskSummary) {
+		typeSummary := summary.TaskSummary[taskType]
+		taskSummary.Rows = append(taskSummary.Rows, []string{
+			taskType,
+			fmt.Sprint(typeSummary.Count),
+			fmt.Sprint(typeSummary.TotalCount),
+			formatFloat(typeSummary.PointsEarned),
+			formatFloat(typeSummary.TotalPointsEarned),
+			formatHours(typeSummary.ActualHours),
+		})
+	}
+	sections = append(sections, taskSummary)
+
+	members := reportSection{
+		Title: "Member Summary",
+		Header: []string{"Member", "Allocation (%)", "Expectation (%)", "Vacations", "Expected SP", "Earned SP",
+			"Hours", "Rating", "Comment"},
+	}
+	for _, member := range report.Members {
+		members.Rows = append(members.Rows, []string{
+			strings.TrimSpace(member.FirstName + " " + member.LastName),
+			formatFloat(member.AllocationPercent),
+			formatFloat(member.ExpectationPercent),
+			formatFloat(member.Vacations),
+			formatFloat(member.ExpectedStoryPoint),
+			formatFloat(member.ActualStoryPoint),
+			formatFloat(member.TotalTimeSpentInMin / 60),
+			retrospective.Rating(member.Rating).GetStringValue(),
+			member.Comment,
+		})
+	}
+	sections = append(sections, members)
+
+	tasks := reportSection{
+		Title:  "Tasks",
+		Header: []string{"Key", "Summary", "Type", "Status", "Estimate", "Points Earned", "Hours", "Owner"},
+	}
+	for _, task := range report.Tasks {
+		tasks.Rows = append(tasks.Rows, []string{
+			task.Key,
+			task.Summary,
+			task.Type,
+			task.Status,
+			formatFloat(task.Estimate),
+			formatFloat(task.PointsEarned),
+			formatHours(task.SprintTime),
+			task.SprintOwner,
+		})
+	}
+	sections = append(sections, tasks)
+
+	goalHeader := []string{"Goal", "Type", "Assignee", "Expected At", "Resolved At"}
+	for _, goals := range []struct {
+		title     string
+		feedbacks []retroModels.RetrospectiveFeedback
+	}{
+		{"Goals Added", report.AddedGoals},
+		{"Goals Completed", report.CompletedGoals},
+		{"Goals Pending", report.PendingGoals},
+	} {
+		section := reportSection{Title: goals.title, Header: goalHeader}
+		for _, goal := range goals.feedbacks {
+			section.Rows = append(section.Rows, []string{
+				goal.Text,
+				goal.SubType,
+				strings.TrimSpace(goal.Assignee.DisplayName()),
+				formatDate(goal.ExpectedAt),
+				formatDate(goal.ResolvedAt),
+			})
+		}
+		sections = append(sections, section)
+	}
+
+	feedbackHeader := []string{"Text", "Type", "Scope", "Added By"}
+	for _, feedbacks := range []struct {
+		title     string
+		feedbacks []retroModels.RetrospectiveFeedback
+	}{
+		{"Highlights", report.Highlights},
+		{"Notes", report.Notes},
+	} {
+		section := reportSection{Title: feedbacks.title, Header: feedbackHeader}
+		for _, feedback := range feedbacks.feedbacks {
+			addedBy := strings.TrimSpace(feedback.CreatedBy.DisplayName())
+			if feedback.Anonymous {
+				addedBy = "Anonymous"
+			}
+			section.Rows = append(section.Rows, []string{
+				feedback.Text,
+				feedback.SubType,
+				feedback.Scope.GetStringValue(),
+				addedBy,
+			})
+		}
+		sections = append(sections, section)
+	}
+
+	return sections
+}
+
+func renderSprintReportMarkdown(report *retroSerializers.SprintReport) []byte {
+	var buffer bytes.Buffer
+	sprint := report.Sprint
+
+	fmt.Fprintf(&buffer, "# %s - %s\n\n", markdownEscape(report.RetrospectiveTitle), markdownEscape(sprint.Title))
+	fmt.Fprintf(&buffer, "**Duration:** %s - %s  \n**Status:** %s\n",
+		sprint.StartDate.Format(reportDateFormat),
+		sprint.EndDate.Format(reportDateFormat),
+		sprint.Status.GetStringValue())
+
+	for _, section := range sprintReportSections(report) {
+		fmt.Fprintf(&buffer, "\n## %s\n\n", section.Title)
+		if len(section.Rows) == 0 {
+			buffer.WriteString("_None_\n")
+			continue
+		}
+		writeMarkdownRow(&buffer, section.Header)
+		separators := make([]string, len(section.Header))
+		for index := range separators {
+			separators[index] = "---"
+		}
+		writeMarkdownRow(&buffer, separators)
+		for _, row := range section.Rows {
+			cells := make([]string, len(row))
+			for index, cell := range row {
+				cells[index] = markdownEscape(cell)
+			}
+			writeMarkdownRow(&buffer, cells)
+		}
+	}
+	return buffer.Bytes()
+}
+
+func renderSprintReportPDF(report *retroSerializers.SprintReport) []byte {
+	document := pdf.New()
+	sprint := report.Sprint
+
+	document.Heading(report.RetrospectiveTitle+" - "+sprint.Title, 16)
+	document.Text(fmt.Sprintf("Duration: %s - %s    Status: %s",
+		sprint.StartDate.Format(reportDateFormat),
+		sprint.EndDate.Format(reportDateFormat),
+		sprint.Status.GetStringValue()))
+
+	for _, section := range sprintReportSections(report) {
+		document.Space()
+		document.Heading(section.Title, 12)
+		if len(section.Rows) == 0 {
+			document.Text("None")
+			continue
+		}
+		for _, row := range section.Rows {
+			lines := make([]string, len(row))
+			for index, cell := range row {
+				lines[index] = section.Header[index] + ": " + cell
+			}
+			document.Mono(strings.Join(lines, " | "))
+		}
+	}
+	return document.Bytes()
+}
+
+func writeMarkdownRow(buffer *bytes.Buffer, cells []string) {
+	buffer.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+func markdownEscape(text string) string {
+	text = strings.Replace(text, "|", "\\|", -1)
+	return strings.Replace(strings.Replace(text, "\r\n", "<br>", -1), "\n", "<br>", -1)
+}
+
+func sortedKeys(taskSummary map[string]retroSerializers.SprintTaskSummary) []string {
+	keys := make([]string, 0, len(taskSummary))
+	for key := range taskSummary {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatFloat(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
+
+func formatHours(minutes uint) string {
+	return formatFloat(float64(minutes) / 60)
+}
+
+func formatDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(reportDateFormat)
+}
+
+func slugify(text string) string {
+	return strings.Trim(strings.Map(func(char rune) rune {
+		switch {
+		case char >= 'a' && char <= 'z', char >= '0' && char <= '9':
+			return char
+		case char >= 'A' && char <= 'Z':
+			return char + 'a' - 'A'
+		}
+		return '-'
+	}, text), "-")
+}
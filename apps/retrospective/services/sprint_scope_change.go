@@ -0,0 +1,159 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	userSerializer "github.com/iReflect/reflect-app/apps/user/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// recordScopeChange adds an entry to the scope change log of a sprint
+func recordScopeChange(db *gorm.DB, scopeChange retroModels.SprintTaskScopeChange) error {
+	return db.Set("gorm:save_associations", false).Create(&scopeChange).Error
+}
+
+// GetSprintScopeChanges returns the scope change log of a sprint
+func (service SprintService) GetSprintScopeChanges(sprintID string) ([]retroSerializers.SprintScopeChangeMarker, int, error) {
+	intSprintID, err := strconv.Atoi(sprintID)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("invalid sprint id")
+	}
+
+	scopeChanges, err := service.getSprintScopeChanges(uint(intSprintID), nil)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint scope changes")
+	}
+	return scopeChanges, http.StatusOK, nil
+}
+
+// getSprintScopeChanges returns the scope changes of a sprint in chronological order, optionally
+// only the ones after the given time
+func (service SprintService) getSprintScopeChanges(sprintID uint,
+	after *time.Time) ([]retroSerializers.SprintScopeChangeMarker, error) {
+	db := service.DB
+	var scopeChanges []retroModels.SprintTaskScopeChange
+
+	query := db.Model(&retroModels.SprintTaskScopeChange{}).
+		Where("sprint_task_scope_changes.deleted_at IS NULL").
+		Where("sprint_id = ?", sprintID)
+	if after != nil {
+		query = query.Where("created_at > ?", *after)
+	}
+
+	// Tasks removed from their only sprint are deleted, so their details are preloaded irrespective of that
+	err := query.
+		Preload("Task", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("ChangedBy").
+		Order("created_at, id").
+		Find(&scopeChanges).Error
+	if err != nil {
+		return nil, err
+	}
+
+	markers := []retroSerializers.SprintScopeChangeMarker{}
+	for _, scopeChange := range scopeChanges {
+		marker := retroSerializers.SprintScopeChangeMarker{
+			Date:             scopeChange.CreatedAt,
+			Change:           scopeChange.Type.GetStringValue(),
+			Source:           scopeChange.Source.GetStringValue(),
+			TaskID:           scopeChange.TaskID,
+			Key:              scopeChange.Task.Key,
+			Summary:          scopeChange.Task.Summary,
+			PreviousEstimate: scopeChange.PreviousEstimate,
+			Estimate:         scopeChange.Estimate,
+		}
+		if scopeChange.ChangedBy != nil {
+			marker.ChangedBy = &userSerializer.User{
+				ID:        scopeChange.ChangedBy.ID,
+				Email:     scopeChange.ChangedBy.Email,
+				FirstName: scopeChange.ChangedBy.FirstName,
+				LastName:  scopeChange.ChangedBy.LastName,
+				Active:    scopeChange.ChangedBy.Active,
+			}
+		}
+		markers = append(markers, marker)
+	}
+	return markers, nil
+}
+
+// getSprintCommitmentTime returns the time until which the tasks entering the sprint are considered committed,
+// i.e. the end of the first day of the sprint or the first successful sync of the sprint, whichever is later
+func (service SprintService) getSprintCommitmentTime(sprint retroModels.Sprint) (time.Time, error) {
+	db := service.DB
+	var syncStatus retroModels.SprintSyncStatus
+
+	calendar := retroModels.GetWorkingCalendarFromRetro(db, sprint.RetrospectiveID)
+	committedAt := utils.GetStartOfDay(calendar.In(*sprint.StartDate)).AddDate(0, 0, 1)
+
+	err := db.Model(&retroModels.SprintSyncStatus{}).
+		Where("sprint_sync_statuses.deleted_at IS NULL").
+		Where("sprint_id = ? AND status = ?", sprint.ID, retroModels.Synced).
+		Order("created_at").
+		First(&syncStatus).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return committedAt, nil
+		}
+		return committedAt, err
+	}
+
+	if syncStatus.CreatedAt.After(committedAt) {
+		committedAt = syncStatus.CreatedAt
+	}
+	return committedAt, nil
+}
+
+// getSprintScopeSummary compares the tasks committed at the start of the sprint with the ones added later
+func (service SprintService) getSprintScopeSummary(sprint retroModels.Sprint) (*retroSerializers.SprintScopeSummary, error) {
+	db := service.DB
+	summary := retroSerializers.SprintScopeSummary{}
+
+	committedAt, err := service.getSprintCommitmentTime(sprint)
+	if err != nil {
+		return nil, err
+	}
+	summary.CommittedAt = committedAt
+
+	err = db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Scopes(retroModels.STJoinTask).
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Select(`
+            COUNT(DISTINCT tasks.id) FILTER (WHERE sprint_tasks.created_at <= ?),
+            COALESCE(SUM(tasks.estimate) FILTER (WHERE sprint_tasks.created_at <= ?), 0),
+            COUNT(DISTINCT tasks.id) FILTER (WHERE sprint_tasks.created_at > ?),
+            COALESCE(SUM(tasks.estimate) FILTER (WHERE sprint_tasks.created_at > ?), 0)`,
+			committedAt, committedAt, committedAt, committedAt).
+		Row().
+		Scan(&summary.CommittedTaskCount, &summary.CommittedEstimate, &summary.AddedTaskCount, &summary.AddedEstimate)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Model(&retroModels.SprintTaskScopeChange{}).
+		Where("sprint_task_scope_changes.deleted_at IS NULL").
+		Where("sprint_id = ? AND created_at > ?", sprint.ID, committedAt).
+		Select(`
+            COUNT(*) FILTER (WHERE type = ?),
+            COALESCE(SUM(previous_estimate) FILTER (WHERE type = ?), 0),
+            COUNT(*) FILTER (WHERE type = ?)`,
+			retroModels.TaskRemovedScopeChange, retroModels.TaskRemovedScopeChange,
+			retroModels.TaskReestimatedScopeChange).
+		Row().
+		Scan(&summary.RemovedTaskCount, &summary.RemovedEstimate, &summary.ReestimatedCount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &summary, nil
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"github.com/deckarep/golang-set"
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	timeTrackerSerializers "github.com/iReflect/reflect-app/apps/timetracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// rollUpSubtasks replaces the subtasks among the tickets with their parent tickets, the estimates of the subtasks
+// are added to the one of their parent. The parents in the synced key set are not fetched again, the estimates of
+// their subtasks are added to their stored tasks and only the keys of their subtasks are returned. The subtasks
+// whose parent cannot be fetched are kept as they are.
+// It returns the tickets to sync along with the keys of the subtasks of each parent.
+func (service SprintService) rollUpSubtasks(
+	sprint retroModels.Sprint,
+	taskProviderConfig []byte,
+	tickets []taskTrackerSerializers.Task,
+	syncedTaskKeySet mapset.Set) ([]taskTrackerSerializers.Task, map[string][]string, error) {
+
+	var rolledUpTickets []taskTrackerSerializers.Task
+	subtasks := make(map[string][]taskTrackerSerializers.Task)
+	for _, ticket := range tickets {
+		if ticket.ParentKey == "" {
+			rolledUpTickets = append(rolledUpTickets, ticket)
+		} else {
+			subtasks[ticket.ParentKey] = append(subtasks[ticket.ParentKey], ticket)
+		}
+	}
+	if len(subtasks) == 0 {
+		return tickets, nil, nil
+	}
+
+	ticketIndexes := make(map[string]int)
+	for index, ticket := range rolledUpTickets {
+		ticketIndexes[ticket.Key] = index
+	}
+	var missingParentKeys []string
+	for parentKey := range subtasks {
+		if _, exists := ticketIndexes[parentKey]; !exists && !syncedTaskKeySet.Contains(parentKey) {
+			missingParentKeys = append(missingParentKeys, parentKey)
+		}
+	}
+	if len(missingParentKeys) > 0 {
+		parentTickets, err := tasktracker.GetTaskList(taskProviderConfig, missingParentKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, parentTicket := range parentTickets {
+			ticketIndexes[parentTicket.Key] = len(rolledUpTickets)
+			rolledUpTickets = append(rolledUpTickets, parentTicket)
+		}
+	}
+
+	subtaskKeys := make(map[string][]string)
+	for parentKey, parentSubtasks := range subtasks {
+		index, exists := ticketIndexes[parentKey]
+		if !exists && !syncedTaskKeySet.Contains(parentKey) {
+			rolledUpTickets = append(rolledUpTickets, parentSubtasks...)
+			continue
+		}
+		subtasksEstimate := float64(0)
+		for _, subtask := range parentSubtasks {
+			subtaskKeys[parentKey] = append(subtaskKeys[parentKey], subtask.Key)
+			if subtask.Estimate != nil {
+				subtasksEstimate += *subtask.Estimate
+			}
+		}
+		if subtasksEstimate == 0 {
+			continue
+		}
+		if !exists {
+			if err := service.addSubtasksEstimate(sprint, parentKey, subtasksEstimate); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		if rolledUpTickets[index].Estimate != nil {
+			subtasksEstimate += *rolledUpTickets[index].Estimate
+		}
+		rolledUpTickets[index].Estimate = &subtasksEstimate
+	}
+	return rolledUpTickets, subtaskKeys, nil
+}
+
+// addSubtasksEstimate adds the estimate of the rolled up subtasks to the stored task of their parent, already
+// synced along with the sprint, and logs the scope change
+func (service SprintService) addSubtasksEstimate(sprint retroModels.Sprint, parentKey string, estimate float64) error {
+	tx := service.DB.Begin()
+	var parentTask retroModels.Task
+	err := tx.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Scopes(retroModels.TaskJoinTaskKeyMaps).
+		Where("tasks.retrospective_id = ?", sprint.RetrospectiveID).
+		Where("task_key_maps.key = ?", parentKey).
+		Select("tasks.*").
+		First(&parentTask).Error
+	if err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+
+	err = recordScopeChange(tx, retroModels.SprintTaskScopeChange{
+		SprintID:         sprint.ID,
+		TaskID:           parentTask.ID,
+		Type:             retroModels.TaskReestimatedScopeChange,
+		Source:           retroModels.SyncScopeChangeSource,
+		PreviousEstimate: parentTask.Estimate,
+		Estimate:         parentTask.Estimate + estimate,
+	})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err = service.changeTaskEstimates(tx, parentTask, parentTask.Estimate+estimate); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+// getLoggedSubtasks returns the subtasks, among the tickets of the time logs missing from the tickets, whose
+// parents are among the tickets
+func getLoggedSubtasks(
+	taskProviderConfig []byte,
+	tickets []taskTrackerSerializers.Task,
+	timeTrackerTaskKeys []string) ([]taskTrackerSerializers.Task, error) {
+	ticketKeySet := mapset.NewSet()
+	for _, ticket := range tickets {
+		ticketKeySet.Add(ticket.Key)
+	}
+	missingTaskKeySet := mapset.NewSetFromSlice(utils.StringSliceToInterfaceSlice(timeTrackerTaskKeys)).
+		Difference(ticketKeySet)
+	if missingTaskKeySet.Cardinality() == 0 {
+		return nil, nil
+	}
+
+	missingTickets, err := tasktracker.GetTaskList(taskProviderConfig,
+		utils.InterfaceSliceToStringSlice(missingTaskKeySet.ToSlice()))
+	if err != nil {
+		return nil, err
+	}
+	var subtasks []taskTrackerSerializers.Task
+	for _, ticket := range missingTickets {
+		if ticket.ParentKey != "" && ticketKeySet.Contains(ticket.ParentKey) && !ticketKeySet.Contains(ticket.Key) {
+			subtasks = append(subtasks, ticket)
+		}
+	}
+	return subtasks, nil
+}
+
+// addOrUpdateSubtask rolls up a subtask, missing from the sprint, to its parent
+func (service SprintService) addOrUpdateSubtask(
+	sprint retroModels.Sprint,
+	taskProviderConfig []byte,
+	subtask taskTrackerSerializers.Task,
+	alternateTaskKey string,
+	syncedTaskKeySet mapset.Set) error {
+
+	tickets, subtaskKeys, err := service.rollUpSubtasks(
+		sprint,
+		taskProviderConfig,
+		[]taskTrackerSerializers.Task{subtask},
+		syncedTaskKeySet)
+	if err != nil {
+		return err
+	}
+
+	for _, ticket := range tickets {
+		ticketAlternateKey := ""
+		if ticket.Key == subtask.Key {
+			ticketAlternateKey = alternateTaskKey
+		}
+		err = service.addOrUpdateTaskTrackerTask(sprint, ticket, sprint.RetrospectiveID, ticketAlternateKey)
+		if err != nil {
+			return err
+		}
+	}
+	for parentKey, keys := range subtaskKeys {
+		if err = service.mapSubtaskKeys(sprint.RetrospectiveID, parentKey, append(keys, alternateTaskKey)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mapRolledUpSubtaskKeys maps the keys of the rolled up subtasks to their parents and adds them to the key set
+func (service SprintService) mapRolledUpSubtaskKeys(
+	retroID uint,
+	subtaskKeys map[string][]string,
+	taskKeySet mapset.Set) error {
+	for parentKey, keys := range subtaskKeys {
+		if err := service.mapSubtaskKeys(retroID, parentKey, keys); err != nil {
+			return err
+		}
+		for _, key := range keys {
+			taskKeySet.Add(key)
+		}
+	}
+	return nil
+}
+
+// mapSubtaskKeys adds the keys of the rolled up subtasks to the task of their parent, so that the time logged on
+// the subtasks is logged on the parent. The keys are moved from the tasks the subtasks were synced as before.
+func (service SprintService) mapSubtaskKeys(retroID uint, parentKey string, subtaskKeys []string) error {
+	tx := service.DB.Begin()
+	var parentTask retroModels.Task
+	err := tx.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Scopes(retroModels.TaskJoinTaskKeyMaps).
+		Where("tasks.retrospective_id = ?", retroID).
+		Where("task_key_maps.key = ?", parentKey).
+		Select("tasks.*").
+		First(&parentTask).Error
+	if err != nil {
+		tx.Rollback()
+		if err == gorm.ErrRecordNotFound {
+			return nil
+		}
+		return err
+	}
+
+	for _, subtaskKey := range subtaskKeys {
+		if subtaskKey == "" || subtaskKey == parentKey {
+			continue
+		}
+		err = tx.Where("task_key_maps.key = ? AND task_key_maps.task_id <> ?", subtaskKey, parentTask.ID).
+			Where("task_key_maps.task_id IN (?)", tx.Model(&retroModels.Task{}).
+				Where("tasks.retrospective_id = ?", retroID).
+				Select("id").
+				QueryExpr()).
+			Delete(&retroModels.TaskKeyMap{}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		err = tx.Where(retroModels.TaskKeyMap{TaskID: parentTask.ID, Key: subtaskKey}).
+			Where("task_key_maps.deleted_at IS NULL").
+			FirstOrCreate(&retroModels.TaskKeyMap{}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
+// mergeTaskTimeLogs adds up the time logs whose keys belong to the same task of the retrospective, the merged
+// time log has the key of the first of them
+func (service SprintService) mergeTaskTimeLogs(
+	retroID uint,
+	timeLogs []timeTrackerSerializers.TimeLog) ([]timeTrackerSerializers.TimeLog, error) {
+	db := service.DB
+	var keys []string
+	for _, timeLog := range timeLogs {
+		keys = append(keys, timeLog.TaskKey)
+	}
+	if len(keys) == 0 {
+		return timeLogs, nil
+	}
+
+	rows, err := db.Model(&retroModels.TaskKeyMap{}).
+		Where("task_key_maps.deleted_at IS NULL").
+		Scopes(retroModels.TaskKeyMapJoinTask).
+		Where("tasks.retrospective_id = ?", retroID).
+		Where("task_key_maps.key IN (?)", keys).
+		Select("task_key_maps.key, task_key_maps.task_id").
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keyTaskIDs := make(map[string]uint)
+	for rows.Next() {
+		var key string
+		var taskID uint
+		if err := rows.Scan(&key, &taskID); err != nil {
+			return nil, err
+		}
+		keyTaskIDs[key] = taskID
+	}
+
+	var mergedTimeLogs []timeTrackerSerializers.TimeLog
+	taskTimeLogIndexes := make(map[uint]int)
+	for _, timeLog := range timeLogs {
+		taskID, exists := keyTaskIDs[timeLog.TaskKey]
+		if !exists {
+			mergedTimeLogs = append(mergedTimeLogs, timeLog)
+			continue
+		}
+		if index, merged := taskTimeLogIndexes[taskID]; merged {
+			mergedTimeLogs[index].Minutes += timeLog.Minutes
+			continue
+		}
+		taskTimeLogIndexes[taskID] = len(mergedTimeLogs)
+		mergedTimeLogs = append(mergedTimeLogs, timeLog)
+	}
+	return mergedTimeLogs, nil
+}
@@ -54,22 +54,28 @@ func (service SprintService) SyncSprintData(sprintID string) (err error) {
 	}
 
 	// TODO Restructure code-flow and document it to make it readable
-	taskTrackerTaskKeySet, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig)
+	var timeTrackerTaskKeys []string
+	sprintMemberTimeLogs := map[uint][]timeTrackerSerializers.TimeLog{}
+
+	// The time logs are fetched first so that the logged subtasks are rolled up along with the sprint tasks
+	timeTrackerTaskKeys, sprintMemberTimeLogs, err = service.GetTimeTrackerData(sprint, taskProviderConfig)
 	if err != nil {
 		utils.LogToSentry(err)
 		service.SetSyncFailed(sprint.ID)
 		return err
 	}
 
-	var timeTrackerTaskKeys []string
-	sprintMemberTimeLogs := map[uint][]timeTrackerSerializers.TimeLog{}
-
-	timeTrackerTaskKeys, sprintMemberTimeLogs, err = service.GetTimeTrackerData(sprint, taskProviderConfig)
+	taskTrackerTaskKeySet, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig, timeTrackerTaskKeys)
 	if err != nil {
 		utils.LogToSentry(err)
 		service.SetSyncFailed(sprint.ID)
 		return err
 	}
+
+	// The tickets of the goals are not a part of the sprint, failing to sync them should not fail the sprint sync
+	if err = service.syncGoalTrackerTasks(sprint, taskProviderConfig); err != nil {
+		utils.LogToSentry(err)
+	}
 	insertedTimeTrackerTaskKeySet, err := service.fetchAndUpdateTimeTrackerTask(
 		sprint,
 		sprint.RetrospectiveID,
@@ -92,6 +98,24 @@ func (service SprintService) SyncSprintData(sprintID string) (err error) {
 		service.SetSyncFailed(sprint.ID)
 		return err
 	}
+	// The tickets whose key changed may have been synced again as new tasks
+	if err = service.reconcileTasks(sprint.RetrospectiveID); err != nil {
+		utils.LogToSentry(err)
+		service.SetSyncFailed(sprint.ID)
+		return err
+	}
+	syncedTaskKeySet := taskTrackerTaskKeySet.Union(
+		mapset.NewSetFromSlice(utils.StringSliceToInterfaceSlice(timeTrackerTaskKeys)))
+	if err = service.removeLeftSprintTasks(sprint, taskTrackerTaskKeySet, syncedTaskKeySet); err != nil {
+		utils.LogToSentry(err)
+		service.SetSyncFailed(sprint.ID)
+		return err
+	}
+	if err = retroModels.LinkTaskParents(service.DB, sprint.RetrospectiveID); err != nil {
+		utils.LogToSentry(err)
+		service.SetSyncFailed(sprint.ID)
+		return err
+	}
 	for _, sprintMember := range sprint.SprintMembers {
 		err = service.updateSprintMemberTimeLog(
 			sprint.ID,
@@ -201,12 +225,6 @@ func (service SprintService) SyncSprintMemberData(sprintID uint, sprintMemberID
 		return err
 	}
 
-	taskTrackerTaskKeySet, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig)
-	if err != nil {
-		utils.LogToSentry(err)
-		service.SetSyncFailed(sprint.ID)
-		return err
-	}
 	timeProviderConfig := sprintMember.Member.TimeProviderConfig
 	if sprint.Retrospective.TimeProviderName == timeTrackerProviders.TimeProviderJira {
 		timeProviderConfig = taskProviderConfig
@@ -217,6 +235,13 @@ func (service SprintService) SyncSprintMemberData(sprintID uint, sprintMemberID
 		service.SetSyncFailed(sprint.ID)
 		return err
 	}
+
+	taskTrackerTaskKeySet, err := service.fetchAndUpdateTaskTrackerTask(sprint, taskProviderConfig, timeTrackerTaskKeys)
+	if err != nil {
+		utils.LogToSentry(err)
+		service.SetSyncFailed(sprint.ID)
+		return err
+	}
 	var memberTimeLogs []timeTrackerSerializers.TimeLog
 	for _, timeLog := range timeLogs {
 		if sprintMember.Member.Email == timeLog.Email {
@@ -247,6 +272,17 @@ func (service SprintService) SyncSprintMemberData(sprintID uint, sprintMemberID
 		service.SetSyncFailed(sprint.ID)
 		return err
 	}
+	// The tickets whose key changed may have been synced again as new tasks
+	if err = service.reconcileTasks(sprint.RetrospectiveID); err != nil {
+		utils.LogToSentry(err)
+		service.SetSyncFailed(sprint.ID)
+		return err
+	}
+	if err = retroModels.LinkTaskParents(service.DB, sprint.RetrospectiveID); err != nil {
+		utils.LogToSentry(err)
+		service.SetSyncFailed(sprint.ID)
+		return err
+	}
 
 	err = service.updateSprintMemberTimeLog(
 		sprint.ID,
@@ -286,50 +322,11 @@ func (service SprintService) AssignPoints(sprintID string, sprintTaskID *string)
 
 	service.SetSyncing(sprint.ID)
 
-	// sprintTaskToSkipPointsAllocation is the list of all the sprint tasks which can be skipped for the points allocation,
-	// i.e., sprint tasks with no related SMTs or SMTs with differing points earned and points assigned value.
-	sprintTaskToSkipPointsAllocation := db.Model(retroModels.SprintTask{}).
-		Scopes(retroModels.STLeftJoinSMT).
-		Where("sprint_tasks.sprint_id = ?", sprintID).
-		Where("sprint_member_tasks.id IS NULL OR sprint_member_tasks.points_earned <> sprint_member_tasks.points_assigned").
-		Select("DISTINCT sprint_tasks.id").QueryExpr()
-
-	annotatedSMTExpr := db.Model(retroModels.SprintMemberTask{}).
-		Where("sprint_member_tasks.deleted_at IS NULL").
-		Scopes(retroModels.SMTJoinSM, retroModels.SMTJoinST, retroModels.STJoinTask, retroModels.SMJoinSprint).
-		Where("(sprints.status <> ? OR sprints.id = ?)", retroModels.DraftSprint, sprintID).
-		Not("sprint_tasks.id in (?)", sprintTaskToSkipPointsAllocation).
-		Scopes(retroModels.NotDeletedSprint).
-		Where("tasks.retrospective_id = ?", sprint.RetrospectiveID).
-		Where("tasks.done_at IS NOT NULL").
-		Select(`
-            sprint_member_tasks.*, 
-            sprint_members.sprint_id,
-            (SUM(sprint_member_tasks.time_spent_minutes)
-				OVER (PARTITION BY sprint_tasks.task_id, sprint_members.sprint_id)::numeric) AS sprint_task_total_time_spent,
-            (tasks.estimate - (SUM(sprint_member_tasks.points_earned) OVER
-				(PARTITION BY sprint_tasks.task_id)) + (SUM(sprint_member_tasks.points_earned) OVER
-				(PARTITION BY sprint_tasks.id))) AS remaining_points
-        `).QueryExpr()
-
-	updateSQL := `UPDATE sprint_member_tasks
-	    SET points_assigned = COALESCE(sprint_member_tasks.time_spent_minutes
-				/ NULLIF(s1.sprint_task_total_time_spent, 0) * s1.remaining_points, 0),
-            points_earned = COALESCE(sprint_member_tasks.time_spent_minutes
-				/ NULLIF(s1.sprint_task_total_time_spent, 0) * s1.remaining_points, 0),
-            updated_at = NOW()
-        FROM (?) AS s1 
-        WHERE s1.sprint_id = ? AND sprint_member_tasks.id = s1.id`
-
-	sqlValues := []interface{}{annotatedSMTExpr, sprintID}
-
-	if sprintTaskID != nil {
-		updateSQL = fmt.Sprintf("%s AND s1.sprint_task_id = ?", updateSQL)
-		sqlValues = append(sqlValues, *sprintTaskID)
+	// The locked points, i.e. the manually edited ones, are kept
+	allocation, err := service.computePointsAllocation(sprint, sprintTaskID, false)
+	if err == nil {
+		err = service.applyPointsAllocation(allocation, false)
 	}
-
-	err = db.Exec(updateSQL, sqlValues...).Error
-
 	if err != nil {
 		utils.LogToSentry(err)
 		service.SetSyncFailed(sprint.ID)
@@ -425,7 +422,8 @@ func (service SprintService) GetSprintMemberSanitizedTimeTrackerData(
 	return sanitizedMemberTaskKeys, sanitizedTimeLogs, nil
 }
 
-// GetSprintMemberTimeTrackerData ...
+// GetSprintMemberTimeTrackerData returns the time logged in the sprint, the days of the sprint are the ones
+// in the time zone of the team, or in the one of the time tracker when the team has none
 func (service SprintService) GetSprintMemberTimeTrackerData(
 	timeTrackerConfig []byte,
 	sprint retroModels.Sprint) ([]string, []timeTrackerSerializers.TimeLog, error) {
@@ -434,7 +432,8 @@ func (service SprintService) GetSprintMemberTimeTrackerData(
 		timeTrackerConfig,
 		sprint.Retrospective.ProjectName,
 		*sprint.StartDate,
-		*sprint.EndDate)
+		*sprint.EndDate,
+		retroModels.GetTeamLocationFromRetro(service.DB, sprint.RetrospectiveID))
 	if err != nil {
 		utils.LogToSentry(err)
 		service.SetSyncFailed(sprint.ID)
@@ -502,9 +501,7 @@ func (service SprintService) insertTimeTrackerTask(sprintID uint, ticketKey stri
 		return err
 	}
 
-	err = tx.Where(retroModels.SprintTask{SprintID: sprintID, TaskID: task.ID}).
-		Where("sprint_tasks.deleted_at IS NULL").
-		FirstOrCreate(&retroModels.SprintTask{}).Error
+	_, err = service.addSprintTask(tx, sprintID, task.ID, task.Estimate)
 	if err != nil {
 		tx.Rollback()
 		utils.LogToSentry(err)
@@ -515,6 +512,103 @@ func (service SprintService) insertTimeTrackerTask(sprintID uint, ticketKey stri
 	return nil
 }
 
+// addSprintTask adds the task to the sprint, unless it already is a part of it, and logs the scope change
+func (service SprintService) addSprintTask(tx *gorm.DB, sprintID uint, taskID uint, estimate float64) (added bool, err error) {
+	var sprintTask retroModels.SprintTask
+
+	err = tx.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_id = ? AND task_id = ?", sprintID, taskID).
+		First(&sprintTask).Error
+	if err == nil {
+		return false, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return false, err
+	}
+
+	sprintTask = retroModels.SprintTask{SprintID: sprintID, TaskID: taskID}
+	if err = tx.Set("gorm:save_associations", false).Create(&sprintTask).Error; err != nil {
+		return false, err
+	}
+
+	return true, recordScopeChange(tx, retroModels.SprintTaskScopeChange{
+		SprintID: sprintID,
+		TaskID:   taskID,
+		Type:     retroModels.TaskAddedScopeChange,
+		Source:   retroModels.SyncScopeChangeSource,
+		Estimate: estimate,
+	})
+}
+
+// removeLeftSprintTasks removes the tickets which left the sprint in the task tracker from the active sprint
+// and logs the scope changes. The tasks seen in the tracker sprint before with none of their keys synced now are
+// the ones which left, unless they were planned or members were added to them in the sprint. Nothing is removed
+// when no ticket of the sprint could be fetched.
+func (service SprintService) removeLeftSprintTasks(
+	sprint retroModels.Sprint,
+	taskTrackerTaskKeySet mapset.Set,
+	syncedTaskKeySet mapset.Set) error {
+	if sprint.Status != retroModels.ActiveSprint || taskTrackerTaskKeySet.Cardinality() == 0 {
+		return nil
+	}
+	db := service.DB
+
+	rows, err := db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Scopes(retroModels.STJoinTask).
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Where("sprint_tasks.in_tracker_sprint = true").
+		Where("sprint_tasks.planned_estimate IS NULL").
+		Where("tasks.is_tracker_task = true").
+		Where(`NOT EXISTS (SELECT 1 FROM sprint_member_tasks
+			WHERE sprint_member_tasks.sprint_task_id = sprint_tasks.id AND sprint_member_tasks.deleted_at IS NULL)`).
+		Where(`NOT EXISTS (SELECT 1 FROM task_key_maps
+			WHERE task_key_maps.task_id = tasks.id AND task_key_maps.deleted_at IS NULL
+				AND task_key_maps.key IN (?))`, utils.InterfaceSliceToStringSlice(syncedTaskKeySet.ToSlice())).
+		Select("sprint_tasks.id, sprint_tasks.task_id, tasks.estimate").
+		Rows()
+	if err != nil {
+		return err
+	}
+	var scopeChanges []retroModels.SprintTaskScopeChange
+	var sprintTaskIDs []uint
+	for rows.Next() {
+		var sprintTaskID, taskID uint
+		var estimate float64
+		if err = rows.Scan(&sprintTaskID, &taskID, &estimate); err != nil {
+			rows.Close()
+			return err
+		}
+		sprintTaskIDs = append(sprintTaskIDs, sprintTaskID)
+		scopeChanges = append(scopeChanges, retroModels.SprintTaskScopeChange{
+			SprintID:         sprint.ID,
+			TaskID:           taskID,
+			Type:             retroModels.TaskRemovedScopeChange,
+			Source:           retroModels.SyncScopeChangeSource,
+			PreviousEstimate: estimate,
+		})
+	}
+	rows.Close()
+	if len(sprintTaskIDs) == 0 {
+		return nil
+	}
+
+	// The tasks are kept, so that they are found again when their tickets come back to the sprint
+	tx := db.Begin()
+	if err = tx.Where("id IN (?)", sprintTaskIDs).Delete(&retroModels.SprintTask{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	for _, scopeChange := range scopeChanges {
+		if err = recordScopeChange(tx, scopeChange); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit().Error
+}
+
 // changeTaskEstimates ...
 func (service SprintService) changeTaskEstimates(tx *gorm.DB, task retroModels.Task, estimate float64) (err error) {
 	txNotProvided := true
@@ -621,20 +715,22 @@ func (service SprintService) addOrUpdateTaskTrackerTask(
 	tx := service.DB.Begin()
 	var task retroModels.Task
 
+	// The task of a renamed or moved ticket is found by its TrackerUniqueID, its previous keys stay mapped to it
 	err = tx.Model(&retroModels.Task{}).
 		Where("tasks.deleted_at IS NULL").
 		Where(retroModels.Task{RetrospectiveID: retroID, TrackerUniqueID: ticket.TrackerUniqueID}).
 		Assign(retroModels.Task{
-			RetrospectiveID: retroID,
-			TrackerUniqueID: ticket.TrackerUniqueID,
-			Key:             ticket.Key,
-			Summary:         ticket.Summary,
-			Description:     ticket.Description,
-			Type:            ticket.Type,
-			Priority:        ticket.Priority,
-			Assignee:        ticket.Assignee,
-			Status:          ticket.Status,
-			IsTrackerTask:   true,
+			RetrospectiveID:  retroID,
+			TrackerUniqueID:  ticket.TrackerUniqueID,
+			Key:              ticket.Key,
+			Summary:          ticket.Summary,
+			Description:      ticket.Description,
+			Type:             ticket.Type,
+			Priority:         ticket.Priority,
+			Assignee:         ticket.Assignee,
+			Status:           ticket.Status,
+			IsTrackerTask:    true,
+			TrackerUpdatedAt: ticket.UpdatedAt,
 		}).
 		FirstOrCreate(&task).Error
 
@@ -644,6 +740,29 @@ func (service SprintService) addOrUpdateTaskTrackerTask(
 		return err
 	}
 
+	// The parent is linked once all the tickets are synced, see LinkTaskParents
+	taskColumns := map[string]interface{}{
+		"parent_tracker_unique_id": ticket.ParentTrackerUniqueID,
+		"epic_key":                 ticket.EpicKey,
+	}
+	if ticket.CreatedAt != nil {
+		taskColumns["tracker_created_at"] = ticket.CreatedAt
+	}
+	err = tx.Model(&retroModels.Task{}).
+		Where("id = ?", task.ID).
+		UpdateColumns(taskColumns).Error
+	if err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return err
+	}
+
+	if err = addTaskStatusTransitions(tx, task.ID, ticket.Transitions); err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return err
+	}
+
 	statusMap, err := tasktracker.GetStatusMapping(sprint.Retrospective.TaskProviderConfig)
 	if err != nil {
 		utils.LogToSentry(err)
@@ -697,21 +816,36 @@ func (service SprintService) addOrUpdateTaskTrackerTask(
 
 	}
 
-	err = tx.Where(retroModels.SprintTask{SprintID: sprint.ID, TaskID: task.ID}).
-		Where("sprint_tasks.deleted_at IS NULL").
-		FirstOrCreate(&retroModels.SprintTask{}).Error
+	estimate := float64(0)
+	if ticket.Estimate != nil {
+		estimate = *ticket.Estimate
+	}
 
+	added, err := service.addSprintTask(tx, sprint.ID, task.ID, estimate)
 	if err != nil {
 		tx.Rollback()
 		utils.LogToSentry(err)
 		return err
 	}
 
-	if ticket.Estimate == nil {
-		err = service.changeTaskEstimates(tx, task, 0)
-	} else {
-		err = service.changeTaskEstimates(tx, task, *ticket.Estimate)
+	// Estimate of a task entering the sprint is a part of its addition
+	if !added && task.Estimate != estimate {
+		err = recordScopeChange(tx, retroModels.SprintTaskScopeChange{
+			SprintID:         sprint.ID,
+			TaskID:           task.ID,
+			Type:             retroModels.TaskReestimatedScopeChange,
+			Source:           retroModels.SyncScopeChangeSource,
+			PreviousEstimate: task.Estimate,
+			Estimate:         estimate,
+		})
+		if err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return err
+		}
 	}
+
+	err = service.changeTaskEstimates(tx, task, estimate)
 	if err != nil {
 		tx.Rollback()
 		utils.LogToSentry(err)
@@ -721,18 +855,26 @@ func (service SprintService) addOrUpdateTaskTrackerTask(
 	return nil
 }
 
-// fetchAndUpdateTaskTrackerTask ...
+// fetchAndUpdateTaskTrackerTask syncs the tickets of the tracker sprint, the subtasks among the tickets of the
+// time logs are rolled up to their parents in the sprint along with the subtasks in the sprint
 func (service SprintService) fetchAndUpdateTaskTrackerTask(
 	sprint retroModels.Sprint,
-	taskProviderConfig []byte) (mapset.Set, error) {
+	taskProviderConfig []byte,
+	timeTrackerTaskKeys []string) (mapset.Set, error) {
 	taskTrackerTaskKeySet := mapset.NewSet()
 
+	storedTransitionTickets, err := service.getStoredTransitionTickets(sprint.RetrospectiveID)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
 	tickets, err := tasktracker.GetSprintTaskList(
 		taskProviderConfig,
 		taskTrackerSerializers.Sprint{
-			ID:       sprint.SprintID,
-			FromDate: sprint.StartDate,
-			ToDate:   sprint.EndDate,
+			ID:                      sprint.SprintID,
+			FromDate:                sprint.StartDate,
+			ToDate:                  sprint.EndDate,
+			StoredTransitionTickets: storedTransitionTickets,
 		},
 	)
 	if err != nil {
@@ -740,6 +882,22 @@ func (service SprintService) fetchAndUpdateTaskTrackerTask(
 		return nil, err
 	}
 
+	var subtaskKeys map[string][]string
+	if sprint.Retrospective.RollUpSubtasks {
+		var loggedSubtasks []taskTrackerSerializers.Task
+		loggedSubtasks, err = getLoggedSubtasks(taskProviderConfig, tickets, timeTrackerTaskKeys)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, err
+		}
+		tickets = append(tickets, loggedSubtasks...)
+		tickets, subtaskKeys, err = service.rollUpSubtasks(sprint, taskProviderConfig, tickets, mapset.NewSet())
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, err
+		}
+	}
+
 	for _, ticket := range tickets {
 		err = service.addOrUpdateTaskTrackerTask(sprint, ticket, sprint.RetrospectiveID, "")
 		if err != nil {
@@ -748,9 +906,127 @@ func (service SprintService) fetchAndUpdateTaskTrackerTask(
 		}
 		taskTrackerTaskKeySet.Add(ticket.Key)
 	}
+	if err = service.mapRolledUpSubtaskKeys(sprint.RetrospectiveID, subtaskKeys, taskTrackerTaskKeySet); err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+	if err = service.markInTrackerSprintTasks(sprint, taskTrackerTaskKeySet); err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
 	return taskTrackerTaskKeySet, nil
 }
 
+// getStoredTransitionTickets returns the TrackerUniqueIDs of the tickets of the retrospective whose status
+// transitions are stored
+func (service SprintService) getStoredTransitionTickets(retroID uint) (map[string]bool, error) {
+	rows, err := service.DB.Model(&retroModels.TaskStatusTransition{}).
+		Where("task_status_transitions.deleted_at IS NULL").
+		Joins("JOIN tasks ON tasks.id = task_status_transitions.task_id AND tasks.deleted_at IS NULL").
+		Where("tasks.retrospective_id = ?", retroID).
+		Where("tasks.tracker_unique_id <> ''").
+		Select("DISTINCT tasks.tracker_unique_id").
+		Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	storedTransitionTickets := make(map[string]bool)
+	for rows.Next() {
+		var trackerUniqueID string
+		if err = rows.Scan(&trackerUniqueID); err != nil {
+			return nil, err
+		}
+		storedTransitionTickets[trackerUniqueID] = true
+	}
+	return storedTransitionTickets, nil
+}
+
+// markInTrackerSprintTasks marks the sprint tasks of the synced tickets of the tracker sprint, only these are
+// removed from the sprint when their tickets leave the tracker sprint
+func (service SprintService) markInTrackerSprintTasks(sprint retroModels.Sprint, taskTrackerTaskKeySet mapset.Set) error {
+	if taskTrackerTaskKeySet.Cardinality() == 0 {
+		return nil
+	}
+	return service.DB.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Where("sprint_tasks.in_tracker_sprint = false").
+		Where("sprint_tasks.task_id IN (?)", service.DB.Model(&retroModels.TaskKeyMap{}).
+			Where("task_key_maps.deleted_at IS NULL").
+			Scopes(retroModels.TaskKeyMapJoinTask).
+			Where("tasks.retrospective_id = ?", sprint.RetrospectiveID).
+			Where("task_key_maps.key IN (?)", utils.InterfaceSliceToStringSlice(taskTrackerTaskKeySet.ToSlice())).
+			Select("task_key_maps.task_id").
+			QueryExpr()).
+		UpdateColumn("in_tracker_sprint", true).Error
+}
+
+// syncGoalTrackerTasks updates the status of the tickets linked to the pending goals of the sprint, resolving
+// the goals whose tickets are done
+func (service SprintService) syncGoalTrackerTasks(sprint retroModels.Sprint, taskProviderConfig []byte) error {
+	db := service.DB
+	var goals []retroModels.RetrospectiveFeedback
+
+	err := db.Model(&retroModels.RetrospectiveFeedback{}).
+		Where("retrospective_feedbacks.deleted_at IS NULL").
+		Where("retrospective_id = ? AND type = ?", sprint.RetrospectiveID, retroModels.GoalType).
+		Where("tracker_task_key IS NOT NULL").
+		Where("resolved_at IS NULL").
+		Where("added_at <= ?", sprint.EndDate).
+		Find(&goals).Error
+	if err != nil || len(goals) == 0 {
+		return err
+	}
+
+	var ticketKeys []string
+	for _, goal := range goals {
+		ticketKeys = append(ticketKeys, *goal.TrackerTaskKey)
+	}
+	tickets, err := tasktracker.GetTaskList(taskProviderConfig, ticketKeys)
+	if err != nil {
+		return err
+	}
+	ticketStatuses := make(map[string]string)
+	for _, ticket := range tickets {
+		ticketStatuses[ticket.Key] = ticket.Status
+	}
+
+	statusMap, err := tasktracker.GetStatusMapping(sprint.Retrospective.TaskProviderConfig)
+	if err != nil {
+		return err
+	}
+	doneStatuses := mapset.NewSet()
+	for _, status := range statusMap[tasktracker.DoneStatus] {
+		doneStatuses.Add(status)
+	}
+
+	resolved := false
+	for _, goal := range goals {
+		status, ok := ticketStatuses[*goal.TrackerTaskKey]
+		if !ok {
+			continue
+		}
+		updates := map[string]interface{}{"tracker_task_status": status}
+		if doneStatuses.Contains(strings.ToLower(status)) {
+			updates["resolved_at"] = sprint.EndDate
+			resolved = true
+		}
+		err = db.Model(&retroModels.RetrospectiveFeedback{}).
+			Where("id = ?", goal.ID).
+			Updates(updates).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if resolved {
+		return retroModels.UpdateGoalCarryOverCounts(db, sprint.RetrospectiveID)
+	}
+	return nil
+}
+
 // fetchAndUpdateTimeTrackerTask ...
 func (service SprintService) fetchAndUpdateTimeTrackerTask(
 	sprint retroModels.Sprint,
@@ -768,6 +1044,17 @@ func (service SprintService) fetchAndUpdateTimeTrackerTask(
 		return nil, err
 	}
 
+	// The logged subtasks of the sprint tasks were rolled up along with them, the estimates of the ones missed then
+	// are added to their parents
+	var subtaskKeys map[string][]string
+	if sprint.Retrospective.RollUpSubtasks {
+		tickets, subtaskKeys, err = service.rollUpSubtasks(sprint, taskProviderConfig, tickets, taskTrackerTaskKeySet)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, err
+		}
+	}
+
 	timeTrackerTaskKeySet.Clear()
 	for _, ticket := range tickets {
 		err = service.addOrUpdateTaskTrackerTask(sprint, ticket, retroID, "")
@@ -777,6 +1064,10 @@ func (service SprintService) fetchAndUpdateTimeTrackerTask(
 		}
 		timeTrackerTaskKeySet.Add(ticket.Key)
 	}
+	if err = service.mapRolledUpSubtaskKeys(retroID, subtaskKeys, timeTrackerTaskKeySet); err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
 	return timeTrackerTaskKeySet, nil
 }
 
@@ -799,7 +1090,9 @@ func (service SprintService) updateMissingTimeTrackerTask(
 			return err
 		}
 
-		if task != nil {
+		if task != nil && task.ParentKey != "" && sprint.Retrospective.RollUpSubtasks {
+			err = service.addOrUpdateSubtask(sprint, taskProviderConfig, *task, taskKey.(string), taskTrackerTaskKeySet)
+		} else if task != nil {
 			err = service.addOrUpdateTaskTrackerTask(sprint, *task, retroID, taskKey.(string))
 		} else {
 			err = service.insertTimeTrackerTask(sprint.ID, taskKey.(string), retroID)
@@ -822,6 +1115,12 @@ func (service SprintService) updateSprintMemberTimeLog(
 	// Reset existing time_spent
 	err := db.Exec("UPDATE sprint_member_tasks SET time_spent_minutes=0 WHERE sprint_member_id = ?", sprintMemberID).Error
 
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+	// The time logged with the different keys of a task, i.e. the keys of its rolled up subtasks, is added up
+	timeLogs, err = service.mergeTaskTimeLogs(retroID, timeLogs)
 	if err != nil {
 		utils.LogToSentry(err)
 		return err
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gocraft/work"
@@ -12,6 +13,8 @@ import (
 	"github.com/iReflect/reflect-app/apps/retrospective"
 	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
 	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
 	"github.com/iReflect/reflect-app/libs/utils"
 	"github.com/iReflect/reflect-app/workers"
 )
@@ -130,7 +133,43 @@ func (service SprintTaskService) Update(sprintTaskID string,
 		task.Rating = retrospective.Rating(*data.Rating)
 	}
 
-	if err := db.Save(&task).Error; err != nil {
+	estimateChanged := data.Estimate != nil && *data.Estimate != task.Estimate
+
+	tx := db.Begin()
+	if err := tx.Save(&task).Error; err != nil {
+		tx.Rollback()
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to update sprint task")
+	}
+
+	if estimateChanged {
+		intSprintID, _ := strconv.Atoi(sprintID)
+		err = recordScopeChange(tx, retroModels.SprintTaskScopeChange{
+			SprintID:         uint(intSprintID),
+			TaskID:           task.ID,
+			Type:             retroModels.TaskReestimatedScopeChange,
+			Source:           retroModels.UserScopeChangeSource,
+			PreviousEstimate: task.Estimate,
+			Estimate:         *data.Estimate,
+			ChangedByID:      &userID,
+		})
+		if err == nil {
+			err = SprintService{DB: tx}.changeTaskEstimates(tx, task, *data.Estimate)
+		}
+		if err != nil {
+			tx.Rollback()
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to update sprint task")
+		}
+
+		status, err := service.writeBackTask(tx, retroID, &task, taskTrackerSerializers.TaskUpdate{Estimate: data.Estimate})
+		if err != nil {
+			tx.Rollback()
+			return nil, status, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		utils.LogToSentry(err)
 		return nil, http.StatusInternalServerError, errors.New("failed to update sprint task")
 	}
@@ -139,7 +178,7 @@ func (service SprintTaskService) Update(sprintTaskID string,
 }
 
 // Delete ...
-func (service SprintTaskService) Delete(sprintTaskID string, retroID string, sprintID string) (int, error) {
+func (service SprintTaskService) Delete(sprintTaskID string, retroID string, sprintID string, userID uint) (int, error) {
 	tx := service.DB.Begin()
 
 	var sprintTask retroModels.SprintTask
@@ -171,6 +210,26 @@ func (service SprintTaskService) Delete(sprintTaskID string, retroID string, spr
 		return http.StatusInternalServerError, err
 	}
 
+	// log the removal of the task from the sprint.
+	var task retroModels.Task
+	err = tx.Model(&retroModels.Task{}).Where("id = ?", sprintTask.TaskID).First(&task).Error
+	if err != nil {
+		tx.Rollback()
+		return http.StatusInternalServerError, err
+	}
+	err = recordScopeChange(tx, retroModels.SprintTaskScopeChange{
+		SprintID:         sprintTask.SprintID,
+		TaskID:           sprintTask.TaskID,
+		Type:             retroModels.TaskRemovedScopeChange,
+		Source:           retroModels.UserScopeChangeSource,
+		PreviousEstimate: task.Estimate,
+		ChangedByID:      &userID,
+	})
+	if err != nil {
+		tx.Rollback()
+		return http.StatusInternalServerError, err
+	}
+
 	// if no other sprint contains this task then delete task and taskKeyMap.
 	if sprintTasksCount == 1 {
 
@@ -218,18 +277,43 @@ func (service SprintTaskService) MarkDone(
 		return nil, http.StatusInternalServerError, errors.New("failed to mark the issue as done")
 	}
 
-	query := db.Model(&retroModels.SprintTask{}).
+	var trackerTask retroModels.Task
+	err = db.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Scopes(retroModels.TaskJoinST).
+		Where("sprint_tasks.id = ?", sprintTaskID).
+		Where("sprint_tasks.sprint_id = ?", sprintID).
+		Find(&trackerTask).
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("issue not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to mark the issue as done")
+	}
+
+	done := true
+	update := taskTrackerSerializers.TaskUpdate{Done: &done}
+	if resolution := retroModels.Resolution(*data.Resolution); resolution != retroModels.TaskNotDoneResolution {
+		resolutionName := resolution.GetStringValue()
+		update.Resolution = &resolutionName
+	}
+
+	tx := db.Begin()
+	query := tx.Model(&retroModels.SprintTask{}).
 		Where("sprint_tasks.deleted_at IS NULL").
 		Where("id = ?", sprintTaskID).
 		Select("task_id").
 		QueryExpr()
-	err = db.Model(&retroModels.Task{}).
+	err = tx.Model(&retroModels.Task{}).
 		Where("tasks.deleted_at IS NULL").
 		Where("id = (?)", query).
 		Updates(map[string]interface{}{"done_at": gorm.Expr("COALESCE(done_at, ?)", *sprint.EndDate), "resolution": retroModels.Resolution(*data.Resolution)}).
 		Error
 
 	if err != nil {
+		tx.Rollback()
 		if err == gorm.ErrRecordNotFound {
 			return nil, http.StatusNotFound, errors.New("issue not found")
 		}
@@ -237,6 +321,15 @@ func (service SprintTaskService) MarkDone(
 		return nil, http.StatusInternalServerError, errors.New("failed to mark the issue as done")
 	}
 
+	if status, err := service.writeBackTask(tx, retroID, &trackerTask, update); err != nil {
+		tx.Rollback()
+		return nil, status, err
+	}
+	if err = tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to mark the issue as done")
+	}
+
 	service.AssignPointsToSprintTask(sprintTaskID, sprintID)
 
 	return service.Get(sprintTaskID, retroID, sprintID, userID)
@@ -249,12 +342,30 @@ func (service SprintTaskService) MarkUndone(
 	sprintID string,
 	userID uint) (task *retroSerializers.SprintTask, status int, err error) {
 	db := service.DB
-	query := db.Model(&retroModels.SprintTask{}).
+
+	var trackerTask retroModels.Task
+	err = db.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Scopes(retroModels.TaskJoinST).
+		Where("sprint_tasks.id = ?", sprintTaskID).
+		Where("sprint_tasks.sprint_id = ?", sprintID).
+		Find(&trackerTask).
+		Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("issue not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to mark the task as undone")
+	}
+
+	tx := db.Begin()
+	query := tx.Model(&retroModels.SprintTask{}).
 		Where("sprint_tasks.deleted_at IS NULL").
 		Where("id = ?", sprintTaskID).
 		Select("task_id").
 		QueryExpr()
-	err = db.Model(&retroModels.Task{}).
+	err = tx.Model(&retroModels.Task{}).
 		Where("tasks.deleted_at IS NULL").
 		Where("id = (?)", query).
 		Update("done_at", nil).
@@ -262,6 +373,7 @@ func (service SprintTaskService) MarkUndone(
 		Error
 
 	if err != nil {
+		tx.Rollback()
 		if err == gorm.ErrRecordNotFound {
 			return nil, http.StatusNotFound, errors.New("issue not found")
 		}
@@ -269,9 +381,93 @@ func (service SprintTaskService) MarkUndone(
 		return nil, http.StatusInternalServerError, errors.New("failed to mark the task as done")
 	}
 
+	done := false
+	if status, err := service.writeBackTask(tx, retroID, &trackerTask, taskTrackerSerializers.TaskUpdate{Done: &done}); err != nil {
+		tx.Rollback()
+		return nil, status, err
+	}
+	if err = tx.Commit().Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to mark the task as undone")
+	}
+
 	return service.Get(sprintTaskID, retroID, sprintID, userID)
 }
 
+// writeBackTask pushes the update of a task tracker task to the task tracker if the retrospective writes back to it.
+// The update is refused if the ticket has changed in the task tracker since it was last synced or written back,
+// otherwise the tracker's state of the ticket is saved on the task.
+// It is called last in the transaction of the local update, which is to be rolled back when it fails, so that
+// the task tracker is never updated when the local update is not.
+func (service SprintTaskService) writeBackTask(db *gorm.DB, retroID string, task *retroModels.Task,
+	update taskTrackerSerializers.TaskUpdate) (int, error) {
+	if !task.IsTrackerTask {
+		return http.StatusOK, nil
+	}
+
+	var retro retroModels.Retrospective
+	if err := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		Find(&retro).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to get retrospective")
+	}
+
+	if !retro.TrackerWriteBack {
+		// The estimates of the tickets are owned by the task tracker, the next sync would overwrite the change
+		if update.Estimate != nil {
+			return http.StatusBadRequest, errors.New("the estimate of a task tracker issue can only be changed " +
+				"when writing back to the task tracker is enabled")
+		}
+		return http.StatusOK, nil
+	}
+
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(retro.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to update the issue in the task tracker")
+	}
+
+	ticket, err := tasktracker.GetTaskDetails(taskProviderConfig, task.Key)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("failed to get the issue from the task tracker")
+	}
+	if ticket == nil {
+		return http.StatusNotFound, errors.New("issue not found in the task tracker")
+	}
+	if task.TrackerUpdatedAt != nil && ticket.UpdatedAt != nil && ticket.UpdatedAt.After(*task.TrackerUpdatedAt) {
+		return http.StatusConflict, errors.New("the issue has changed in the task tracker since the last sync, " +
+			"please refresh the sprint and retry")
+	}
+
+	ticket, err = tasktracker.UpdateTask(taskProviderConfig, task.Key, update)
+	if err == tasktracker.ErrPartialTaskUpdate {
+		return http.StatusBadGateway, errors.New("the issue was only partially updated in the task tracker, " +
+			"please refresh the sprint and retry")
+	}
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("failed to update the issue in the task tracker")
+	}
+	if ticket == nil {
+		return http.StatusOK, nil
+	}
+
+	task.Status = ticket.Status
+	task.TrackerUpdatedAt = ticket.UpdatedAt
+	if err = db.Model(&retroModels.Task{}).
+		Where("id = ?", task.ID).
+		UpdateColumns(map[string]interface{}{"status": ticket.Status, "tracker_updated_at": ticket.UpdatedAt}).
+		Error; err != nil {
+		utils.LogToSentry(err)
+		return http.StatusInternalServerError, errors.New("failed to update the issue")
+	}
+	return http.StatusOK, nil
+}
+
 // tasksForCurrentAndPrevSprint ...
 func (service SprintTaskService) tasksForCurrentAndPrevSprint(retroID string, sprintID string) *gorm.DB {
 	db := service.DB
@@ -375,6 +571,9 @@ func (service SprintTaskService) tasksWithTimeDetailsForCurrentAndPrevSprint(ret
 						tasks.done_at,
 						tasks.resolution,
             tasks.is_tracker_task,
+            tasks.parent_id,
+            (SELECT parents.key FROM tasks AS parents WHERE parents.id = tasks.parent_id) AS parent_key,
+            tasks.epic_key,
             sprint_tasks.sprint_id,
             SUM(sprint_member_tasks.time_spent_minutes) OVER (PARTITION BY tasks.id)                           AS total_time,
             SUM(sprint_member_tasks.time_spent_minutes) OVER (PARTITION BY tasks.id, sprint_members.sprint_id) AS sprint_time,
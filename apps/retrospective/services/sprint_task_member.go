@@ -42,6 +42,11 @@ func (service SprintTaskMemberService) GetMembers(
 			sprint_members.sprint_id, 
 			sprint_member_tasks.comment,
 			sprint_member_tasks.rating,
+			sprint_member_tasks.planned_points,
+			sprint_member_tasks.points_locked,
+			sprint_member_tasks.points_locked_by_id,
+			sprint_member_tasks.points_lock_reason,
+			sprint_member_tasks.points_locked_at,
 			CASE WHEN (sprint_members.sprint_id = ?) THEN TRUE ELSE FALSE END AS current,
 			SUM(sprint_member_tasks.points_earned) OVER (PARTITION BY sprint_members.member_id) AS total_points,
 			SUM(sprint_member_tasks.points_earned) OVER (PARTITION BY sprint_members.member_id,sprint_members.sprint_id) AS sprint_points,
@@ -156,33 +161,52 @@ func (service SprintTaskMemberService) AddMember(
 	return service.GetMember(sprintMemberTask, sprintMember.MemberID, retroID, sprintID)
 }
 
+// GetSprintMemberTaskID returns the ID of the sprint member task of a sprint member for a sprint task
+func (service SprintTaskMemberService) GetSprintMemberTaskID(sprintTaskID string, sprintMemberID uint) (uint, error) {
+	db := service.DB
+	var sprintMemberTask retroModels.SprintMemberTask
+
+	err := db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Where("sprint_member_id = ?", sprintMemberID).
+		Where("sprint_task_id = ?", sprintTaskID).
+		First(&sprintMemberTask).Error
+	if err != nil {
+		utils.LogToSentry(err)
+		return 0, err
+	}
+	return sprintMemberTask.ID, nil
+}
+
 // UpdateTaskMember ...
 func (service SprintTaskMemberService) UpdateTaskMember(
 	sprintTaskID string,
 	retroID string,
 	sprintID string,
 	smtID string,
-	taskMemberData *retroSerializers.SprintTaskMemberUpdate) (*retroSerializers.TaskMember, int, error) {
+	taskMemberData *retroSerializers.SprintTaskMemberUpdate,
+	userID uint) (*retroSerializers.TaskMember, int, error) {
 	db := service.DB
 
-	sprintMemberTask := retroModels.SprintMemberTask{}
-	err := db.Model(&retroModels.SprintMemberTask{}).
-		Where("sprint_member_tasks.deleted_at IS NULL").
-		Where("sprint_task_id = ?", sprintTaskID).
-		Where("id = ?", smtID).
-		Preload("SprintMember").
-		Find(&sprintMemberTask).Error
-
+	sprintMemberTask, status, err := service.getSprintMemberTask(sprintTaskID, smtID)
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, http.StatusNotFound, errors.New("task member not found")
+		if status == http.StatusInternalServerError {
+			err = errors.New("failed to update task member")
 		}
-		utils.LogToSentry(err)
-		return nil, http.StatusInternalServerError, errors.New("failed to update task member")
+		return nil, status, err
 	}
 
+	// The manually edited points are locked so that the points allocation doesn't overwrite them
 	if taskMemberData.SprintPoints != nil {
 		sprintMemberTask.PointsEarned = *taskMemberData.SprintPoints
+		reason := ""
+		if taskMemberData.PointsLockReason != nil {
+			reason = *taskMemberData.PointsLockReason
+		}
+		sprintMemberTask.Lock(userID, reason)
+	}
+	if taskMemberData.PlannedPoints != nil {
+		sprintMemberTask.PlannedPoints = *taskMemberData.PlannedPoints
 	}
 	if taskMemberData.Rating != nil {
 		sprintMemberTask.Rating = retrospective.Rating(*taskMemberData.Rating)
@@ -200,6 +224,98 @@ func (service SprintTaskMemberService) UpdateTaskMember(
 	return service.GetMember(sprintMemberTask, sprintMemberTask.SprintMember.MemberID, retroID, sprintID)
 }
 
+// LockPoints locks the points of a task member against the points allocation
+func (service SprintTaskMemberService) LockPoints(
+	sprintTaskID string,
+	retroID string,
+	sprintID string,
+	smtID string,
+	reason string,
+	userID uint) (*retroSerializers.TaskMember, int, error) {
+	db := service.DB
+
+	sprintMemberTask, status, err := service.getSprintMemberTask(sprintTaskID, smtID)
+	if err != nil {
+		if status == http.StatusInternalServerError {
+			err = errors.New("failed to lock task member points")
+		}
+		return nil, status, err
+	}
+
+	sprintMemberTask.Lock(userID, reason)
+	if err = db.Set("gorm:save_associations", false).Save(&sprintMemberTask).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to lock task member points")
+	}
+	return service.GetMember(sprintMemberTask, sprintMemberTask.SprintMember.MemberID, retroID, sprintID)
+}
+
+// UnlockPoints unlocks the points of a task member, the points of the task are re-allocated right away in an
+// active sprint so that the unlocked points are returned re-allocated
+func (service SprintTaskMemberService) UnlockPoints(
+	sprintTaskID string,
+	retroID string,
+	sprintID string,
+	smtID string) (*retroSerializers.TaskMember, int, error) {
+	db := service.DB
+
+	sprintMemberTask, status, err := service.getSprintMemberTask(sprintTaskID, smtID)
+	if err != nil {
+		if status == http.StatusInternalServerError {
+			err = errors.New("failed to unlock task member points")
+		}
+		return nil, status, err
+	}
+
+	sprintMemberTask.Unlock()
+	if err = db.Set("gorm:save_associations", false).Save(&sprintMemberTask).Error; err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to unlock task member points")
+	}
+
+	sprintService := SprintService{DB: db}
+	sprint, status, err := sprintService.getPointsAllocationSprint(sprintID, false)
+	if err != nil {
+		return nil, status, err
+	}
+	if sprint.Status == retroModels.ActiveSprint {
+		allocation, err := sprintService.computePointsAllocation(*sprint, &sprintTaskID, false)
+		if err == nil {
+			err = sprintService.applyPointsAllocation(allocation, false)
+		}
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, http.StatusInternalServerError, errors.New("failed to re-allocate task points")
+		}
+	}
+
+	return service.GetMember(sprintMemberTask, sprintMemberTask.SprintMember.MemberID, retroID, sprintID)
+}
+
+// getSprintMemberTask returns the sprint member task of a sprint task along with its sprint member
+func (service SprintTaskMemberService) getSprintMemberTask(
+	sprintTaskID string,
+	smtID string) (retroModels.SprintMemberTask, int, error) {
+	db := service.DB
+
+	sprintMemberTask := retroModels.SprintMemberTask{}
+	err := db.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Where("sprint_task_id = ?", sprintTaskID).
+		Where("id = ?", smtID).
+		Preload("SprintMember").
+		Find(&sprintMemberTask).Error
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return sprintMemberTask, http.StatusNotFound, errors.New("task member not found")
+		}
+		utils.LogToSentry(err)
+		return sprintMemberTask, http.StatusInternalServerError, err
+	}
+	return sprintMemberTask, http.StatusOK, nil
+}
+
 // smtForCurrentAndPrevSprint ...
 func (service SprintTaskMemberService) smtForCurrentAndPrevSprint(sprintTaskID string, retroID string, sprintID string) *gorm.DB {
 	db := service.DB
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/deckarep/golang-set"
+	"github.com/jinzhu/gorm"
+
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	"github.com/iReflect/reflect-app/apps/tasktracker"
+	taskTrackerSerializers "github.com/iReflect/reflect-app/apps/tasktracker/serializers"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// GetFlowMetrics returns the flow metrics of a sprint task along with its status transitions
+func (service SprintTaskService) GetFlowMetrics(sprintTaskID string, retroID string,
+	sprintID string) (*retroSerializers.TaskFlowMetrics, int, error) {
+	db := service.DB
+	var task retroModels.Task
+	if err := db.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Scopes(retroModels.TaskJoinST).
+		Where("sprint_tasks.id = ?", sprintTaskID).
+		Where("sprint_tasks.sprint_id = ?", sprintID).
+		Where("tasks.retrospective_id = ?", retroID).
+		Select("tasks.*").
+		Preload("Retrospective").
+		Preload("StatusTransitions").
+		First(&task).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("task not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get task flow metrics")
+	}
+
+	doneStatuses, err := getDoneStatuses(task.Retrospective)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get task flow metrics")
+	}
+
+	metrics := getTaskFlowMetrics(task, doneStatuses, time.Time{}, time.Now())
+	metrics.Transitions = []retroSerializers.TaskStatusTransition{}
+	for _, transition := range task.StatusTransitions {
+		metrics.Transitions = append(metrics.Transitions, retroSerializers.TaskStatusTransition{
+			FromStatus:     transition.FromStatus,
+			ToStatus:       transition.ToStatus,
+			TransitionedAt: transition.TransitionedAt,
+			TransitionedBy: transition.TransitionedBy,
+		})
+	}
+	sort.SliceStable(metrics.Transitions, func(i, j int) bool {
+		return metrics.Transitions[i].TransitionedAt.Before(metrics.Transitions[j].TransitionedAt)
+	})
+	return &metrics, http.StatusOK, nil
+}
+
+// GetSprintFlowMetrics returns the flow metrics of the tasks of the sprint during the sprint
+func (service SprintService) GetSprintFlowMetrics(sprintID string) (*retroSerializers.FlowMetricsSerializer, int, error) {
+	db := service.DB
+	var sprint retroModels.Sprint
+	if err := db.Model(&retroModels.Sprint{}).
+		Where("sprints.deleted_at IS NULL").
+		Where("id = ?", sprintID).
+		Preload("Retrospective").
+		First(&sprint).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("sprint not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint flow metrics")
+	}
+	if sprint.StartDate == nil || sprint.EndDate == nil {
+		return nil, http.StatusBadRequest, errors.New("sprint has no start/end date")
+	}
+
+	doneStatuses, err := getDoneStatuses(sprint.Retrospective)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint flow metrics")
+	}
+
+	to := *sprint.EndDate
+	if now := time.Now(); now.Before(to) {
+		to = now
+	}
+	taskIDs := db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("sprint_tasks.sprint_id = ?", sprint.ID).
+		Select("sprint_tasks.task_id").
+		QueryExpr()
+	metrics, err := getFlowMetrics(db, taskIDs, doneStatuses, *sprint.StartDate, to)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get sprint flow metrics")
+	}
+	return metrics, http.StatusOK, nil
+}
+
+// GetFlowMetrics returns the flow metrics of the tasks of the sprints of the retrospective
+func (service RetrospectiveService) GetFlowMetrics(retroID string) (*retroSerializers.FlowMetricsSerializer, int, error) {
+	db := service.DB
+	var retro retroModels.Retrospective
+	if err := db.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		First(&retro).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get retrospective flow metrics")
+	}
+
+	doneStatuses, err := getDoneStatuses(retro)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get retrospective flow metrics")
+	}
+
+	taskIDs := db.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Scopes(retroModels.STJoinSprint).
+		Where("sprints.retrospective_id = ?", retro.ID).
+		Scopes(retroModels.NotDeletedSprint).
+		Select("sprint_tasks.task_id").
+		QueryExpr()
+	metrics, err := getFlowMetrics(db, taskIDs, doneStatuses, time.Time{}, time.Now())
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get retrospective flow metrics")
+	}
+	return metrics, http.StatusOK, nil
+}
+
+// addTaskStatusTransitions stores the status transitions of a task which are not stored yet
+func addTaskStatusTransitions(tx *gorm.DB, taskID uint, transitions []taskTrackerSerializers.TaskTransition) error {
+	for _, transition := range transitions {
+		err := tx.Where(retroModels.TaskStatusTransition{
+			TaskID:         taskID,
+			ToStatus:       transition.ToStatus,
+			TransitionedAt: transition.TransitionedAt,
+		}).
+			Where("task_status_transitions.deleted_at IS NULL").
+			Attrs(retroModels.TaskStatusTransition{
+				FromStatus:     transition.FromStatus,
+				TransitionedBy: transition.TransitionedBy,
+			}).
+			FirstOrCreate(&retroModels.TaskStatusTransition{}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getDoneStatuses returns the lower cased done statuses of the task tracker of the retrospective
+func getDoneStatuses(retro retroModels.Retrospective) (mapset.Set, error) {
+	statusMap, err := tasktracker.GetStatusMapping(retro.TaskProviderConfig)
+	if err != nil {
+		return nil, err
+	}
+	doneStatuses := mapset.NewSet()
+	for _, status := range statusMap[tasktracker.DoneStatus] {
+		doneStatuses.Add(strings.ToLower(status))
+	}
+	return doneStatuses, nil
+}
+
+// getFlowMetrics returns the flow metrics of the tasks with status transitions among the given ones, the time in
+// status is counted between the from and to times and only the tasks done between them are averaged
+func getFlowMetrics(db *gorm.DB, taskIDs interface{}, doneStatuses mapset.Set,
+	from time.Time, to time.Time) (*retroSerializers.FlowMetricsSerializer, error) {
+	var tasks []retroModels.Task
+	err := db.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Where("tasks.id IN (?)", taskIDs).
+		Where("EXISTS (SELECT 1 FROM task_status_transitions WHERE task_status_transitions.task_id = tasks.id " +
+			"AND task_status_transitions.deleted_at IS NULL)").
+		Preload("StatusTransitions").
+		Order("tasks.id").
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &retroSerializers.FlowMetricsSerializer{
+		Tasks:        []retroSerializers.TaskFlowMetrics{},
+		TimeInStatus: make(map[string]float64),
+	}
+	var totalCycleTime, totalLeadTime float64
+	for _, task := range tasks {
+		taskMetrics := getTaskFlowMetrics(task, doneStatuses, from, to)
+		metrics.Tasks = append(metrics.Tasks, taskMetrics)
+		metrics.TaskCount++
+		for status, hours := range taskMetrics.TimeInStatus {
+			metrics.TimeInStatus[status] = roundHours(metrics.TimeInStatus[status] + hours)
+		}
+
+		if taskMetrics.DoneAt == nil || taskMetrics.DoneAt.Before(from) || taskMetrics.DoneAt.After(to) {
+			continue
+		}
+		metrics.DoneCount++
+		if taskMetrics.CycleTime != nil {
+			totalCycleTime += *taskMetrics.CycleTime
+		}
+		if taskMetrics.LeadTime != nil {
+			totalLeadTime += *taskMetrics.LeadTime
+		}
+	}
+
+	if metrics.DoneCount > 0 {
+		averageCycleTime := roundHours(totalCycleTime / float64(metrics.DoneCount))
+		averageLeadTime := roundHours(totalLeadTime / float64(metrics.DoneCount))
+		metrics.AverageCycleTime = &averageCycleTime
+		metrics.AverageLeadTime = &averageLeadTime
+	}
+	return metrics, nil
+}
+
+// getTaskFlowMetrics computes the flow metrics of a task from its status transitions, the time in status is
+// counted between the from and to times. The task is done since it last moved into the done statuses.
+func getTaskFlowMetrics(task retroModels.Task, doneStatuses mapset.Set,
+	from time.Time, to time.Time) retroSerializers.TaskFlowMetrics {
+	transitions := append([]retroModels.TaskStatusTransition{}, task.StatusTransitions...)
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].TransitionedAt.Before(transitions[j].TransitionedAt)
+	})
+
+	metrics := retroSerializers.TaskFlowMetrics{
+		TaskID:       task.ID,
+		Key:          task.Key,
+		Summary:      task.Summary,
+		Status:       task.Status,
+		CreatedAt:    task.CreatedAt,
+		TimeInStatus: make(map[string]float64),
+	}
+	if task.TrackerCreatedAt != nil {
+		metrics.CreatedAt = *task.TrackerCreatedAt
+	}
+	if len(transitions) == 0 {
+		return metrics
+	}
+	isDone := func(status string) bool {
+		return doneStatuses.Contains(strings.ToLower(status))
+	}
+
+	addTimeInStatus := func(status string, start time.Time, end time.Time) {
+		if status == "" || isDone(status) {
+			return
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			metrics.TimeInStatus[status] = roundHours(metrics.TimeInStatus[status] + end.Sub(start).Hours())
+		}
+	}
+	addTimeInStatus(transitions[0].FromStatus, metrics.CreatedAt, transitions[0].TransitionedAt)
+	for index, transition := range transitions {
+		end := to
+		if index+1 < len(transitions) {
+			end = transitions[index+1].TransitionedAt
+		}
+		addTimeInStatus(transition.ToStatus, transition.TransitionedAt, end)
+	}
+
+	metrics.StartedAt = &transitions[0].TransitionedAt
+	for index := len(transitions) - 1; index >= 0 && isDone(transitions[index].ToStatus); index-- {
+		metrics.DoneAt = &transitions[index].TransitionedAt
+	}
+	if metrics.DoneAt != nil {
+		cycleTime := roundHours(metrics.DoneAt.Sub(*metrics.StartedAt).Hours())
+		leadTime := roundHours(metrics.DoneAt.Sub(metrics.CreatedAt).Hours())
+		metrics.CycleTime = &cycleTime
+		metrics.LeadTime = &leadTime
+	}
+	return metrics
+}
+
+// roundHours rounds the hours to two decimals
+func roundHours(hours float64) float64 {
+	return math.Floor(hours*100+0.5) / 100
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/apps/retrospective"
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
+)
+
+// reconcileTasks merges the duplicate tasks of the retrospective, created when the key of a ticket changed, see
+// ReconcileTaskKeys. The stale keys are removed first so that the merges do not carry them over.
+func (service SprintService) reconcileTasks(retroID uint) error {
+	db := service.DB
+	var tasks []retroModels.Task
+	err := db.Model(&retroModels.Task{}).
+		Where("tasks.deleted_at IS NULL").
+		Where("retrospective_id = ?", retroID).
+		Find(&tasks).Error
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Model(&retroModels.TaskKeyMap{}).
+		Where("task_key_maps.deleted_at IS NULL").
+		Scopes(retroModels.TaskKeyMapJoinTask).
+		Where("tasks.retrospective_id = ?", retroID).
+		Select("task_key_maps.task_id, task_key_maps.key").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	taskKeys := make(map[uint][]string)
+	for rows.Next() {
+		var taskID uint
+		var key string
+		if err := rows.Scan(&taskID, &key); err != nil {
+			return err
+		}
+		taskKeys[taskID] = append(taskKeys[taskID], key)
+	}
+
+	var identities []retroModels.TaskIdentity
+	for _, task := range tasks {
+		identities = append(identities, retroModels.TaskIdentity{
+			ID:               task.ID,
+			Key:              task.Key,
+			TrackerUniqueID:  task.TrackerUniqueID,
+			IsTrackerTask:    task.IsTrackerTask,
+			TrackerUpdatedAt: task.TrackerUpdatedAt,
+			Keys:             taskKeys[task.ID],
+		})
+	}
+	reconciliation := retroModels.ReconcileTaskKeys(identities)
+	if len(reconciliation.Merges) == 0 && len(reconciliation.StaleKeys) == 0 {
+		return nil
+	}
+
+	tx := db.Begin()
+	for _, staleKey := range reconciliation.StaleKeys {
+		err = tx.Where("task_id = ? AND key = ?", staleKey.TaskID, staleKey.Key).
+			Delete(&retroModels.TaskKeyMap{}).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	for _, merge := range reconciliation.Merges {
+		for _, duplicateID := range merge.DuplicateIDs {
+			if err = mergeTask(tx, merge.TaskID, duplicateID); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+	return tx.Commit().Error
+}
+
+// mergeTask moves the sprint tasks, the keys, the status transitions, the scope changes and the subtasks of the
+// duplicate task to the task and deletes the duplicate task
+func mergeTask(tx *gorm.DB, taskID uint, duplicateID uint) error {
+	var task, duplicate retroModels.Task
+	if err := tx.Where("id = ?", taskID).First(&task).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("id = ?", duplicateID).First(&duplicate).Error; err != nil {
+		return err
+	}
+
+	var duplicateSprintTasks []retroModels.SprintTask
+	err := tx.Model(&retroModels.SprintTask{}).
+		Where("sprint_tasks.deleted_at IS NULL").
+		Where("task_id = ?", duplicateID).
+		Find(&duplicateSprintTasks).Error
+	if err != nil {
+		return err
+	}
+	for _, duplicateSprintTask := range duplicateSprintTasks {
+		var sprintTask retroModels.SprintTask
+		err = tx.Model(&retroModels.SprintTask{}).
+			Where("sprint_tasks.deleted_at IS NULL").
+			Where("sprint_id = ? AND task_id = ?", duplicateSprintTask.SprintID, taskID).
+			First(&sprintTask).Error
+		if err == gorm.ErrRecordNotFound {
+			// The task was not a part of the sprint, the sprint task of the duplicate becomes its sprint task
+			err = tx.Model(&retroModels.SprintTask{}).
+				Where("id = ?", duplicateSprintTask.ID).
+				UpdateColumn("task_id", taskID).Error
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err = mergeSprintTask(tx, sprintTask, duplicateSprintTask); err != nil {
+			return err
+		}
+	}
+
+	// The keys the task already had, even the deleted ones, are restored rather than moved
+	err = tx.Exec(`UPDATE task_key_maps SET task_id = ?, updated_at = NOW()
+		WHERE task_id = ? AND deleted_at IS NULL
+			AND key NOT IN (SELECT key FROM task_key_maps WHERE task_id = ?)`, taskID, duplicateID, taskID).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Exec(`UPDATE task_key_maps SET deleted_at = NULL, updated_at = NOW()
+		WHERE task_id = ? AND deleted_at IS NOT NULL
+			AND key IN (SELECT key FROM task_key_maps WHERE task_id = ? AND deleted_at IS NULL)`,
+		taskID, duplicateID).Error
+	if err != nil {
+		return err
+	}
+	if err = tx.Where("task_id = ?", duplicateID).Delete(&retroModels.TaskKeyMap{}).Error; err != nil {
+		return err
+	}
+
+	err = tx.Exec(`UPDATE task_status_transitions SET task_id = ?, updated_at = NOW()
+		WHERE task_id = ? AND deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM task_status_transitions AS transitions
+				WHERE transitions.task_id = ? AND transitions.deleted_at IS NULL
+					AND transitions.transitioned_at = task_status_transitions.transitioned_at
+					AND transitions.to_status = task_status_transitions.to_status)`,
+		taskID, duplicateID, taskID).Error
+	if err != nil {
+		return err
+	}
+	if err = tx.Where("task_id = ?", duplicateID).Delete(&retroModels.TaskStatusTransition{}).Error; err != nil {
+		return err
+	}
+
+	err = tx.Model(&retroModels.SprintTaskScopeChange{}).
+		Where("task_id = ?", duplicateID).
+		UpdateColumn("task_id", taskID).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Model(&retroModels.Task{}).
+		Where("parent_id = ?", duplicateID).
+		UpdateColumn("parent_id", taskID).Error
+	if err != nil {
+		return err
+	}
+
+	if task.Rating == retrospective.DecentRating && duplicate.Rating != task.Rating {
+		err = tx.Model(&retroModels.Task{}).
+			Where("id = ?", taskID).
+			UpdateColumn("rating", duplicate.Rating).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Where("id = ?", duplicateID).Delete(&retroModels.Task{}).Error
+}
+
+// mergeSprintTask moves the sprint member tasks of the duplicate sprint task to the sprint task of the same
+// sprint, merging the ones of the same sprint member, and deletes the duplicate sprint task
+func mergeSprintTask(tx *gorm.DB, sprintTask retroModels.SprintTask, duplicate retroModels.SprintTask) error {
+	var duplicateSMTs []retroModels.SprintMemberTask
+	err := tx.Model(&retroModels.SprintMemberTask{}).
+		Where("sprint_member_tasks.deleted_at IS NULL").
+		Where("sprint_task_id = ?", duplicate.ID).
+		Find(&duplicateSMTs).Error
+	if err != nil {
+		return err
+	}
+
+	for _, duplicateSMT := range duplicateSMTs {
+		var smt retroModels.SprintMemberTask
+		err = tx.Model(&retroModels.SprintMemberTask{}).
+			Where("sprint_member_tasks.deleted_at IS NULL").
+			Where("sprint_task_id = ? AND sprint_member_id = ?", sprintTask.ID, duplicateSMT.SprintMemberID).
+			First(&smt).Error
+		if err == gorm.ErrRecordNotFound {
+			err = tx.Model(&retroModels.SprintMemberTask{}).
+				Where("id = ?", duplicateSMT.ID).
+				UpdateColumn("sprint_task_id", sprintTask.ID).Error
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		merged := retroModels.MergeSprintMemberTasks(smt, duplicateSMT)
+		err = tx.Model(&retroModels.SprintMemberTask{}).
+			Where("id = ?", smt.ID).
+			UpdateColumns(map[string]interface{}{
+				"time_spent_minutes":  merged.TimeSpentMinutes,
+				"points_earned":       merged.PointsEarned,
+				"points_assigned":     merged.PointsAssigned,
+				"planned_points":      merged.PlannedPoints,
+				"rating":              merged.Rating,
+				"comment":             merged.Comment,
+				"points_locked":       merged.PointsLocked,
+				"points_locked_by_id": merged.PointsLockedByID,
+				"points_lock_reason":  merged.PointsLockReason,
+				"points_locked_at":    merged.PointsLockedAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+		if err = tx.Where("id = ?", duplicateSMT.ID).Delete(&retroModels.SprintMemberTask{}).Error; err != nil {
+			return err
+		}
+	}
+
+	if sprintTask.PlannedEstimate == nil && duplicate.PlannedEstimate != nil {
+		err = tx.Model(&retroModels.SprintTask{}).
+			Where("id = ?", sprintTask.ID).
+			UpdateColumn("planned_estimate", duplicate.PlannedEstimate).Error
+		if err != nil {
+			return err
+		}
+	}
+	if !sprintTask.InTrackerSprint && duplicate.InTrackerSprint {
+		err = tx.Model(&retroModels.SprintTask{}).
+			Where("id = ?", sprintTask.ID).
+			UpdateColumn("in_tracker_sprint", true).Error
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Where("id = ?", duplicate.ID).Delete(&retroModels.SprintTask{}).Error
+}
@@ -3,13 +3,20 @@ package services
 import (
 	"github.com/jinzhu/gorm"
 
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
 	trailSerializer "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	userModels "github.com/iReflect/reflect-app/apps/user/models"
 	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
 )
 
 // TrailService ...
@@ -17,8 +24,134 @@ type TrailService struct {
 	DB *gorm.DB
 }
 
-// Add ...
-func (service TrailService) Add(action constants.ActionType, actionItem constants.ActionItemType, actionItemID string, actionByID uint) {
+// trailSnapshotQuery describes how to fetch the audited fields of an action item
+type trailSnapshotQuery struct {
+	table   string
+	joins   string
+	columns []string
+}
+
+// trailSnapshotQueries has the audited fields of each action item type, secrets like the task provider
+// credentials must never be a part of them
+var trailSnapshotQueries = map[constants.ActionItemType]trailSnapshotQuery{
+	constants.Retrospective: {
+		table: "retrospectives",
+		columns: []string{"title", "project_name", "team_id", "story_point_per_week", "time_provider_name",
+			"anonymous_feedback", "template_id", "tracker_write_back",
+			"points_allocation_strategy", "reviewer_points_weight", "sprint_length_days", "sprint_start_weekday",
+			"sprint_title_pattern", "roll_up_subtasks"},
+	},
+	constants.Sprint: {
+		table:   "sprints",
+		columns: []string{"title", "sprint_id", "status", "start_date", "end_date"},
+	},
+	constants.SprintMember: {
+		table: "sprint_members",
+		columns: []string{"member_id", "allocation_percent", "expectation_percent", "vacations",
+			"undated_vacations", "rating", "comment"},
+	},
+	constants.SprintTask: {
+		table: "sprint_tasks",
+		joins: "JOIN tasks ON sprint_tasks.task_id = tasks.id",
+		columns: []string{"tasks.key", "tasks.estimate", "tasks.rating", "tasks.resolution",
+			"tasks.done_at", "tasks.epic_key", "sprint_tasks.planned_estimate"},
+	},
+	constants.SprintMemberTask: {
+		table: "sprint_member_tasks",
+		columns: []string{"sprint_member_id", "role", "time_spent_minutes", "points_assigned", "points_earned",
+			"planned_points", "rating", "comment", "points_locked", "points_locked_by_id", "points_lock_reason"},
+	},
+	constants.RetrospectiveFeedback: {
+		table: "retrospective_feedbacks",
+		columns: []string{"type", "sub_type", "scope", "text", "assignee_id", "expected_at", "resolved_at",
+			"added_at", "anonymous", "progress", "tracker_task_key",
+			"tracker_task_status"},
+	},
+}
+
+// Snapshot returns the current values of the audited fields of an action item, it is meant to be taken
+// before changing the item and passed on to Add
+func (service TrailService) Snapshot(actionItem constants.ActionItemType, actionItemID string) map[string]interface{} {
+	db := service.DB
+	query, exists := trailSnapshotQueries[actionItem]
+	if !exists {
+		return nil
+	}
+
+	snapshotQuery := db.Table(query.table)
+	if query.joins != "" {
+		snapshotQuery = snapshotQuery.Joins(query.joins)
+	}
+	rows, err := snapshotQuery.
+		Where(query.table+".deleted_at IS NULL").
+		Where(query.table+".id = ?", actionItemID).
+		Select(strings.Join(query.columns, ", ")).
+		Rows()
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	values := make([]interface{}, len(columns))
+	valuePointers := make([]interface{}, len(columns))
+	for index := range values {
+		valuePointers[index] = &values[index]
+	}
+	if err = rows.Scan(valuePointers...); err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+
+	snapshot := make(map[string]interface{})
+	for index, column := range columns {
+		if value, isBytes := values[index].([]byte); isBytes {
+			snapshot[column] = string(value)
+		} else {
+			snapshot[column] = values[index]
+		}
+	}
+	return snapshot
+}
+
+// getTrailChanges returns the before/after values of the fields that differ between the two snapshots
+func getTrailChanges(before map[string]interface{}, after map[string]interface{}) map[string]trailSerializer.TrailChange {
+	changes := make(map[string]trailSerializer.TrailChange)
+	for field, value := range before {
+		changes[field] = trailSerializer.TrailChange{Before: value, After: after[field]}
+	}
+	for field, value := range after {
+		if _, exists := before[field]; !exists {
+			changes[field] = trailSerializer.TrailChange{Before: nil, After: value}
+		}
+	}
+
+	for field, change := range changes {
+		beforeValue, _ := json.Marshal(change.Before)
+		afterValue, _ := json.Marshal(change.After)
+		if string(beforeValue) == string(afterValue) {
+			delete(changes, field)
+		}
+	}
+	return changes
+}
+
+// Add a trail of the action, along with the changes made to the action item since the given snapshot.
+// before is nil for the newly added items.
+func (service TrailService) Add(
+	action constants.ActionType,
+	actionItem constants.ActionItemType,
+	actionItemID string,
+	actionByID uint,
+	before map[string]interface{}) {
 	db := service.DB
 	trail := new(retroModels.Trail)
 
@@ -31,64 +164,145 @@ func (service TrailService) Add(action constants.ActionType, actionItem constant
 	trail.ActionItemID = uint(intID)
 	trail.ActionByID = actionByID
 
+	after := service.Snapshot(actionItem, actionItemID)
+	if changes := getTrailChanges(before, after); len(changes) > 0 {
+		if trail.Changes, err = json.Marshal(changes); err != nil {
+			utils.LogToSentry(err)
+		}
+	}
+
 	db.Create(&trail)
 	return
 }
 
-// GetTrails method to get history of trails for a particular sprint
-func (service TrailService) GetTrails(sprintID uint, retroID string) (trails *trailSerializer.TrailSerializer, status int, err error) {
+// filterTrails applies the scope and the filters, except the pagination, on the trails
+func (service TrailService) filterTrails(filter trailSerializer.TrailFilter) *gorm.DB {
 	db := service.DB
-	trails = new(trailSerializer.TrailSerializer)
-
-	retroTrail := db.Model(&retroModels.Trail{}).
-		Where("trails.action_item = ?", constants.ActionItemTypeMap[constants.Retrospective]).
-		Where("trails.action_item_id = ?", retroID).
-		QueryExpr()
-
-	sprintTrail := db.Model(&retroModels.Trail{}).
-		Where("trails.action_item = ?", constants.ActionItemTypeMap[constants.Sprint]).
-		Where("trails.action_item_id = ?", sprintID).
-		QueryExpr()
-
-	sprintMemberTrail := db.Model(&retroModels.Trail{}).
-		Scopes(retroModels.TrailJoinSM).
-		Where("sprint_members.sprint_id = ?", sprintID).
-		Where("trails.action_item = ?", constants.ActionItemTypeMap[constants.SprintMember]).
-		QueryExpr()
-
-	sprintTaskTrail := db.Model(&retroModels.Trail{}).
-		Scopes(retroModels.TrailJoinST).
-		Where("sprint_tasks.sprint_id = ?", sprintID).
-		Where("trails.action_item = ?", constants.ActionItemTypeMap[constants.SprintTask]).
-		QueryExpr()
-
-	sprintMemberTaskTrail := db.Model(&retroModels.Trail{}).
-		Scopes(retroModels.TrailJoinSMT, retroModels.SMTJoinST).
-		Where("trails.action_item = ?", constants.ActionItemTypeMap[constants.SprintMemberTask]).
-		Where("sprint_tasks.sprint_id = ?", sprintID).
-		QueryExpr()
-
-	retroFeedbackTrail := db.Model(&retroModels.Trail{}).
-		Scopes(retroModels.TrailJoinFeedback).
-		Where("trails.action_item = ?", constants.ActionItemTypeMap[constants.RetrospectiveFeedback]).
-		Where("retrospective_feedbacks.retrospective_id = ?", retroID).
-		QueryExpr()
-
-	err = db.Raw(
-		`SELECT * FROM (?) AS sprint_trails
-		UNION SELECT * FROM (?) AS retro_trails
-		UNION SELECT * FROM (?) AS sprint_member_trails
-		UNION SELECT * FROM (?) AS sprint_task_trails
-		UNION SELECT * FROM (?) AS sprint_member_task_trails
-		UNION SELECT * FROM (?) AS retro_feedback_trails`,
-		retroTrail, sprintTrail, sprintMemberTrail, sprintTaskTrail, sprintMemberTaskTrail, retroFeedbackTrail).
+	query := db.Model(&retroModels.Trail{}).
+		Where("trails.deleted_at IS NULL").
+		Scopes(retroModels.TrailJoinActionItems)
+
+	switch {
+	case filter.SprintID != 0:
+		query = query.Where("("+retroModels.TrailSprintID+" = ? OR ("+retroModels.TrailSprintID+" IS NULL AND "+
+			retroModels.TrailRetrospectiveID+" = ?))", filter.SprintID, filter.RetrospectiveID)
+	case filter.RetrospectiveID != 0:
+		query = query.Where(retroModels.TrailRetrospectiveID+" = ?", filter.RetrospectiveID)
+	case filter.TeamID != 0:
+		query = query.Where("trail_retrospectives.team_id = ?", filter.TeamID)
+	}
+
+	// Actions and action items can be given either by their keys or by their values
+	if filter.Action != "" {
+		action, exists := constants.ActionTypeMap[constants.ActionType(filter.Action)]
+		if !exists {
+			action = filter.Action
+		}
+		query = query.Where("trails.action = ?", action)
+	}
+	if filter.ActionItem != "" {
+		actionItem, exists := constants.ActionItemTypeMap[constants.ActionItemType(filter.ActionItem)]
+		if !exists {
+			actionItem = filter.ActionItem
+		}
+		query = query.Where("trails.action_item = ?", actionItem)
+	}
+	if filter.ActionByID != 0 {
+		query = query.Where("trails.action_by_id = ?", filter.ActionByID).
+			Where("trail_feedbacks.anonymous IS NOT TRUE")
+	}
+	if filter.From != nil {
+		query = query.Where("trails.created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("trails.created_at < ?", *filter.To)
+	}
+	return query
+}
+
+// GetTrails returns a page of the trails, latest first, matching the given filter
+func (service TrailService) GetTrails(
+	filter trailSerializer.TrailFilter) (trails *trailSerializer.TrailSerializer, status int, err error) {
+	trails = &trailSerializer.TrailSerializer{Trails: []trailSerializer.Trail{}}
+
+	query := service.filterTrails(filter)
+	if filter.After != 0 {
+		query = query.Where("trails.id < ?", filter.After)
+	}
+
+	err = query.
+		Select("trails.*, " + retroModels.TrailSprintID + " AS sprint_id, " +
+			retroModels.TrailRetrospectiveID + " AS retrospective_id, " +
+			"COALESCE(trail_feedbacks.anonymous, FALSE) AS anonymous").
 		Preload("ActionBy").
-		Order("created_at DESC").
+		Order("trails.id DESC").
+		Limit(filter.Count).
 		Find(&trails.Trails).Error
 
 	if err != nil {
-		return nil, http.StatusInternalServerError, errors.New("failed to get the sprint trails")
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the trails")
+	}
+	// The users acting on the anonymous feedbacks are hidden as they are most likely their authors
+	for index := range trails.Trails {
+		if trails.Trails[index].Anonymous {
+			trails.Trails[index].ActionByID = 0
+			trails.Trails[index].ActionBy = userModels.User{}
+		}
+	}
+	if len(trails.Trails) == filter.Count {
+		trails.Next = &trails.Trails[len(trails.Trails)-1].ID
 	}
 	return trails, http.StatusOK, nil
+}
+
+// ExportTrails returns all the trails matching the given filter as a csv file
+func (service TrailService) ExportTrails(filter trailSerializer.TrailFilter) ([]byte, int, error) {
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+
+	writer.Write([]string{"ID", "Date", "Action", "Action Item", "Action Item ID", "Retrospective ID",
+		"Sprint ID", "Action By", "Action By Email", "Changes"})
 
+	// Trails are fetched in batches to keep the memory in check for the large exports
+	filter.Count = 500
+	filter.After = 0
+	for {
+		trails, status, err := service.GetTrails(filter)
+		if err != nil {
+			return nil, status, err
+		}
+		for _, trail := range trails.Trails {
+			writer.Write([]string{
+				strconv.Itoa(int(trail.ID)),
+				trail.CreatedAt.Format(time.RFC3339),
+				trail.Action,
+				trail.ActionItem,
+				strconv.Itoa(int(trail.ActionItemID)),
+				formatOptionalID(trail.RetrospectiveID),
+				formatOptionalID(trail.SprintID),
+				trail.ActionBy.DisplayName(),
+				trail.ActionBy.Email,
+				string(trail.Changes),
+			})
+		}
+		if trails.Next == nil {
+			break
+		}
+		filter.After = *trails.Next
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to export the trails")
+	}
+	return buffer.Bytes(), http.StatusOK, nil
+}
+
+func formatOptionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*id))
 }
@@ -0,0 +1,49 @@
+package tasktracker
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// secretCredentials are the credentials encrypted with the encryption key of the server
+var secretCredentials = []string{"password", "apiToken"}
+
+// RemoveSecretCredentials removes the secret credentials of the task providers from the config, the remaining
+// config can be shared with another server which does not have the encryption key
+func RemoveSecretCredentials(config []byte) ([]byte, error) {
+	var configList []map[string]interface{}
+	if err := json.Unmarshal(config, &configList); err != nil {
+		return nil, err
+	}
+	for _, taskProviderConfig := range configList {
+		providerData, _ := taskProviderConfig["data"].(map[string]interface{})
+		credentials, _ := providerData["credentials"].(map[string]interface{})
+		for _, secret := range secretCredentials {
+			delete(credentials, secret)
+		}
+	}
+	return json.Marshal(configList)
+}
+
+// SetCredentials sets the given decrypted credentials of the task providers, in the order of the task providers
+// of the config, and returns the config to validate and encrypt
+func SetCredentials(config []byte, credentialsList []map[string]interface{}) ([]map[string]interface{}, error) {
+	var configList []map[string]interface{}
+	if err := json.Unmarshal(config, &configList); err != nil {
+		return nil, err
+	}
+	if len(credentialsList) != len(configList) {
+		return nil, errors.New("credentials are required for each task provider")
+	}
+	for index, taskProviderConfig := range configList {
+		providerData, ok := taskProviderConfig["data"].(map[string]interface{})
+		if !ok {
+			return nil, errors.New("invalid task provider config")
+		}
+		if err := ValidateCredentials(credentialsList[index]); err != nil {
+			return nil, err
+		}
+		providerData["credentials"] = credentialsList[index]
+	}
+	return configList, nil
+}
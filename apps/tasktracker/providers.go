@@ -31,7 +31,11 @@ type Connection interface {
 	GetTask(ticketKey string) (*serializers.Task, error)
 	GetTaskUrl(ticketKey string) string
 	GetSprint(sprintID string) *serializers.Sprint
+	ListSprints(boardOrProject string, state string) ([]serializers.Sprint, error)
 	GetSprintTaskList(sprint serializers.Sprint) []serializers.Task
+	GetBacklogTaskList() ([]serializers.Task, error)
+	CreateTask(task serializers.Task) (*serializers.Task, error)
+	UpdateTask(ticketKey string, update serializers.TaskUpdate) (*serializers.Task, error)
 	ValidateConfig() error
 	SanitizeTimeLogs([]string) map[string]string
 }
@@ -48,6 +52,38 @@ const DoneStatus = "DoneStatus"
 // StatusTypes ...
 var StatusTypes = []string{DoneStatus}
 
+// The states of the sprints listed by a connection, a comma separated list of states can be given to ListSprints
+const (
+	ActiveSprintState = "active"
+	FutureSprintState = "future"
+	ClosedSprintState = "closed"
+)
+
+// DefaultSprintStates are the states of the sprints a new sprint can be picked from
+const DefaultSprintStates = ActiveSprintState + "," + FutureSprintState
+
+// ValidateSprintStates checks the comma separated sprint states given to ListSprints, an empty one is the default
+func ValidateSprintStates(state string) error {
+	if state == "" {
+		return nil
+	}
+	for _, sprintState := range strings.Split(state, ",") {
+		switch strings.TrimSpace(sprintState) {
+		case ActiveSprintState, FutureSprintState, ClosedSprintState:
+		default:
+			return errors.New("invalid sprint state, the states can be " +
+				strings.Join([]string{ActiveSprintState, FutureSprintState, ClosedSprintState}, ", "))
+		}
+	}
+	return nil
+}
+
+// ErrUnknownBoard is returned by ListSprints for a board, or a project, which is not a configured one
+var ErrUnknownBoard = errors.New("the board is not configured for the task tracker")
+
+// ErrPartialTaskUpdate is returned by UpdateTask when only a part of the changes was written to the ticket
+var ErrPartialTaskUpdate = errors.New("the ticket was partially updated in the task tracker")
+
 // RegisterTaskProvider ...
 func RegisterTaskProvider(name string, newProvider TaskProvider) {
 	TaskProviders[name] = newProvider
@@ -180,6 +216,36 @@ func GetTaskDetails(config []byte, taskKey string) (*serializers.Task, error) {
 	return connection.GetTask(taskKey)
 }
 
+// GetBacklogTaskList returns the tickets in the backlog of the task tracker, i.e. the candidates for a sprint
+func GetBacklogTaskList(config []byte) ([]serializers.Task, error) {
+	connection := GetConnection(config)
+	if connection == nil {
+		return nil, errors.New("backlog_task_list: invalid connection config")
+	}
+
+	return connection.GetBacklogTaskList()
+}
+
+// CreateTask creates a ticket with the summary, description and type of the given task in the task tracker
+func CreateTask(config []byte, task serializers.Task) (*serializers.Task, error) {
+	connection := GetConnection(config)
+	if connection == nil {
+		return nil, errors.New("create_task: invalid connection config")
+	}
+
+	return connection.CreateTask(task)
+}
+
+// UpdateTask writes the given changes to the ticket in the task tracker
+func UpdateTask(config []byte, ticketKey string, update serializers.TaskUpdate) (*serializers.Task, error) {
+	connection := GetConnection(config)
+	if connection == nil {
+		return nil, errors.New("update_task: invalid connection config")
+	}
+
+	return connection.UpdateTask(ticketKey, update)
+}
+
 // GetSprintTaskList ...
 func GetSprintTaskList(config []byte, sprint serializers.Sprint) (tasks []serializers.Task, err error) {
 	connection := GetConnection(config)
@@ -2,14 +2,17 @@ package providers
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/andygrunwald/go-jira"
 
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"net/url"
 
 	"github.com/iReflect/reflect-app/apps/tasktracker"
 	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
@@ -26,6 +29,9 @@ const FromDateJQLKeyword = "${fromDate}"
 // ToDateJQLKeyword ...
 const ToDateJQLKeyword = "${toDate}"
 
+// jiraChangelogTimeFormat is the format of the times of the changelog of a ticket
+const jiraChangelogTimeFormat = "2006-01-02T15:04:05.000-0700"
+
 // JIRATaskProvider ...
 type JIRATaskProvider struct {
 }
@@ -43,6 +49,10 @@ type JIRAConfig struct {
 	BoardIds      string                  `json:"BoardIds"`
 	JQL           string                  `json:"JQL"`
 	EstimateField string                  `json:"EstimateField"`
+	EpicLinkField string                  `json:"EpicLinkField"`
+	ProjectKey    string                  `json:"ProjectKey"`
+	IssueType     string                  `json:"IssueType"`
+	DoneStatus    string                  `json:"DoneStatus"`
 }
 
 // GetBaseURL returns the sanitized base URL.
@@ -51,11 +61,32 @@ func (config JIRAConfig) GetBaseURL() string {
 	return strings.Trim(config.BaseURL, "/")
 }
 
+// isDoneStatus tells if the status is one of the configured done statuses
+func (config JIRAConfig) isDoneStatus(status string) bool {
+	for _, doneStatus := range strings.Split(config.DoneStatus, ",") {
+		if strings.TrimSpace(doneStatus) != "" && strings.EqualFold(strings.TrimSpace(doneStatus), status) {
+			return true
+		}
+	}
+	return false
+}
+
+// jiraTransition is a workflow transition of an issue and the status it leads to
+type jiraTransition struct {
+	ID string `json:"id"`
+	To struct {
+		Name string `json:"name"`
+	} `json:"to"`
+}
+
 // TaskProviderJira ...
 const (
 	TaskProviderJira = "jira"
 )
 
+// defaultJIRAIssueType is the type of the issues created when no issue type is configured
+const defaultJIRAIssueType = "Task"
+
 func init() {
 	provider := &JIRATaskProvider{}
 	tasktracker.RegisterTaskProvider(TaskProviderJira, provider)
@@ -155,6 +186,27 @@ func (p *JIRATaskProvider) ConfigTemplate() (configMap map[string]interface{}) {
 				"Required":         false,
 				"Editable":         false,
 			},
+			{
+				"FieldName":        "EpicLinkField",
+				"FieldDisplayName": "Epic Link Field. eg. 'customfield_10014' (Leave blank to use the Epic/Parent of the ticket)",
+				"Type":             "string",
+				"Required":         false,
+				"Editable":         true,
+			},
+			{
+				"FieldName":        "ProjectKey",
+				"FieldDisplayName": "Project Key to create the tickets in. eg. 'IR' (Leave blank to disable ticket creation)",
+				"Type":             "string",
+				"Required":         false,
+				"Editable":         true,
+			},
+			{
+				"FieldName":        "IssueType",
+				"FieldDisplayName": fmt.Sprintf("Type of the created tickets (Leave blank to use %s)", defaultJIRAIssueType),
+				"Type":             "string",
+				"Required":         false,
+				"Editable":         true,
+			},
 		},
 	}
 	return configMap
@@ -216,11 +268,64 @@ func (c *JIRAConnection) GetSprint(sprintID string) *serializers.Sprint {
 		ID:       sprintID,
 		BoardID:  strconv.Itoa(sprint.OriginBoardID),
 		Name:     sprint.Name,
+		State:    sprint.State,
 		FromDate: sprint.StartDate,
 		ToDate:   sprint.EndDate,
 	}
 }
 
+// ListSprints returns the sprints of the configured board, or of all the configured boards when no board is
+// given, in any of the comma separated states
+func (c *JIRAConnection) ListSprints(boardOrProject string, state string) ([]serializers.Sprint, error) {
+	if state == "" {
+		state = tasktracker.DefaultSprintStates
+	}
+	boardIDs := c.config.BoardIds
+	if boardOrProject != "" {
+		configuredBoardIDs := make(map[string]bool)
+		for _, boardID := range strings.Split(c.config.BoardIds, ",") {
+			configuredBoardIDs[strings.TrimSpace(boardID)] = true
+		}
+		if !configuredBoardIDs[strings.TrimSpace(boardOrProject)] {
+			return nil, tasktracker.ErrUnknownBoard
+		}
+		boardIDs = boardOrProject
+	}
+
+	var sprints []serializers.Sprint
+	for _, boardID := range strings.Split(boardIDs, ",") {
+		boardID = strings.TrimSpace(boardID)
+		if boardID == "" {
+			continue
+		}
+		for startAt := 0; ; {
+			var page struct {
+				IsLast bool          `json:"isLast"`
+				Values []jira.Sprint `json:"values"`
+			}
+			if err := c.do("GET", fmt.Sprintf("rest/agile/1.0/board/%s/sprint?state=%s&startAt=%d",
+				url.PathEscape(boardID), url.QueryEscape(state), startAt), nil, &page); err != nil {
+				return nil, err
+			}
+			for _, sprint := range page.Values {
+				sprints = append(sprints, serializers.Sprint{
+					ID:       strconv.Itoa(sprint.ID),
+					BoardID:  boardID,
+					Name:     sprint.Name,
+					State:    sprint.State,
+					FromDate: sprint.StartDate,
+					ToDate:   sprint.EndDate,
+				})
+			}
+			if page.IsLast || len(page.Values) == 0 {
+				break
+			}
+			startAt += len(page.Values)
+		}
+	}
+	return sprints, nil
+}
+
 // GetSprintTaskList ...
 func (c *JIRAConnection) GetSprintTaskList(sprint serializers.Sprint) []serializers.Task {
 	var extraJQL string
@@ -231,6 +336,195 @@ func (c *JIRAConnection) GetSprintTaskList(sprint serializers.Sprint) []serializ
 	return tickets
 }
 
+// CreateTask ...
+func (c *JIRAConnection) CreateTask(task serializers.Task) (*serializers.Task, error) {
+	if c.config.ProjectKey == "" {
+		return nil, errors.New("no project key configured to create the ticket in")
+	}
+	issueType := task.Type
+	if issueType == "" {
+		issueType = c.config.IssueType
+	}
+	if issueType == "" {
+		issueType = defaultJIRAIssueType
+	}
+
+	issue := jira.Issue{
+		Fields: &jira.IssueFields{
+			Project:     jira.Project{Key: c.config.ProjectKey},
+			Type:        jira.IssueType{Name: issueType},
+			Summary:     task.Summary,
+			Description: task.Description,
+		},
+	}
+	createdIssue, resp, err := c.client.Issue.Create(&issue)
+	if err != nil {
+		err = jira.NewJiraError(resp, err)
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	// The create API only returns the id and the key of the issue
+	ticket, err := c.getTicket(createdIssue.Key)
+	if err != nil || ticket == nil {
+		return &serializers.Task{
+			Key:             createdIssue.Key,
+			TrackerUniqueID: createdIssue.ID,
+			Summary:         task.Summary,
+			Description:     task.Description,
+			Type:            issueType,
+		}, nil
+	}
+	return ticket, nil
+}
+
+// UpdateTask updates the estimate and the status of the ticket, in two requests. The ticket is transitioned
+// first, unless it is being closed as the closed tickets may not be editable, and ErrPartialTaskUpdate is
+// returned when the second request fails
+func (c *JIRAConnection) UpdateTask(ticketKey string, update serializers.TaskUpdate) (*serializers.Task, error) {
+	transition := false
+	if update.Done != nil {
+		ticket, err := c.getTicket(ticketKey)
+		if err != nil {
+			return nil, err
+		}
+		if ticket == nil {
+			return nil, fmt.Errorf("%s: ticket not found", ticketKey)
+		}
+		transition = c.config.isDoneStatus(ticket.Status) != *update.Done
+	}
+
+	updated := false
+	if transition && !*update.Done {
+		if err := c.transitionTicket(ticketKey, false, nil); err != nil {
+			return nil, err
+		}
+		updated = true
+	}
+
+	if update.Estimate != nil {
+		if err := c.updateEstimate(ticketKey, *update.Estimate); err != nil {
+			utils.LogToSentry(err)
+			if updated {
+				return nil, tasktracker.ErrPartialTaskUpdate
+			}
+			return nil, err
+		}
+		updated = true
+	}
+
+	if transition && *update.Done {
+		if err := c.transitionTicket(ticketKey, true, update.Resolution); err != nil {
+			utils.LogToSentry(err)
+			if updated {
+				return nil, tasktracker.ErrPartialTaskUpdate
+			}
+			return nil, err
+		}
+	}
+
+	return c.getTicket(ticketKey)
+}
+
+// updateEstimate sets the estimate of the ticket, in the estimate field when one is configured
+func (c *JIRAConnection) updateEstimate(ticketKey string, estimate float64) error {
+	fields := make(map[string]interface{})
+	if c.config.EstimateField != "" {
+		fields[c.config.EstimateField] = estimate
+	} else {
+		// Without an estimate field the estimate is the original time estimate in hours
+		fields["timetracking"] = map[string]interface{}{
+			"originalEstimate": fmt.Sprintf("%dm", int(estimate*60+0.5)),
+		}
+	}
+	return c.do("PUT", fmt.Sprintf("rest/api/2/issue/%s", ticketKey), map[string]interface{}{"fields": fields}, nil)
+}
+
+// transitionTicket moves the ticket to a done or a not done status, through the first such transition
+// available in its workflow
+func (c *JIRAConnection) transitionTicket(ticketKey string, done bool, resolution *string) error {
+	if c.config.DoneStatus == "" {
+		return errors.New("no done status configured")
+	}
+
+	url := fmt.Sprintf("rest/api/2/issue/%s/transitions", ticketKey)
+	var transitions struct {
+		Transitions []jiraTransition `json:"transitions"`
+	}
+	if err := c.do("GET", url, nil, &transitions); err != nil {
+		return err
+	}
+
+	for _, transition := range transitions.Transitions {
+		if c.config.isDoneStatus(transition.To.Name) != done {
+			continue
+		}
+		payload := map[string]interface{}{"transition": map[string]string{"id": transition.ID}}
+		if done && resolution != nil {
+			payload["fields"] = map[string]interface{}{"resolution": map[string]string{"name": *resolution}}
+			if err := c.do("POST", url, payload, nil); err == nil {
+				return nil
+			}
+			// The resolution can not be set if it is not on the transition screen
+			delete(payload, "fields")
+		}
+		return c.do("POST", url, payload, nil)
+	}
+	return fmt.Errorf("%s: no transition available to the requested status", ticketKey)
+}
+
+// do sends a request to the JIRA REST API and decodes the response into v
+func (c *JIRAConnection) do(method string, url string, body interface{}, v interface{}) error {
+	req, err := c.client.NewRequest(method, url, body)
+	if err != nil {
+		utils.LogToSentry(err)
+		return err
+	}
+
+	resp, err := c.client.Do(req, v)
+	if err != nil {
+		err = jira.NewJiraError(resp, err)
+		utils.LogToSentry(err)
+		return err
+	}
+	return nil
+}
+
+// GetBacklogTaskList returns the tickets in the backlogs of the configured boards
+func (c *JIRAConnection) GetBacklogTaskList() ([]serializers.Task, error) {
+	var tickets []serializers.Task
+	ticketKeys := make(map[string]bool)
+
+	for _, boardID := range strings.Split(c.config.BoardIds, ",") {
+		boardID = strings.TrimSpace(boardID)
+		if boardID == "" {
+			continue
+		}
+		for startAt := 0; ; {
+			var page struct {
+				Total  int          `json:"total"`
+				Issues []jira.Issue `json:"issues"`
+			}
+			if err := c.do("GET", fmt.Sprintf("rest/agile/1.0/board/%s/backlog?startAt=%d",
+				url.PathEscape(boardID), startAt), nil, &page); err != nil {
+				return nil, err
+			}
+			for _, ticket := range c.serializeTickets(page.Issues) {
+				// An issue can be in the backlog of more than one board
+				if !ticketKeys[ticket.Key] {
+					ticketKeys[ticket.Key] = true
+					tickets = append(tickets, ticket)
+				}
+			}
+			startAt += len(page.Issues)
+			if len(page.Issues) == 0 || startAt >= page.Total {
+				break
+			}
+		}
+	}
+	return tickets, nil
+}
+
 // ValidateConfig ...
 func (c *JIRAConnection) ValidateConfig() error {
 	searchOptions := jira.SearchOptions{MaxResults: 1}
@@ -241,7 +535,8 @@ func (c *JIRAConnection) ValidateConfig() error {
 
 func (c *JIRAConnection) getTicketsFromJQL(extraJQL string, skipBaseJQL bool, sprint *serializers.Sprint) (ticketsSerialized []serializers.Task, err error) {
 	// Need to pass in validateQuery=warn like this until jira-go supports this natively
-	searchOptions := jira.SearchOptions{MaxResults: 50000, ValidateQuery: "warn"}
+	// The changelog of the tickets has their status transitions
+	searchOptions := jira.SearchOptions{MaxResults: 50000, ValidateQuery: "warn", Expand: "changelog"}
 
 	jql := ""
 	if !skipBaseJQL && c.config.JQL != "" {
@@ -269,7 +564,7 @@ func (c *JIRAConnection) getTicketsFromJQL(extraJQL string, skipBaseJQL bool, sp
 
 func (c *JIRAConnection) getTicket(ticketKey string) (ticketSerialized *serializers.Task, err error) {
 
-	ticket, resp, err := c.client.Issue.Get(ticketKey, nil)
+	ticket, resp, err := c.client.Issue.Get(ticketKey, &jira.GetQueryOptions{Expand: "changelog"})
 	if err != nil {
 
 		if resp.StatusCode == 404 {
@@ -338,10 +633,67 @@ func (c *JIRAConnection) serializeTicket(ticket jira.Issue) *serializers.Task {
 	if ticket.Fields.Priority != nil {
 		serializedTask.Priority = ticket.Fields.Priority.Name
 	}
+	if updatedAt := time.Time(ticket.Fields.Updated); !updatedAt.IsZero() {
+		serializedTask.UpdatedAt = &updatedAt
+	}
+	if createdAt := time.Time(ticket.Fields.Created); !createdAt.IsZero() {
+		serializedTask.CreatedAt = &createdAt
+	}
+	c.setTicketHierarchy(ticket, &serializedTask)
+	serializedTask.Transitions = c.getTicketTransitions(ticket)
 
 	return &serializedTask
 }
 
+// getTicketTransitions returns the status transitions of the ticket from its changelog
+func (c *JIRAConnection) getTicketTransitions(ticket jira.Issue) (transitions []serializers.TaskTransition) {
+	if ticket.Changelog == nil {
+		return nil
+	}
+	for _, history := range ticket.Changelog.Histories {
+		transitionedAt, err := time.Parse(jiraChangelogTimeFormat, history.Created)
+		if err != nil {
+			continue
+		}
+		for _, item := range history.Items {
+			if item.Field != "status" {
+				continue
+			}
+			transitions = append(transitions, serializers.TaskTransition{
+				FromStatus:     item.FromString,
+				ToStatus:       item.ToString,
+				TransitionedAt: transitionedAt,
+				TransitionedBy: history.Author.DisplayName,
+			})
+		}
+	}
+	sort.SliceStable(transitions, func(i, j int) bool {
+		return transitions[i].TransitionedAt.Before(transitions[j].TransitionedAt)
+	})
+	return transitions
+}
+
+// setTicketHierarchy sets the parent and the epic of the ticket. The parent of a subtask is its parent ticket,
+// whereas the parent of any other ticket, in the team-managed projects, is its epic.
+func (c *JIRAConnection) setTicketHierarchy(ticket jira.Issue, serializedTask *serializers.Task) {
+	if ticket.Fields.Parent != nil {
+		if ticket.Fields.Type.Subtask {
+			serializedTask.ParentKey = ticket.Fields.Parent.Key
+			serializedTask.ParentTrackerUniqueID = ticket.Fields.Parent.ID
+		} else {
+			serializedTask.EpicKey = ticket.Fields.Parent.Key
+		}
+	}
+
+	if c.config.EpicLinkField != "" {
+		if epicKey, ok := ticket.Fields.Unknowns[c.config.EpicLinkField].(string); ok && epicKey != "" {
+			serializedTask.EpicKey = epicKey
+		}
+	} else if ticket.Fields.Epic != nil && ticket.Fields.Epic.Key != "" {
+		serializedTask.EpicKey = ticket.Fields.Epic.Key
+	}
+}
+
 // sanitizeJQL replaces the parameters in the JQL with their respective values
 func (c *JIRAConnection) sanitizeJQL(sprint *serializers.Sprint) string {
 	if sprint == nil {
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/iReflect/go-pivotaltracker/v5/pivotal"
 	"github.com/iReflect/reflect-app/apps/tasktracker"
@@ -32,6 +34,22 @@ type PivotalConfig struct {
 // TaskProviderPivotal ...
 const TaskProviderPivotal = "pivotal"
 
+// defaultPivotalStoryType is the type of the stories created when no type is given
+const defaultPivotalStoryType = "chore"
+
+// pivotalIterationScopes are the scopes of the iterations in each of the sprint states
+var pivotalIterationScopes = map[string]string{
+	tasktracker.ActiveSprintState: "current",
+	tasktracker.FutureSprintState: "backlog",
+	tasktracker.ClosedSprintState: "done",
+}
+
+// The states a story is moved to when it is marked done or not done
+const (
+	pivotalDoneState    = "accepted"
+	pivotalNotDoneState = "started"
+)
+
 func init() {
 	provider := &PivotalTaskProvider{}
 	tasktracker.RegisterTaskProvider(TaskProviderPivotal, provider)
@@ -126,6 +144,110 @@ func (c *PivotalConnection) getProjectUsers() ([]pivotal.Person, error) {
 	return projectUsers, err
 }
 
+// pivotalEpic is an epic of a project, the stories of an epic are the ones with the label of the epic
+type pivotalEpic struct {
+	ID    int           `json:"id"`
+	Name  string        `json:"name"`
+	Label pivotal.Label `json:"label"`
+}
+
+// getEpicLabelMap returns the names of the epics of the project by the ID of their labels
+func (c *PivotalConnection) getEpicLabelMap() map[int]string {
+	projectID, err := strconv.Atoi(c.config.ProjectID)
+	if err != nil {
+		return nil
+	}
+
+	req, err := c.client.NewRequest("GET", fmt.Sprintf("projects/%v/epics", projectID), nil)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+	var epics []*pivotalEpic
+	if _, err = c.client.Do(req, &epics); err != nil {
+		utils.LogToSentry(err)
+		return nil
+	}
+
+	epicLabelMap := make(map[int]string)
+	for _, epic := range epics {
+		epicLabelMap[epic.Label.Id] = epic.Name
+	}
+	return epicLabelMap
+}
+
+// pivotalActivity is an activity of the project, the changes of the state of a story are its status transitions
+type pivotalActivity struct {
+	OccurredAt  time.Time `json:"occurred_at"`
+	PerformedBy struct {
+		Name string `json:"name"`
+	} `json:"performed_by"`
+	Changes []struct {
+		Kind           string                 `json:"kind"`
+		ID             int                    `json:"id"`
+		OriginalValues map[string]interface{} `json:"original_values"`
+		NewValues      map[string]interface{} `json:"new_values"`
+	} `json:"changes"`
+}
+
+// pivotalActivityPageSize is the largest number of activities Pivotal lists in a page
+const pivotalActivityPageSize = 500
+
+// getStoryTransitions returns the state transitions of the stories made after the given time, by the ID of the
+// story, from the activity at the given path, i.e. the one of the project or of a story
+func (c *PivotalConnection) getStoryTransitions(activityPath string,
+	occurredAfter *time.Time) map[int][]serializers.TaskTransition {
+	filterQuery := ""
+	if occurredAfter != nil {
+		filterQuery = fmt.Sprintf("&occurred_after=%v", occurredAfter.UTC().Format(time.RFC3339))
+	}
+	var activities []*pivotalActivity
+	for offset := 0; ; offset += pivotalActivityPageSize {
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("%v?limit=%v&offset=%v%v",
+			activityPath, pivotalActivityPageSize, offset, filterQuery), nil)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil
+		}
+		var page []*pivotalActivity
+		if _, err = c.client.Do(req, &page); err != nil {
+			utils.LogToSentry(err)
+			return nil
+		}
+		activities = append(activities, page...)
+		if len(page) < pivotalActivityPageSize {
+			break
+		}
+	}
+
+	transitions := make(map[int][]serializers.TaskTransition)
+	for _, activity := range activities {
+		for _, change := range activity.Changes {
+			if change.Kind != "story" {
+				continue
+			}
+			toState, isPresent := change.NewValues["current_state"].(string)
+			if !isPresent {
+				continue
+			}
+			fromState, _ := change.OriginalValues["current_state"].(string)
+			transitions[change.ID] = append(transitions[change.ID], serializers.TaskTransition{
+				FromStatus:     fromState,
+				ToStatus:       toState,
+				TransitionedAt: activity.OccurredAt,
+				TransitionedBy: activity.PerformedBy.Name,
+			})
+		}
+	}
+	// The activities are listed latest first
+	for _, storyTransitions := range transitions {
+		sort.SliceStable(storyTransitions, func(i, j int) bool {
+			return storyTransitions[i].TransitionedAt.Before(storyTransitions[j].TransitionedAt)
+		})
+	}
+	return transitions
+}
+
 // getUserIDNameMap ...
 func (c *PivotalConnection) getUserIDNameMap() map[int]string {
 	projectUsers, err := c.getProjectUsers()
@@ -144,16 +266,20 @@ func (c *PivotalConnection) getUserIDNameMap() map[int]string {
 
 // serializeTickets ...
 func (c *PivotalConnection) serializeTickets(tickets []*pivotal.Story, userIDNameMap map[int]string) (ticketsSerialized []serializers.Task) {
+	epicLabelMap := c.getEpicLabelMap()
 
 	for _, ticket := range tickets {
-		ticketsSerialized = append(ticketsSerialized, *c.serializeTicket(ticket, userIDNameMap))
+		task := c.serializeTicket(ticket, userIDNameMap, epicLabelMap)
+		ticketsSerialized = append(ticketsSerialized, *task)
 	}
 
 	return ticketsSerialized
 }
 
-// serializeTicket ...
-func (c *PivotalConnection) serializeTicket(ticket *pivotal.Story, userIDNameMap map[int]string) *serializers.Task {
+// serializeTicket serializes the story, stories have no subtasks and the epic of a story is the one
+// whose label the story has
+func (c *PivotalConnection) serializeTicket(ticket *pivotal.Story, userIDNameMap map[int]string,
+	epicLabelMap map[int]string) *serializers.Task {
 	ticketID := strconv.Itoa(ticket.Id)
 	task := &serializers.Task{
 		Key:             ticketID,
@@ -165,6 +291,8 @@ func (c *PivotalConnection) serializeTicket(ticket *pivotal.Story, userIDNameMap
 		Status:          ticket.State,
 		ProjectID:       c.config.ProjectID,
 		Priority:        "",
+		UpdatedAt:       ticket.UpdatedAt,
+		CreatedAt:       ticket.CreatedAt,
 	}
 
 	// Set Assignee of a task, since PT has owners (multiple) so we can set it to a comma separated list of Owner names
@@ -177,6 +305,13 @@ func (c *PivotalConnection) serializeTicket(ticket *pivotal.Story, userIDNameMap
 		}
 		task.Assignee = strings.Join(owners, ", ")
 	}
+
+	for _, label := range ticket.Labels {
+		if epicName, isPresent := epicLabelMap[label.Id]; isPresent {
+			task.EpicKey = epicName
+			break
+		}
+	}
 	return task
 }
 
@@ -235,7 +370,7 @@ func (c *PivotalConnection) GetTask(ticketKey string) (*serializers.Task, error)
 		return nil, nil
 	}
 
-	return c.serializeTicket(story, c.getUserIDNameMap()), nil
+	return c.serializeTicket(story, c.getUserIDNameMap(), c.getEpicLabelMap()), nil
 }
 
 // GetSprint ...
@@ -263,6 +398,54 @@ func (c *PivotalConnection) GetSprint(sprintID string) *serializers.Sprint {
 	}
 }
 
+// ListSprints returns the iterations of the configured project in any of the comma separated states, the
+// project can be left out
+func (c *PivotalConnection) ListSprints(boardOrProject string, state string) ([]serializers.Sprint, error) {
+	if state == "" {
+		state = tasktracker.DefaultSprintStates
+	}
+	if boardOrProject == "" {
+		boardOrProject = c.config.ProjectID
+	}
+	if strings.TrimSpace(boardOrProject) != strings.TrimSpace(c.config.ProjectID) {
+		return nil, tasktracker.ErrUnknownBoard
+	}
+	projectID, err := strconv.Atoi(boardOrProject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID %q", boardOrProject)
+	}
+
+	var sprints []serializers.Sprint
+	for _, sprintState := range strings.Split(state, ",") {
+		sprintState = strings.TrimSpace(sprintState)
+		scope, ok := pivotalIterationScopes[sprintState]
+		if !ok {
+			return nil, fmt.Errorf("invalid sprint state %q", sprintState)
+		}
+		req, err := c.client.NewRequest("GET", fmt.Sprintf("projects/%v/iterations?scope=%v", projectID, scope), nil)
+		if err != nil {
+			utils.LogToSentry(err)
+			return nil, err
+		}
+		var iterations []*pivotal.Iteration
+		if _, err = c.client.Do(req, &iterations); err != nil {
+			utils.LogToSentry(err)
+			return nil, err
+		}
+		for _, iteration := range iterations {
+			sprints = append(sprints, serializers.Sprint{
+				ID:       strconv.Itoa(iteration.Number),
+				BoardID:  "",
+				Name:     fmt.Sprintf("Iteration-%v", strconv.Itoa(iteration.Number)),
+				State:    sprintState,
+				FromDate: iteration.Start,
+				ToDate:   iteration.Finish,
+			})
+		}
+	}
+	return sprints, nil
+}
+
 // GetSprintTaskList ...
 func (c *PivotalConnection) GetSprintTaskList(sprint serializers.Sprint) []serializers.Task {
 	if sprint.ID == "" {
@@ -288,7 +471,97 @@ func (c *PivotalConnection) GetSprintTaskList(sprint serializers.Sprint) []seria
 		storyIDs = append(storyIDs, strconv.Itoa(story.Id))
 	}
 
-	return c.GetTaskList(storyIDs)
+	tasks := c.GetTaskList(storyIDs)
+	if len(tasks) == 0 {
+		return tasks
+	}
+	// The activity of the project since the sprint began has the transitions of the stories whose earlier
+	// transitions are stored, the whole activity of the other stories is fetched story by story
+	var transitions map[int][]serializers.TaskTransition
+	for index := range tasks {
+		storyID, err := strconv.Atoi(tasks[index].TrackerUniqueID)
+		if err != nil {
+			continue
+		}
+		if !sprint.StoredTransitionTickets[tasks[index].TrackerUniqueID] {
+			tasks[index].Transitions = c.getStoryTransitions(
+				fmt.Sprintf("projects/%v/stories/%v/activity", projectID, storyID), nil)[storyID]
+			continue
+		}
+		if transitions == nil {
+			transitions = c.getStoryTransitions(fmt.Sprintf("projects/%v/activity", projectID), sprint.FromDate)
+		}
+		tasks[index].Transitions = transitions[storyID]
+	}
+	return tasks
+}
+
+// GetBacklogTaskList returns the stories which are not started yet, in the backlog as well as in the icebox
+func (c *PivotalConnection) GetBacklogTaskList() ([]serializers.Task, error) {
+	projectID, err := strconv.Atoi(c.config.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	stories, err := c.client.Stories.List(projectID, "state:unstarted OR state:unscheduled")
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	return c.serializeTickets(stories, c.getUserIDNameMap()), nil
+}
+
+// CreateTask ...
+func (c *PivotalConnection) CreateTask(task serializers.Task) (*serializers.Task, error) {
+	projectID, err := strconv.Atoi(c.config.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	storyType := task.Type
+	if storyType == "" {
+		storyType = defaultPivotalStoryType
+	}
+
+	story, _, err := c.client.Stories.Create(projectID, &pivotal.StoryRequest{
+		Name:        task.Summary,
+		Description: task.Description,
+		Type:        storyType,
+	})
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	return c.serializeTicket(story, nil, nil), nil
+}
+
+// UpdateTask updates the estimate and the state of the story, stories have no resolutions
+func (c *PivotalConnection) UpdateTask(ticketKey string, update serializers.TaskUpdate) (*serializers.Task, error) {
+	ticketID, err := strconv.Atoi(strings.TrimPrefix(ticketKey, "#"))
+	if err != nil {
+		return nil, err
+	}
+	projectID, err := strconv.Atoi(c.config.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	storyRequest := pivotal.StoryRequest{Estimate: update.Estimate}
+	if update.Done != nil {
+		storyRequest.State = pivotalNotDoneState
+		if *update.Done {
+			storyRequest.State = pivotalDoneState
+		}
+	}
+
+	story, _, err := c.client.Stories.Update(projectID, ticketID, &storyRequest)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, err
+	}
+
+	return c.serializeTicket(story, c.getUserIDNameMap(), c.getEpicLabelMap()), nil
 }
 
 // ValidateConfig validates if the provided API Token and ProjectID are correct
@@ -4,27 +4,54 @@ import (
 	"time"
 )
 
-//Task ...
+// Task is a ticket of the task tracker. ParentKey and ParentTrackerUniqueID are the ones of the parent
+// ticket of a subtask, EpicKey is the key of the epic the ticket belongs to. Transitions are the changes of
+// the status of the ticket in the order they were made, when the task tracker provides them.
 type Task struct {
-	Key             string
-	TrackerUniqueID string
-	ProjectID       string
-	Summary         string
-	Description     string
-	Type            string
-	Priority        string
-	Estimate        *float64
-	Assignee        string
-	Status          string
+	Key                   string
+	TrackerUniqueID       string
+	ProjectID             string
+	Summary               string
+	Description           string
+	Type                  string
+	Priority              string
+	Estimate              *float64
+	Assignee              string
+	Status                string
+	UpdatedAt             *time.Time
+	ParentKey             string
+	ParentTrackerUniqueID string
+	EpicKey               string
+	CreatedAt             *time.Time
+	Transitions           []TaskTransition
+}
+
+// TaskTransition is a change of the status of a ticket
+type TaskTransition struct {
+	FromStatus     string
+	ToStatus       string
+	TransitionedAt time.Time
+	TransitionedBy string
+}
+
+// TaskUpdate is a change to be written to a ticket, the nil fields are left unchanged
+type TaskUpdate struct {
+	Estimate   *float64
+	Done       *bool
+	Resolution *string
 }
 
 //Sprint ...
+// StoredTransitionTickets, given to get the tickets of the sprint, are the TrackerUniqueIDs of the tickets whose
+// status transitions are already stored, the whole history of the other tickets is fetched
 type Sprint struct {
-	ID       string
-	BoardID  string
-	Name     string
-	FromDate *time.Time
-	ToDate   *time.Time
+	ID                      string
+	BoardID                 string
+	Name                    string
+	State                   string
+	FromDate                *time.Time
+	ToDate                  *time.Time
+	StoredTransitionTickets map[string]bool
 }
 
 //Board ...
@@ -43,3 +70,8 @@ type TimeProvider struct {
 type TimeProvidersSerializer struct {
 	TimeProviders []TimeProvider
 }
+
+// SprintsSerializer ...
+type SprintsSerializer struct {
+	Sprints []Sprint
+}
@@ -1,13 +1,18 @@
 package services
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/jinzhu/gorm"
 
+	retroModels "github.com/iReflect/reflect-app/apps/retrospective/models"
 	"github.com/iReflect/reflect-app/apps/tasktracker"
 	"github.com/iReflect/reflect-app/apps/tasktracker/serializers"
 	"github.com/iReflect/reflect-app/apps/timetracker"
 	userModels "github.com/iReflect/reflect-app/apps/user/models"
 	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/libs/utils"
 )
 
 //TaskTrackerService ...
@@ -59,3 +64,48 @@ func (service TaskTrackerService) SupportedTimeTrackersList(taskTracker string,
 
 	return &timeTrackerList, nil
 }
+
+// ListSprints returns the sprints of the task tracker of the retrospective in the given comma separated states,
+// the active and the future ones by default, to pick the sprint ID of a new sprint from
+func (service TaskTrackerService) ListSprints(retroID string, boardOrProject string,
+	state string) (*serializers.SprintsSerializer, int, error) {
+	if err := tasktracker.ValidateSprintStates(state); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	var retro retroModels.Retrospective
+	if err := service.DB.Model(&retroModels.Retrospective{}).
+		Where("retrospectives.deleted_at IS NULL").
+		Where("id = ?", retroID).
+		First(&retro).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, http.StatusNotFound, errors.New("retrospective not found")
+		}
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get retrospective")
+	}
+
+	taskProviderConfig, err := tasktracker.DecryptTaskProviders(retro.TaskProviderConfig)
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError,
+			errors.New("failed to get task provider config. please contact admin")
+	}
+	connection := tasktracker.GetConnection(taskProviderConfig)
+	if connection == nil {
+		return nil, http.StatusInternalServerError, errors.New("invalid connection config")
+	}
+
+	sprints, err := connection.ListSprints(boardOrProject, state)
+	if err == tasktracker.ErrUnknownBoard {
+		return nil, http.StatusBadRequest, err
+	}
+	if err != nil {
+		utils.LogToSentry(err)
+		return nil, http.StatusInternalServerError, errors.New("failed to get the sprints from the task tracker")
+	}
+	if sprints == nil {
+		sprints = []serializers.Sprint{}
+	}
+	return &serializers.SprintsSerializer{Sprints: sprints}, http.StatusOK, nil
+}
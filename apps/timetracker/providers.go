@@ -14,7 +14,9 @@ type TimeProvider interface {
 
 // Connection ...
 type Connection interface {
-	GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) []serializers.TimeLog
+	// GetProjectTimeLogs returns the time logged on the project in between the days of startTime and endTime
+	// in the location, i.e. the time zone of the team
+	GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time, location *time.Location) []serializers.TimeLog
 	CleanTimeProviderConfig() interface{}
 }
 
@@ -53,7 +55,8 @@ func CleanTimeProviderConfig(data interface{}, name string) interface{} {
 func GetProjectTimeLogs(
 	config []byte,
 	project string,
-	startTime, endTime time.Time) ([]serializers.TimeLog, error) {
+	startTime, endTime time.Time,
+	location *time.Location) ([]serializers.TimeLog, error) {
 	connections, err := GetConnections(config)
 	if err != nil {
 		return nil, err
@@ -61,7 +64,7 @@ func GetProjectTimeLogs(
 	timeLogs := make([]serializers.TimeLog, 0)
 
 	for _, connection := range connections {
-		timeLogs = append(timeLogs, connection.GetProjectTimeLogs(project, startTime, endTime)...)
+		timeLogs = append(timeLogs, connection.GetProjectTimeLogs(project, startTime, endTime, location)...)
 	}
 	return timeLogs, nil
 }
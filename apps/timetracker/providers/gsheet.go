@@ -97,18 +97,22 @@ func (m *GsheetConnection) CleanTimeProviderConfig() interface{} {
 	return m.config
 }
 
-// GetProjectTimeLogs ...
-func (m *GsheetConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) []serializers.TimeLog {
+// GetProjectTimeLogs returns the time logs of the sheet, the configured time zone of the time tracker is used
+// when the location isn't given
+func (m *GsheetConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time, location *time.Location) []serializers.TimeLog {
 
 	timeLogs := make([]serializers.TimeLog, 0)
 	timeTrackerConfig := config.GetConfig().TimeTracker
 	appExecutor := google.AppScriptExecutor{ScriptID: timeTrackerConfig.ScriptID, CredentialsFile: timeTrackerConfig.GoogleCredentials}
 
-	location, err := time.LoadLocation(timeTrackerConfig.TimeZone)
-	if err != nil {
-		log.Println("Invalid Timezone: ", err)
-		utils.LogToSentry(err)
-		return timeLogs
+	if location == nil {
+		var err error
+		location, err = time.LoadLocation(timeTrackerConfig.TimeZone)
+		if err != nil {
+			log.Println("Invalid Timezone: ", err)
+			utils.LogToSentry(err)
+			return timeLogs
+		}
 	}
 	responseBytes, err := appExecutor.Run(
 		timeTrackerConfig.FnGetTimeLog,
@@ -137,9 +137,13 @@ func (jiraConnection *JIRAConnection) CleanTimeProviderConfig() interface{} {
 }
 
 // GetProjectTimeLogs ...
-func (jiraConnection *JIRAConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time) []serializers.TimeLog {
+func (jiraConnection *JIRAConnection) GetProjectTimeLogs(project string, startTime time.Time, endTime time.Time, location *time.Location) []serializers.TimeLog {
 
 	var timeLogs []serializers.TimeLog
+	if location != nil {
+		startTime = startTime.In(location)
+		endTime = endTime.In(location)
+	}
 	searchOptions := jira.SearchOptions{MaxResults: 50000, Fields: []string{"worklog", "project"}, ValidateQuery: "warn"}
 
 	// constructed JQL for a fetching ticket which had worklogs for a particular time period and project.
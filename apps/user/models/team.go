@@ -9,15 +9,20 @@ import (
 	"github.com/qor/qor/resource"
 
 	"github.com/iReflect/reflect-app/apps/timetracker"
+	"github.com/iReflect/reflect-app/libs/utils"
 )
 
-// Team represent a team/project comprising a set of user
+// Team represent a team/project comprising a set of user. Workweek is the comma separated working days of the
+// team, i.e. "Sun,Mon,Tue,Wed,Thu", and the server time zone is used when TimeZone is empty.
 type Team struct {
 	gorm.Model
-	Name             string `gorm:"type:varchar(64);not null"`
-	Description      string `gorm:"type:text"`
-	Active           bool   `gorm:"default:true; not null"`
-	TimeProviderName string `gorm:"not null"`
+	Name             string  `gorm:"type:varchar(64);not null"`
+	Description      string  `gorm:"type:text"`
+	Active           bool    `gorm:"default:true; not null"`
+	TimeProviderName string  `gorm:"not null"`
+	Workweek         string  `gorm:"type:varchar(32); not null; default:'Mon,Tue,Wed,Thu,Fri'"`
+	TimeZone         string  `gorm:"type:varchar(64); not null; default:''"`
+	HoursPerDay      float64 `gorm:"not null; default:8"`
 	Users            []User
 }
 
@@ -38,9 +43,28 @@ func (team *Team) BeforeSave(scope *gorm.Scope) error {
 	if _, exists := timetracker.TimeProvidersDisplayNameMap[team.TimeProviderName]; !exists {
 		return errors.New("Invalid time provider name")
 	}
+	if team.Workweek == "" {
+		team.Workweek = utils.DefaultWorkweek
+	}
+	if team.HoursPerDay == 0 {
+		team.HoursPerDay = utils.DefaultHoursPerDay
+	}
+	if _, err := utils.NewWorkingCalendar(team.Workweek, team.TimeZone, team.HoursPerDay); err != nil {
+		return err
+	}
 	return nil
 }
 
+// GetWorkingCalendar returns the working calendar of the team, the default calendar is the fallback
+func (team Team) GetWorkingCalendar() utils.WorkingCalendar {
+	calendar, err := utils.NewWorkingCalendar(team.Workweek, team.TimeZone, team.HoursPerDay)
+	if err != nil {
+		utils.LogToSentry(err)
+		return utils.GetDefaultWorkingCalendar()
+	}
+	return *calendar
+}
+
 // getTimeProviderMeta ...
 func getTimeProviderMeta() admin.Meta {
 	return admin.Meta{
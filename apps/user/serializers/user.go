@@ -23,6 +23,9 @@ type Team struct {
 	Name        string
 	Description string
 	Active      bool
+	Workweek    string
+	TimeZone    string
+	HoursPerDay float64
 }
 
 // TeamsSerializer ...
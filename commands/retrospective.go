@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/config"
+	"github.com/iReflect/reflect-app/db"
+)
+
+var exportOutput string
+var importTeamID uint
+
+var exportRetrospectiveCmd = &cobra.Command{
+	Use:   "export-retrospective [retrospective id]",
+	Short: "Export a retrospective to a portable json document",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			log.Fatal("retrospective id is required")
+		}
+		service := retroServices.RetrospectiveExportService{DB: db.Initialize(config.GetConfig())}
+
+		export, _, err := service.Export(args[0])
+		if err != nil {
+			log.Fatal("Export failed: ", err)
+		}
+
+		content, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			log.Fatal("Export failed: ", err)
+		}
+
+		if exportOutput == "" {
+			os.Stdout.Write(content)
+			return
+		}
+		if err = ioutil.WriteFile(exportOutput, content, 0644); err != nil {
+			log.Fatal("Export failed: ", err)
+		}
+		log.Println("Exported retrospective to", exportOutput)
+	},
+}
+
+var importRetrospectiveCmd = &cobra.Command{
+	Use:   "import-retrospective [file]",
+	Short: "Import a retrospective from a portable json document",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 1 {
+			log.Fatal("file is required")
+		}
+		if importTeamID == 0 {
+			log.Fatal("team id is required")
+		}
+
+		content, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			log.Fatal("Import failed: ", err)
+		}
+		var export retroSerializers.RetrospectiveExport
+		if err = json.Unmarshal(content, &export); err != nil {
+			log.Fatal("Import failed: ", err)
+		}
+
+		service := retroServices.RetrospectiveExportService{DB: db.Initialize(config.GetConfig())}
+		retro, _, err := service.Import(importTeamID, &export)
+		if err != nil {
+			log.Fatal("Import failed: ", err)
+		}
+		log.Println("Imported retrospective with id", retro.ID)
+	},
+}
+
+func init() {
+	exportRetrospectiveCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "file to write the export to, defaults to stdout")
+	importRetrospectiveCmd.Flags().UintVarP(&importTeamID, "team", "t", 0, "id of the team to import the retrospective into")
+
+	rootCmd.AddCommand(exportRetrospectiveCmd)
+	rootCmd.AddCommand(importRetrospectiveCmd)
+}
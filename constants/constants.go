@@ -14,6 +14,8 @@ const (
 	SprintMemberTask      ActionItemType = "SprintMemberTask"
 	Sprint                ActionItemType = "Sprint"
 	SprintTask            ActionItemType = "SprintTask"
+	RetroMeeting          ActionItemType = "RetroMeeting"
+	GoalComment           ActionItemType = "GoalComment"
 )
 
 // ActionItemTypeMap is types of ActionItem of Trail model used in adding trails.
@@ -24,6 +26,8 @@ var ActionItemTypeMap = map[ActionItemType]string{
 	SprintMemberTask:      "Sprint Member Task",
 	Sprint:                "Sprint",
 	SprintTask:            "Sprint Task",
+	RetroMeeting:          "Retro Meeting",
+	GoalComment:           "Goal Comment",
 }
 
 // ActionType special data type for action
@@ -31,66 +35,92 @@ type ActionType string
 
 // constants defined to use in ActionTypeMap
 const (
-	CreatedRetrospective    ActionType = "CreatedRetrospective"
-	UpdatedRetrospective    ActionType = "UpdatedRetrospective"
-	AddedGoal               ActionType = "AddedGoal"
-	UpdatedGoal             ActionType = "UpdatedGoal"
-	ResolvedGoal            ActionType = "ResolvedGoal"
-	UnresolvedGoal          ActionType = "UnresolvedGoal"
-	DeletedGoal             ActionType = "DeletedGoal"
-	AddedHighlight          ActionType = "AddedHighlight"
-	UpdatedHighlight        ActionType = "UpdatedHighlight"
-	DeletedHighlight        ActionType = "DeletedHighlight"
-	AddedSprintMember       ActionType = "AddedSprintMember"
-	UpdatedSprintMember     ActionType = "UpdatedSprintMember"
-	RemovedSprintMember     ActionType = "RemovedSprintMember"
-	AddedNote               ActionType = "AddedNote"
-	UpdatedNote             ActionType = "UpdatedNote"
-	DeletedNote             ActionType = "DeletedNote"
-	AddedSprintMemberTask   ActionType = "AddedSprintMemberTask"
-	UpdatedSprintMemberTask ActionType = "UpdatedSprintMemberTask"
-	CreatedSprint           ActionType = "CreatedSprint"
-	DeletedSprint           ActionType = "DeletedSprint"
-	UpdatedSprint           ActionType = "UpdatedSprint"
-	ActivatedSprint         ActionType = "ActivatedSprint"
-	FreezeSprint            ActionType = "FreezeSprint"
-	TriggeredSprintRefresh  ActionType = "TriggeredSprintRefresh"
-	UpdatedSprintTask       ActionType = "UpdatedSprintTask"
-	MarkDoneSprintTask      ActionType = "MarkDoneSprintTask"
-	MarkUndoneSprintTask    ActionType = "MarkUndoneSprintTask"
-	DeletedSprintTask       ActionType = "DeletedSprintTask"
+	CreatedRetrospective           ActionType = "CreatedRetrospective"
+	UpdatedRetrospective           ActionType = "UpdatedRetrospective"
+	ImportedRetrospective          ActionType = "ImportedRetrospective"
+	AddedGoal                      ActionType = "AddedGoal"
+	UpdatedGoal                    ActionType = "UpdatedGoal"
+	ResolvedGoal                   ActionType = "ResolvedGoal"
+	UnresolvedGoal                 ActionType = "UnresolvedGoal"
+	DeletedGoal                    ActionType = "DeletedGoal"
+	CommentedOnGoal                ActionType = "CommentedOnGoal"
+	UpdatedGoalComment             ActionType = "UpdatedGoalComment"
+	DeletedGoalComment             ActionType = "DeletedGoalComment"
+	CreatedGoalTicket              ActionType = "CreatedGoalTicket"
+	AddedHighlight                 ActionType = "AddedHighlight"
+	UpdatedHighlight               ActionType = "UpdatedHighlight"
+	DeletedHighlight               ActionType = "DeletedHighlight"
+	AddedSprintMember              ActionType = "AddedSprintMember"
+	UpdatedSprintMember            ActionType = "UpdatedSprintMember"
+	RemovedSprintMember            ActionType = "RemovedSprintMember"
+	AddedNote                      ActionType = "AddedNote"
+	UpdatedNote                    ActionType = "UpdatedNote"
+	DeletedNote                    ActionType = "DeletedNote"
+	AddedSprintMemberTask          ActionType = "AddedSprintMemberTask"
+	UpdatedSprintMemberTask        ActionType = "UpdatedSprintMemberTask"
+	CreatedSprint                  ActionType = "CreatedSprint"
+	DeletedSprint                  ActionType = "DeletedSprint"
+	UpdatedSprint                  ActionType = "UpdatedSprint"
+	ActivatedSprint                ActionType = "ActivatedSprint"
+	FreezeSprint                   ActionType = "FreezeSprint"
+	TriggeredSprintRefresh         ActionType = "TriggeredSprintRefresh"
+	UpdatedSprintTask              ActionType = "UpdatedSprintTask"
+	MarkDoneSprintTask             ActionType = "MarkDoneSprintTask"
+	MarkUndoneSprintTask           ActionType = "MarkUndoneSprintTask"
+	DeletedSprintTask              ActionType = "DeletedSprintTask"
+	PlannedSprintTask              ActionType = "PlannedSprintTask"
+	UpdatedPlannedTask             ActionType = "UpdatedPlannedTask"
+	AppliedPointsAllocation        ActionType = "AppliedPointsAllocation"
+	LockedSprintMemberTaskPoints   ActionType = "LockedSprintMemberTaskPoints"
+	UnlockedSprintMemberTaskPoints ActionType = "UnlockedSprintMemberTaskPoints"
+	AddedSprintMemberLeave         ActionType = "AddedSprintMemberLeave"
+	DeletedSprintMemberLeave       ActionType = "DeletedSprintMemberLeave"
+	ImportedSprintMemberLeaves     ActionType = "ImportedSprintMemberLeaves"
 )
 
 // ActionTypeMap is types of Action of Trail model used in adding trails.
 var ActionTypeMap = map[ActionType]string{
-	CreatedRetrospective:    "Created Retrospective",
-	UpdatedRetrospective:    "Updated Retrospective",
-	AddedGoal:               "Added a Goal",
-	UpdatedGoal:             "Updated a Goal",
-	ResolvedGoal:            "Marked a goal resolved",
-	UnresolvedGoal:          "Marked a goal unresolved",
-	DeletedGoal:             "Deleted a goal",
-	AddedHighlight:          "Added a highlight",
-	UpdatedHighlight:        "Updated a highlight",
-	DeletedHighlight:        "Deleted a highlight",
-	AddedSprintMember:       "Added member in sprint",
-	UpdatedSprintMember:     "Updated member in sprint",
-	RemovedSprintMember:     "Removed member from sprint",
-	AddedNote:               "Added a note",
-	UpdatedNote:             "Updated a note",
-	DeletedNote:             "Deleted a note",
-	AddedSprintMemberTask:   "Added a member on task in sprint",
-	UpdatedSprintMemberTask: "Updated member on task in sprint",
-	CreatedSprint:           "Created sprint",
-	DeletedSprint:           "Deleted sprint",
-	UpdatedSprint:           "Updated sprint",
-	ActivatedSprint:         "Activated sprint",
-	FreezeSprint:            "Freeze the sprint",
-	TriggeredSprintRefresh:  "Triggered sprint refresh",
-	UpdatedSprintTask:       "Updated the task in sprint",
-	MarkDoneSprintTask:      "Marked done a task in sprint",
-	MarkUndoneSprintTask:    "Marked undone a task in sprint",
-	DeletedSprintTask:       "Deleted the task in sprint",
+	CreatedRetrospective:           "Created Retrospective",
+	UpdatedRetrospective:           "Updated Retrospective",
+	ImportedRetrospective:          "Imported Retrospective",
+	AddedGoal:                      "Added a Goal",
+	UpdatedGoal:                    "Updated a Goal",
+	ResolvedGoal:                   "Marked a goal resolved",
+	UnresolvedGoal:                 "Marked a goal unresolved",
+	DeletedGoal:                    "Deleted a goal",
+	CommentedOnGoal:                "Commented on a goal",
+	UpdatedGoalComment:             "Updated a comment on a goal",
+	DeletedGoalComment:             "Deleted a comment on a goal",
+	CreatedGoalTicket:              "Created a ticket for a goal",
+	AddedHighlight:                 "Added a highlight",
+	UpdatedHighlight:               "Updated a highlight",
+	DeletedHighlight:               "Deleted a highlight",
+	AddedSprintMember:              "Added member in sprint",
+	UpdatedSprintMember:            "Updated member in sprint",
+	RemovedSprintMember:            "Removed member from sprint",
+	AddedNote:                      "Added a note",
+	UpdatedNote:                    "Updated a note",
+	DeletedNote:                    "Deleted a note",
+	AddedSprintMemberTask:          "Added a member on task in sprint",
+	UpdatedSprintMemberTask:        "Updated member on task in sprint",
+	CreatedSprint:                  "Created sprint",
+	DeletedSprint:                  "Deleted sprint",
+	UpdatedSprint:                  "Updated sprint",
+	ActivatedSprint:                "Activated sprint",
+	FreezeSprint:                   "Freeze the sprint",
+	TriggeredSprintRefresh:         "Triggered sprint refresh",
+	UpdatedSprintTask:              "Updated the task in sprint",
+	MarkDoneSprintTask:             "Marked done a task in sprint",
+	MarkUndoneSprintTask:           "Marked undone a task in sprint",
+	DeletedSprintTask:              "Deleted the task in sprint",
+	PlannedSprintTask:              "Added a task to the sprint plan",
+	UpdatedPlannedTask:             "Updated the planned estimate of a task in sprint",
+	AppliedPointsAllocation:        "Applied the points allocation in sprint",
+	LockedSprintMemberTaskPoints:   "Locked the points of a member on task in sprint",
+	UnlockedSprintMemberTaskPoints: "Unlocked the points of a member on task in sprint",
+	AddedSprintMemberLeave:         "Added a leave for member in sprint",
+	DeletedSprintMemberLeave:       "Deleted a leave of member in sprint",
+	ImportedSprintMemberLeaves:     "Imported the leaves of member in sprint",
 }
 
 // constants for error messages
@@ -98,8 +128,12 @@ const (
 	InvalidEmailOrPassword     = "Invalid email or password"
 	TaskTrackerNameIsMustError = "no task tracker name provided in the request"
 	TeamIDIsMustError          = "no team ID provided in the request"
+	RetroIDIsMustError         = "no retrospective ID provided in the request"
 )
 
+// ImportedAuthorEmail is the email of the inactive user the imported items without an author are attributed to
+const ImportedAuthorEmail = "imported-author@ireflect.invalid"
+
 // <----------- constants for email --------------->
 
 // OTPEmailSubject ...
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+)
+
+// MemberController ...
+type MemberController struct {
+	SprintService     retroServices.SprintService
+	PermissionService retroServices.PermissionService
+}
+
+// Routes for Members
+func (ctrl MemberController) Routes(r *gin.RouterGroup) {
+	r.GET("/:memberID/performance-history/", ctrl.GetPerformanceHistory)
+}
+
+// GetPerformanceHistory returns the sprint wise performance of a member across retrospectives
+func (ctrl MemberController) GetPerformanceHistory(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	memberID, err := strconv.ParseUint(c.Param("memberID"), 10, 32)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid member ID"})
+		return
+	}
+	retroID := c.DefaultQuery("retroID", "")
+	if retroID != "" {
+		if _, err = strconv.ParseUint(retroID, 10, 32); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid retrospective ID"})
+			return
+		}
+	}
+
+	if !ctrl.PermissionService.UserCanAccessMemberHistory(uint(memberID), userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetMemberPerformanceHistory(uint(memberID), retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
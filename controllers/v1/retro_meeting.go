@@ -0,0 +1,358 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/constants"
+)
+
+// RetroMeetingController ...
+type RetroMeetingController struct {
+	RetroMeetingService retroServices.RetroMeetingService
+	PermissionService   retroServices.PermissionService
+	TrailService        retroServices.TrailService
+	SprintEventService  retroServices.SprintEventService
+}
+
+// Routes for Retro Meetings
+func (ctrl RetroMeetingController) Routes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.Get)
+	r.POST("/", ctrl.Start)
+	r.PATCH("/", ctrl.Update)
+	r.POST("/timer/", ctrl.Timer)
+	r.POST("/groups/", ctrl.AddGroup)
+	r.PATCH("/groups/:groupID/", ctrl.UpdateGroup)
+	r.DELETE("/groups/:groupID/", ctrl.DeleteGroup)
+	r.POST("/groups/:groupID/items/", ctrl.AddGroupItem)
+	r.DELETE("/groups/:groupID/items/:feedbackID/", ctrl.RemoveGroupItem)
+	r.POST("/groups/:groupID/votes/", ctrl.Vote)
+	r.DELETE("/groups/:groupID/votes/", ctrl.Unvote)
+	r.POST("/groups/:groupID/goal/", ctrl.ConvertToGoal)
+}
+
+// Get the retro meeting of the sprint
+func (ctrl RetroMeetingController) Get(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.Get(sprintID, userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, meeting)
+}
+
+// Start the retro meeting of the sprint, the user starting it facilitates it
+func (ctrl RetroMeetingController) Start(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.Start(sprintID, userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemCreated, meeting, userID.(uint))
+	c.JSON(http.StatusCreated, meeting)
+}
+
+// Update the phase, the votes per user or the facilitator of the retro meeting
+func (ctrl RetroMeetingController) Update(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	meetingData := retroSerializers.RetroMeetingUpdateSerializer{}
+	if err := c.BindJSON(&meetingData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.Update(sprintID, userID.(uint), &meetingData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// Timer controls the timer of the retro meeting
+func (ctrl RetroMeetingController) Timer(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	timerData := retroSerializers.RetroMeetingTimerSerializer{}
+	if err := c.BindJSON(&timerData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.Timer(sprintID, userID.(uint), &timerData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// AddGroup adds a group of notes and highlights to the retro meeting
+func (ctrl RetroMeetingController) AddGroup(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	groupData := retroSerializers.RetroMeetingGroupSerializer{}
+	if err := c.BindJSON(&groupData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.AddGroup(sprintID, userID.(uint), &groupData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// UpdateGroup renames a group of the retro meeting and optionally replaces its items
+func (ctrl RetroMeetingController) UpdateGroup(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	groupID := c.Param("groupID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	groupData := retroSerializers.RetroMeetingGroupSerializer{}
+	if err := c.BindJSON(&groupData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.UpdateGroup(sprintID, groupID, userID.(uint), &groupData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// DeleteGroup deletes a group of the retro meeting
+func (ctrl RetroMeetingController) DeleteGroup(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	groupID := c.Param("groupID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.DeleteGroup(sprintID, groupID, userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// AddGroupItem moves a note or a highlight into a group of the retro meeting
+func (ctrl RetroMeetingController) AddGroupItem(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	groupID := c.Param("groupID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	itemData := retroSerializers.RetroMeetingGroupItemSerializer{}
+	if err := c.BindJSON(&itemData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.AddGroupItem(sprintID, groupID, userID.(uint), &itemData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// RemoveGroupItem takes a note or a highlight out of a group of the retro meeting
+func (ctrl RetroMeetingController) RemoveGroupItem(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	groupID := c.Param("groupID")
+	feedbackID := c.Param("feedbackID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.RemoveGroupItem(sprintID, groupID, feedbackID, userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// Vote gives one of the user's votes to a group of the retro meeting
+func (ctrl RetroMeetingController) Vote(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	groupID := c.Param("groupID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.Vote(sprintID, groupID, userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// Unvote takes back one of the user's votes from a group of the retro meeting
+func (ctrl RetroMeetingController) Unvote(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	groupID := c.Param("groupID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	meeting, status, err := ctrl.RetroMeetingService.Unvote(sprintID, groupID, userID.(uint))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	c.JSON(status, meeting)
+}
+
+// ConvertToGoal records the outcome of discussing a group of the retro meeting as a goal
+func (ctrl RetroMeetingController) ConvertToGoal(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	groupID := c.Param("groupID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	goalData := retroSerializers.RetroMeetingGoalSerializer{}
+	if err := c.BindJSON(&goalData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	goal, status, err := ctrl.RetroMeetingService.ConvertToGoal(sprintID, retroID, groupID, userID.(uint), &goalData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.AddedGoal,
+		constants.RetrospectiveFeedback,
+		fmt.Sprint(goal.ID),
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemCreated,
+		constants.RetrospectiveFeedback,
+		fmt.Sprint(goal.ID),
+		userID.(uint),
+		goal)
+	if meeting, _, err := ctrl.RetroMeetingService.Get(sprintID, userID.(uint)); err == nil {
+		ctrl.publish(sprintID, retroSerializers.SprintItemUpdated, meeting, userID.(uint))
+	}
+	c.JSON(http.StatusCreated, goal)
+}
+
+// publish lets the others in the meeting know that it has changed, the meeting is not sent along as the votes
+// in it are as seen by the user who changed it
+func (ctrl RetroMeetingController) publish(sprintID string, eventType string,
+	meeting *retroSerializers.RetroMeeting, userID uint) {
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		eventType,
+		constants.RetroMeeting,
+		fmt.Sprint(meeting.ID),
+		userID,
+		nil)
+}
@@ -0,0 +1,30 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+)
+
+// RetroTemplateController ...
+type RetroTemplateController struct {
+	RetroTemplateService retroServices.RetroTemplateService
+}
+
+// Routes for Retro Templates
+func (ctrl RetroTemplateController) Routes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.List)
+}
+
+// List the retro templates a retrospective can pick from
+func (ctrl RetroTemplateController) List(c *gin.Context) {
+	response, status, err := ctrl.RetroTemplateService.List()
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -13,9 +14,10 @@ import (
 
 // RetrospectiveController ...
 type RetrospectiveController struct {
-	RetrospectiveService retrospectiveService.RetrospectiveService
-	PermissionService    retrospectiveService.PermissionService
-	TrailService         retrospectiveService.TrailService
+	RetrospectiveService       retrospectiveService.RetrospectiveService
+	RetrospectiveExportService retrospectiveService.RetrospectiveExportService
+	PermissionService          retrospectiveService.PermissionService
+	TrailService               retrospectiveService.TrailService
 }
 
 // Routes for Retrospective
@@ -26,9 +28,16 @@ func (ctrl RetrospectiveController) Routes(r *gin.RouterGroup) {
 	r.GET("/:retroID/edit-level/", ctrl.GetEditLevels)
 	r.GET("/:retroID/team-members/", ctrl.GetTeamMembers)
 	r.GET("/:retroID/latest-sprint/", ctrl.GetLatestSprint)
+	r.GET("/:retroID/flow-metrics/", ctrl.GetFlowMetrics)
+	r.GET("/:retroID/export/", ctrl.Export)
 	r.POST("/", ctrl.Create)
 }
 
+// ImportRoutes for Retrospective imports
+func (ctrl RetrospectiveController) ImportRoutes(r *gin.RouterGroup) {
+	r.POST("/", ctrl.Import)
+}
+
 // List Retrospectives
 func (ctrl RetrospectiveController) List(c *gin.Context) {
 	userID, _ := c.Get("userID")
@@ -123,6 +132,25 @@ func (ctrl RetrospectiveController) GetLatestSprint(c *gin.Context) {
 	c.JSON(status, sprint)
 }
 
+// GetFlowMetrics returns the cycle time, lead time and time in status of the tasks of the retrospective
+func (ctrl RetrospectiveController) GetFlowMetrics(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	metrics, status, err := ctrl.RetrospectiveService.GetFlowMetrics(retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, metrics)
+}
+
 // Create Retrospective
 func (ctrl RetrospectiveController) Create(c *gin.Context) {
 	userID, _ := c.Get("userID")
@@ -147,7 +175,8 @@ func (ctrl RetrospectiveController) Create(c *gin.Context) {
 		constants.CreatedRetrospective,
 		constants.Retrospective,
 		strconv.Itoa(int(retro.ID)),
-		userID.(uint))
+		userID.(uint),
+		nil)
 
 	c.JSON(status, retro)
 }
@@ -167,6 +196,8 @@ func (ctrl RetrospectiveController) Update(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.Retrospective, strconv.Itoa(int(retrospectiveData.RetroID)))
+
 	retro, status, err := ctrl.RetrospectiveService.Update(userID.(uint), &retrospectiveData)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -177,7 +208,70 @@ func (ctrl RetrospectiveController) Update(c *gin.Context) {
 		constants.UpdatedRetrospective,
 		constants.Retrospective,
 		strconv.Itoa(int(retro.ID)),
-		userID.(uint))
+		userID.(uint),
+		before)
 
 	c.JSON(status, nil)
 }
+
+// Export the whole retrospective as a portable json document
+func (ctrl RetrospectiveController) Export(c *gin.Context) {
+	retroID := c.Param("retroID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.RetrospectiveExportService.Export(retroID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"retrospective-%s.json\"", retroID))
+	c.JSON(status, response)
+}
+
+// Import a retrospective exported from another instance into the given team
+func (ctrl RetrospectiveController) Import(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	teamID, err := strconv.Atoi(c.Query("teamID"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": constants.TeamIDIsMustError})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanCreateOrEditRetro(uint(teamID), userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	exportData := retrospectiveSerializers.RetrospectiveExport{}
+	if err = c.BindJSON(&exportData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	retro, status, err := ctrl.RetrospectiveExportService.Import(uint(teamID), &exportData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.ImportedRetrospective,
+		constants.Retrospective,
+		strconv.Itoa(int(retro.ID)),
+		userID.(uint),
+		nil)
+
+	response, status, err := ctrl.RetrospectiveService.Get(strconv.Itoa(int(retro.ID)), true)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
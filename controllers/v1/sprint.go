@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -13,9 +14,10 @@ import (
 
 // SprintController ...
 type SprintController struct {
-	SprintService     retrospectiveServices.SprintService
-	PermissionService retrospectiveServices.PermissionService
-	TrailService      retrospectiveServices.TrailService
+	SprintService       retrospectiveServices.SprintService
+	SprintReportService retrospectiveServices.SprintReportService
+	PermissionService   retrospectiveServices.PermissionService
+	TrailService        retrospectiveServices.TrailService
 }
 
 // Routes for Sprints
@@ -31,8 +33,18 @@ func (ctrl SprintController) Routes(r *gin.RouterGroup) {
 	r.POST("/:sprintID/process/", ctrl.Process)
 
 	r.GET("/:sprintID/member-summary/", ctrl.GetSprintMemberSummary)
+	r.GET("/:sprintID/epic-summary/", ctrl.GetSprintEpicSummary)
+	r.GET("/:sprintID/flow-metrics/", ctrl.GetFlowMetrics)
+	r.GET("/:sprintID/burndown/", ctrl.GetBurndown)
+	r.GET("/:sprintID/scope-changes/", ctrl.GetScopeChanges)
+	r.GET("/:sprintID/points-allocation/", ctrl.PreviewPointsAllocation)
+	r.POST("/:sprintID/points-allocation/", ctrl.ApplyPointsAllocation)
+	r.GET("/:sprintID/points-allocation/:sprintTaskID/", ctrl.PreviewPointsAllocation)
+	r.POST("/:sprintID/points-allocation/:sprintTaskID/", ctrl.ApplyPointsAllocation)
 
 	r.GET("/:sprintID/process_history/", ctrl.GetTrails)
+
+	r.GET("/:sprintID/export/", ctrl.Export)
 }
 
 // List the sprints accessible to the user
@@ -86,7 +98,8 @@ func (ctrl SprintController) Create(c *gin.Context) {
 		constants.CreatedSprint,
 		constants.Sprint,
 		strconv.Itoa(int(sprint.ID)),
-		userID.(uint))
+		userID.(uint),
+		nil)
 
 	c.JSON(http.StatusCreated, sprint)
 }
@@ -119,6 +132,8 @@ func (ctrl SprintController) Delete(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.Sprint, sprintID)
+
 	status, err := ctrl.SprintService.DeleteSprint(sprintID)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -129,7 +144,8 @@ func (ctrl SprintController) Delete(c *gin.Context) {
 		constants.DeletedSprint,
 		constants.Sprint,
 		sprintID,
-		userID.(uint))
+		userID.(uint),
+		before)
 
 	c.JSON(status, nil)
 }
@@ -149,6 +165,8 @@ func (ctrl SprintController) Update(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.Sprint, sprintID)
+
 	response, status, err := ctrl.SprintService.UpdateSprint(sprintID, userID.(uint), sprintData)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -159,7 +177,8 @@ func (ctrl SprintController) Update(c *gin.Context) {
 		constants.UpdatedSprint,
 		constants.Sprint,
 		sprintID,
-		userID.(uint))
+		userID.(uint),
+		before)
 
 	c.JSON(status, response)
 }
@@ -175,6 +194,8 @@ func (ctrl SprintController) ActivateSprint(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.Sprint, sprintID)
+
 	status, err := ctrl.SprintService.ActivateSprint(sprintID, retroID)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -185,7 +206,8 @@ func (ctrl SprintController) ActivateSprint(c *gin.Context) {
 		constants.ActivatedSprint,
 		constants.Sprint,
 		sprintID,
-		userID.(uint))
+		userID.(uint),
+		before)
 
 	c.JSON(status, nil)
 }
@@ -201,7 +223,9 @@ func (ctrl SprintController) FreezeSprint(c *gin.Context) {
 		return
 	}
 
-	status, err := ctrl.SprintService.FreezeSprint(sprintID, retroID)
+	before := ctrl.TrailService.Snapshot(constants.Sprint, sprintID)
+
+	status, err := ctrl.SprintService.FreezeSprint(sprintID, retroID, userID.(uint))
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
 		return
@@ -211,7 +235,8 @@ func (ctrl SprintController) FreezeSprint(c *gin.Context) {
 		constants.FreezeSprint,
 		constants.Sprint,
 		sprintID,
-		userID.(uint))
+		userID.(uint),
+		before)
 
 	c.JSON(status, nil)
 }
@@ -232,6 +257,8 @@ func (ctrl SprintController) Process(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid sprint"})
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.Sprint, sprintID)
+
 	sprint, _, err := ctrl.SprintService.Get(sprintID, userID.(uint), false)
 
 	if err != nil {
@@ -244,7 +271,8 @@ func (ctrl SprintController) Process(c *gin.Context) {
 		constants.TriggeredSprintRefresh,
 		constants.Sprint,
 		sprintID,
-		userID.(uint))
+		userID.(uint),
+		before)
 
 	c.JSON(http.StatusNoContent, nil)
 }
@@ -269,14 +297,166 @@ func (ctrl SprintController) GetSprintMemberSummary(c *gin.Context) {
 	c.JSON(status, response)
 }
 
+// GetSprintEpicSummary returns the summary of the sprint tasks grouped by their epics
+func (ctrl SprintController) GetSprintEpicSummary(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSprintEpicSummary(sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetFlowMetrics returns the cycle time, lead time and time in status of the sprint tasks during the sprint
+func (ctrl SprintController) GetFlowMetrics(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSprintFlowMetrics(sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetBurndown returns the daily burndown/burnup series of the sprint along with its scope changes
+func (ctrl SprintController) GetBurndown(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSprintBurndown(sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// GetScopeChanges returns the log of tasks added to, removed from and re-estimated in the sprint
+func (ctrl SprintController) GetScopeChanges(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSprintScopeChanges(sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// PreviewPointsAllocation explains the points allocation of the done tasks of the sprint, or of a sprint task,
+// without applying it. The locked points are re-allocated as well with the overrideLocks query param.
+func (ctrl SprintController) PreviewPointsAllocation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	sprintTaskID := c.Param("sprintTaskID")
+
+	var taskID *string
+	if sprintTaskID != "" {
+		if !ctrl.PermissionService.UserCanAccessSprintTask(retroID, sprintID, sprintTaskID, userID.(uint)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+			return
+		}
+		taskID = &sprintTaskID
+	} else if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.PreviewPointsAllocation(sprintID, taskID,
+		c.Query("overrideLocks") == "true")
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// ApplyPointsAllocation applies the points allocation of the done tasks of the sprint, or of a sprint task
+func (ctrl SprintController) ApplyPointsAllocation(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	sprintTaskID := c.Param("sprintTaskID")
+
+	var taskID *string
+	if sprintTaskID != "" {
+		if !ctrl.PermissionService.UserCanEditSprintTask(retroID, sprintID, sprintTaskID, userID.(uint)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+			return
+		}
+		taskID = &sprintTaskID
+	} else if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	var data retroSerializers.PointsAllocationApply
+	if err := c.BindJSON(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.ApplyPointsAllocation(sprintID, taskID, data.OverrideLocks)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.AppliedPointsAllocation,
+		constants.Sprint,
+		sprintID,
+		userID.(uint),
+		nil)
+
+	c.JSON(status, response)
+}
+
 // GetTrails is method to get the all trails related to a particular sprint
 func (ctrl SprintController) GetTrails(c *gin.Context) {
 	sprintID := c.Param("sprintID")
 	retroID := c.Param("retroID")
 	userID, _ := c.Get("userID")
 	sprintIDInt, errConversion := strconv.Atoi(sprintID)
+	retroIDInt, errRetroConversion := strconv.Atoi(retroID)
 
-	if errConversion != nil {
+	if errConversion != nil || errRetroConversion != nil {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
 		return
 	}
@@ -285,7 +465,15 @@ func (ctrl SprintController) GetTrails(c *gin.Context) {
 		return
 	}
 
-	trails, status, err := ctrl.TrailService.GetTrails(uint(sprintIDInt), retroID)
+	filter, err := getTrailFilter(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.SprintID = uint(sprintIDInt)
+	filter.RetrospectiveID = uint(retroIDInt)
+
+	trails, status, err := ctrl.TrailService.GetTrails(filter)
 
 	if err != nil {
 		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
@@ -293,3 +481,25 @@ func (ctrl SprintController) GetTrails(c *gin.Context) {
 	}
 	c.JSON(status, trails)
 }
+
+// Export returns the sprint report as a csv, markdown or pdf file
+func (ctrl SprintController) Export(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	format := retrospectiveServices.SprintReportFormat(c.DefaultQuery("format", "csv"))
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	content, fileName, status, err := ctrl.SprintReportService.Export(retroID, sprintID, userID.(uint), format)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+	c.Data(status, retrospectiveServices.SprintReportContentTypes[format], content)
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	retrospectiveServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// sprintEventHeartbeatInterval keeps the idle event streams from being closed by the proxies in between
+const sprintEventHeartbeatInterval = 30 * time.Second
+
+// SprintEventController ...
+type SprintEventController struct {
+	SprintEventService retrospectiveServices.SprintEventService
+	PermissionService  retrospectiveServices.PermissionService
+}
+
+// Routes for Sprint Events
+func (ctrl SprintEventController) Routes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.Stream)
+}
+
+// Stream the events of the sprint to the user as server sent events till the user disconnects
+func (ctrl SprintEventController) Stream(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	subscription, err := ctrl.SprintEventService.Subscribe(sprintID)
+	if err != nil {
+		utils.LogToSentry(err)
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": "failed to follow the sprint"})
+		return
+	}
+	defer subscription.Close()
+
+	heartbeat := time.NewTicker(sprintEventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	closed := c.Writer.CloseNotify()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-closed:
+			return false
+		case message, open := <-subscription.Messages:
+			if !open {
+				return false
+			}
+			c.SSEvent("message", string(message))
+		case <-heartbeat.C:
+			c.SSEvent("heartbeat", time.Now().Unix())
+		}
+		return true
+	})
+}
@@ -16,6 +16,8 @@ type SprintGoalController struct {
 	RetrospectiveFeedbackService retrospectiveServices.RetrospectiveFeedbackService
 	PermissionService            retrospectiveServices.PermissionService
 	TrailService                 retrospectiveServices.TrailService
+	SprintEventService           retrospectiveServices.SprintEventService
+	GoalCommentService           retrospectiveServices.GoalCommentService
 }
 
 // Routes for Sprints
@@ -26,6 +28,11 @@ func (ctrl SprintGoalController) Routes(r *gin.RouterGroup) {
 	r.DELETE("/:goalID/", ctrl.Delete)
 	r.POST("/:goalID/resolve/", ctrl.Resolve)
 	r.DELETE("/:goalID/resolve/", ctrl.UnResolve)
+	r.POST("/:goalID/ticket/", ctrl.CreateTicket)
+	r.GET("/:goalID/comments/", ctrl.ListComments)
+	r.POST("/:goalID/comments/", ctrl.AddComment)
+	r.PATCH("/:goalID/comments/:commentID/", ctrl.UpdateComment)
+	r.DELETE("/:goalID/comments/:commentID/", ctrl.DeleteComment)
 }
 
 // Add Goal to sprint's retrospective
@@ -33,7 +40,10 @@ func (ctrl SprintGoalController) Add(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	sprintID := c.Param("sprintID")
 	retroID := c.Param("retroID")
-	feedbackData := serializers.RetrospectiveFeedbackCreateSerializer{}
+	feedbackData := serializers.RetrospectiveFeedbackCreateSerializer{
+		RetrospectiveID: retroID,
+		Type:            models.GoalType,
+	}
 
 	if err := c.BindJSON(&feedbackData); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
@@ -66,7 +76,15 @@ func (ctrl SprintGoalController) Add(c *gin.Context) {
 		constants.AddedGoal,
 		constants.RetrospectiveFeedback,
 		fmt.Sprint(response.ID),
-		userID.(uint))
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemCreated,
+		constants.RetrospectiveFeedback,
+		fmt.Sprint(response.ID),
+		userID.(uint),
+		response)
 	c.JSON(status, response)
 }
 
@@ -124,6 +142,8 @@ func (ctrl SprintGoalController) Update(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, goalID)
+
 	response, status, err := ctrl.RetrospectiveFeedbackService.Update(
 		userID.(uint),
 		retroID,
@@ -138,7 +158,15 @@ func (ctrl SprintGoalController) Update(c *gin.Context) {
 		constants.UpdatedGoal,
 		constants.RetrospectiveFeedback,
 		goalID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemUpdated,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
@@ -158,6 +186,9 @@ func (ctrl SprintGoalController) Delete(c *gin.Context) {
 		c.AbortWithStatus(http.StatusForbidden)
 		return
 	}
+
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, goalID)
+
 	status, err := ctrl.RetrospectiveFeedbackService.Delete(goalID)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -166,7 +197,15 @@ func (ctrl SprintGoalController) Delete(c *gin.Context) {
 		constants.DeletedGoal,
 		constants.RetrospectiveFeedback,
 		goalID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemDeleted,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		nil)
 
 	c.JSON(status, nil)
 }
@@ -188,6 +227,8 @@ func (ctrl SprintGoalController) Resolve(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, goalID)
+
 	response, status, err := ctrl.RetrospectiveFeedbackService.Resolve(
 		userID.(uint),
 		sprintID,
@@ -202,7 +243,15 @@ func (ctrl SprintGoalController) Resolve(c *gin.Context) {
 		constants.ResolvedGoal,
 		constants.RetrospectiveFeedback,
 		goalID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemUpdated,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
@@ -224,6 +273,8 @@ func (ctrl SprintGoalController) UnResolve(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, goalID)
+
 	response, status, err := ctrl.RetrospectiveFeedbackService.Resolve(
 		userID.(uint),
 		sprintID,
@@ -239,7 +290,217 @@ func (ctrl SprintGoalController) UnResolve(c *gin.Context) {
 		constants.UnresolvedGoal,
 		constants.RetrospectiveFeedback,
 		goalID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemUpdated,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		response)
+
+	c.JSON(status, response)
+}
+
+// CreateTicket creates a ticket for a goal in the retrospective's task tracker
+func (ctrl SprintGoalController) CreateTicket(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	goalID := c.Param("goalID")
+
+	if !ctrl.PermissionService.CanAccessRetrospectiveFeedback(sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, goalID)
+
+	response, status, err := ctrl.RetrospectiveFeedbackService.CreateTrackerTask(retroID, goalID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.CreatedGoalTicket,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemUpdated,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		response)
+
+	c.JSON(status, response)
+}
+
+// ListComments lists the comment thread of a goal
+func (ctrl SprintGoalController) ListComments(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	goalID := c.Param("goalID")
+
+	if !ctrl.PermissionService.CanAccessRetrospectiveFeedback(sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.GoalCommentService.List(retroID, goalID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// AddComment adds a comment to a goal
+func (ctrl SprintGoalController) AddComment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	goalID := c.Param("goalID")
+
+	commentData := serializers.GoalCommentSerializer{}
+	if err := c.BindJSON(&commentData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	if !ctrl.PermissionService.CanAccessRetrospectiveFeedback(sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.GoalCommentService.Add(userID.(uint), retroID, goalID, &commentData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.CommentedOnGoal,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemCreated,
+		constants.GoalComment,
+		fmt.Sprint(response.ID),
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
+
+// UpdateComment edits a comment on a goal
+func (ctrl SprintGoalController) UpdateComment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	goalID := c.Param("goalID")
+	commentID := c.Param("commentID")
+
+	commentData := serializers.GoalCommentSerializer{}
+	if err := c.BindJSON(&commentData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	if !ctrl.PermissionService.CanAccessRetrospectiveFeedback(sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.GoalCommentService.Update(userID.(uint), retroID, goalID, commentID, &commentData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.UpdatedGoalComment,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemUpdated,
+		constants.GoalComment,
+		commentID,
+		userID.(uint),
+		response)
+
+	c.JSON(status, response)
+}
+
+// DeleteComment deletes a comment on a goal
+func (ctrl SprintGoalController) DeleteComment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	goalID := c.Param("goalID")
+	commentID := c.Param("commentID")
+
+	if !ctrl.PermissionService.CanAccessRetrospectiveFeedback(sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	status, err := ctrl.GoalCommentService.Delete(userID.(uint), retroID, goalID, commentID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.DeletedGoalComment,
+		constants.RetrospectiveFeedback,
+		goalID,
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemDeleted,
+		constants.GoalComment,
+		commentID,
+		userID.(uint),
+		nil)
+
+	c.JSON(status, nil)
+}
@@ -17,6 +17,7 @@ type SprintHighlightController struct {
 	RetrospectiveFeedbackService retrospectiveServices.RetrospectiveFeedbackService
 	PermissionService            retrospectiveServices.PermissionService
 	TrailService                 retrospectiveServices.TrailService
+	SprintEventService           retrospectiveServices.SprintEventService
 }
 
 // Routes for Sprints
@@ -32,7 +33,10 @@ func (ctrl SprintHighlightController) Add(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	sprintID := c.Param("sprintID")
 	retroID := c.Param("retroID")
-	feedbackData := serializers.RetrospectiveFeedbackCreateSerializer{}
+	feedbackData := serializers.RetrospectiveFeedbackCreateSerializer{
+		RetrospectiveID: retroID,
+		Type:            models.HighlightType,
+	}
 
 	if err := c.BindJSON(&feedbackData); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
@@ -64,7 +68,15 @@ func (ctrl SprintHighlightController) Add(c *gin.Context) {
 		constants.AddedHighlight,
 		constants.RetrospectiveFeedback,
 		fmt.Sprint(response.ID),
-		userID.(uint))
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemCreated,
+		constants.RetrospectiveFeedback,
+		fmt.Sprint(response.ID),
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
@@ -121,6 +133,8 @@ func (ctrl SprintHighlightController) Update(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, highlightID)
+
 	response, status, err := ctrl.RetrospectiveFeedbackService.Update(
 		userID.(uint),
 		retroID,
@@ -135,7 +149,15 @@ func (ctrl SprintHighlightController) Update(c *gin.Context) {
 		constants.UpdatedHighlight,
 		constants.RetrospectiveFeedback,
 		highlightID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemUpdated,
+		constants.RetrospectiveFeedback,
+		highlightID,
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
@@ -155,6 +177,9 @@ func (ctrl SprintHighlightController) Delete(c *gin.Context) {
 		c.AbortWithStatus(http.StatusForbidden)
 		return
 	}
+
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, highlightID)
+
 	status, err := ctrl.RetrospectiveFeedbackService.Delete(highlightID)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -163,7 +188,15 @@ func (ctrl SprintHighlightController) Delete(c *gin.Context) {
 		constants.DeletedHighlight,
 		constants.RetrospectiveFeedback,
 		highlightID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemDeleted,
+		constants.RetrospectiveFeedback,
+		highlightID,
+		userID.(uint),
+		nil)
 
 	c.JSON(status, nil)
 }
@@ -13,9 +13,10 @@ import (
 
 // SprintMemberController ...
 type SprintMemberController struct {
-	SprintService     retrospectiveServices.SprintService
-	PermissionService retrospectiveServices.PermissionService
-	TrailService      retrospectiveServices.TrailService
+	SprintService      retrospectiveServices.SprintService
+	PermissionService  retrospectiveServices.PermissionService
+	TrailService       retrospectiveServices.TrailService
+	SprintEventService retrospectiveServices.SprintEventService
 }
 
 // Routes for Sprints
@@ -24,6 +25,10 @@ func (ctrl SprintMemberController) Routes(r *gin.RouterGroup) {
 	r.GET("/", ctrl.GetSprintMemberList)
 	r.PATCH("/:memberID/", ctrl.UpdateSprintMember)
 	r.DELETE("/:memberID/", ctrl.RemoveMember)
+	r.GET("/:memberID/leaves/", ctrl.GetLeaves)
+	r.POST("/:memberID/leaves/", ctrl.AddLeave)
+	r.POST("/:memberID/leaves/import/", ctrl.ImportLeaves)
+	r.DELETE("/:memberID/leaves/:leaveID/", ctrl.DeleteLeave)
 }
 
 // AddMember to a Sprint
@@ -53,7 +58,15 @@ func (ctrl SprintMemberController) AddMember(c *gin.Context) {
 		constants.AddedSprintMember,
 		constants.SprintMember,
 		strconv.Itoa(int(response.ID)),
-		userID.(uint))
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemCreated,
+		constants.SprintMember,
+		strconv.Itoa(int(response.ID)),
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
@@ -90,6 +103,8 @@ func (ctrl SprintMemberController) RemoveMember(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.SprintMember, memberID)
+
 	status, err := ctrl.SprintService.RemoveSprintMember(sprintID, memberID)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -100,7 +115,15 @@ func (ctrl SprintMemberController) RemoveMember(c *gin.Context) {
 		constants.RemovedSprintMember,
 		constants.SprintMember,
 		memberID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemDeleted,
+		constants.SprintMember,
+		memberID,
+		userID.(uint),
+		nil)
 
 	c.JSON(status, nil)
 }
@@ -119,6 +142,8 @@ func (ctrl SprintMemberController) UpdateSprintMember(c *gin.Context) {
 	var memberData retroSerializers.SprintMemberUpdate
 	err := c.BindJSON(&memberData)
 
+	before := ctrl.TrailService.Snapshot(constants.SprintMember, sprintMemberID)
+
 	response, status, err := ctrl.SprintService.UpdateSprintMember(sprintID, sprintMemberID, memberData)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -129,7 +154,159 @@ func (ctrl SprintMemberController) UpdateSprintMember(c *gin.Context) {
 		constants.UpdatedSprintMember,
 		constants.SprintMember,
 		sprintMemberID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintMember,
+		sprintMemberID,
+		userID.(uint),
+		response)
+
+	c.JSON(status, response)
+}
+
+// GetLeaves returns the leaves of a sprint member
+func (ctrl SprintMemberController) GetLeaves(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	sprintMemberID := c.Param("memberID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	response, status, err := ctrl.SprintService.GetSprintMemberLeaves(sprintID, sprintMemberID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, response)
+}
+
+// AddLeave adds a leave for a sprint member
+func (ctrl SprintMemberController) AddLeave(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	sprintMemberID := c.Param("memberID")
+
+	leaveData := retroSerializers.SprintMemberLeaveCreate{}
+	if err := c.BindJSON(&leaveData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	before := ctrl.TrailService.Snapshot(constants.SprintMember, sprintMemberID)
+
+	response, status, err := ctrl.SprintService.AddSprintMemberLeave(sprintID, sprintMemberID, leaveData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.AddedSprintMemberLeave,
+		constants.SprintMember,
+		sprintMemberID,
+		userID.(uint),
+		before)
+	ctrl.publishSprintMemberUpdate(sprintID, sprintMemberID, userID.(uint))
+
+	c.JSON(status, response)
+}
+
+// ImportLeaves imports the leaves of a sprint member from an ICS calendar or a CSV file
+func (ctrl SprintMemberController) ImportLeaves(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	sprintMemberID := c.Param("memberID")
+
+	importData := retroSerializers.SprintMemberLeaveImport{}
+	if err := c.BindJSON(&importData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	before := ctrl.TrailService.Snapshot(constants.SprintMember, sprintMemberID)
+
+	response, status, err := ctrl.SprintService.ImportSprintMemberLeaves(sprintID, sprintMemberID, importData)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(response.Leaves) > 0 {
+		ctrl.TrailService.Add(
+			constants.ImportedSprintMemberLeaves,
+			constants.SprintMember,
+			sprintMemberID,
+			userID.(uint),
+			before)
+		ctrl.publishSprintMemberUpdate(sprintID, sprintMemberID, userID.(uint))
+	}
 
 	c.JSON(status, response)
 }
+
+// DeleteLeave deletes a leave of a sprint member
+func (ctrl SprintMemberController) DeleteLeave(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	sprintMemberID := c.Param("memberID")
+	leaveID := c.Param("leaveID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	before := ctrl.TrailService.Snapshot(constants.SprintMember, sprintMemberID)
+
+	status, err := ctrl.SprintService.DeleteSprintMemberLeave(sprintID, sprintMemberID, leaveID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.DeletedSprintMemberLeave,
+		constants.SprintMember,
+		sprintMemberID,
+		userID.(uint),
+		before)
+	ctrl.publishSprintMemberUpdate(sprintID, sprintMemberID, userID.(uint))
+
+	c.JSON(status, nil)
+}
+
+// publishSprintMemberUpdate publishes the summary of a sprint member whose leaves changed
+func (ctrl SprintMemberController) publishSprintMemberUpdate(sprintID string, sprintMemberID string, userID uint) {
+	summary, _, err := ctrl.SprintService.GetSprintMemberSummary(sprintID, sprintMemberID)
+	if err != nil {
+		return
+	}
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintMember,
+		sprintMemberID,
+		userID,
+		summary)
+}
@@ -17,6 +17,7 @@ type SprintNoteController struct {
 	RetrospectiveFeedbackService retrospectiveServices.RetrospectiveFeedbackService
 	PermissionService            retrospectiveServices.PermissionService
 	TrailService                 retrospectiveServices.TrailService
+	SprintEventService           retrospectiveServices.SprintEventService
 }
 
 // Routes for Sprints
@@ -32,7 +33,10 @@ func (ctrl SprintNoteController) Add(c *gin.Context) {
 	userID, _ := c.Get("userID")
 	sprintID := c.Param("sprintID")
 	retroID := c.Param("retroID")
-	feedbackData := serializers.RetrospectiveFeedbackCreateSerializer{}
+	feedbackData := serializers.RetrospectiveFeedbackCreateSerializer{
+		RetrospectiveID: retroID,
+		Type:            models.NoteType,
+	}
 
 	if err := c.BindJSON(&feedbackData); err != nil {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
@@ -64,7 +68,15 @@ func (ctrl SprintNoteController) Add(c *gin.Context) {
 		constants.AddedNote,
 		constants.RetrospectiveFeedback,
 		fmt.Sprint(response.ID),
-		userID.(uint))
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemCreated,
+		constants.RetrospectiveFeedback,
+		fmt.Sprint(response.ID),
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
@@ -121,6 +133,8 @@ func (ctrl SprintNoteController) Update(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, noteID)
+
 	response, status, err := ctrl.RetrospectiveFeedbackService.Update(
 		userID.(uint),
 		retroID,
@@ -135,7 +149,15 @@ func (ctrl SprintNoteController) Update(c *gin.Context) {
 		constants.UpdatedNote,
 		constants.RetrospectiveFeedback,
 		noteID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemUpdated,
+		constants.RetrospectiveFeedback,
+		noteID,
+		userID.(uint),
+		response)
 
 	c.JSON(status, response)
 }
@@ -155,6 +177,9 @@ func (ctrl SprintNoteController) Delete(c *gin.Context) {
 		c.AbortWithStatus(http.StatusForbidden)
 		return
 	}
+
+	before := ctrl.TrailService.Snapshot(constants.RetrospectiveFeedback, noteID)
+
 	status, err := ctrl.RetrospectiveFeedbackService.Delete(noteID)
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -163,7 +188,15 @@ func (ctrl SprintNoteController) Delete(c *gin.Context) {
 		constants.DeletedNote,
 		constants.RetrospectiveFeedback,
 		noteID,
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		serializers.SprintItemDeleted,
+		constants.RetrospectiveFeedback,
+		noteID,
+		userID.(uint),
+		nil)
 
 	c.JSON(status, nil)
 }
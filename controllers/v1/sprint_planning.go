@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/constants"
+)
+
+// SprintPlanningController ...
+type SprintPlanningController struct {
+	SprintPlanningService retroServices.SprintPlanningService
+	PermissionService     retroServices.PermissionService
+	TrailService          retroServices.TrailService
+	SprintEventService    retroServices.SprintEventService
+}
+
+// Routes for Sprint Planning, the tasks of the plan are assigned to the members through the task member routes
+func (ctrl SprintPlanningController) Routes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.Get)
+	r.GET("/backlog/", ctrl.Backlog)
+	r.POST("/tasks/", ctrl.AddTask)
+	r.PATCH("/tasks/:sprintTaskID/", ctrl.UpdateTask)
+	r.GET("/commitment/", ctrl.Commitment)
+}
+
+// Get the plan of a draft sprint along with the capacity of its members
+func (ctrl SprintPlanningController) Get(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	plan, status, err := ctrl.SprintPlanningService.GetPlan(sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, plan)
+}
+
+// Backlog lists the tickets of the task tracker backlog which can be pulled into the sprint
+func (ctrl SprintPlanningController) Backlog(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	backlog, status, err := ctrl.SprintPlanningService.GetBacklog(sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, backlog)
+}
+
+// AddTask pulls a ticket of the task tracker into the sprint plan
+func (ctrl SprintPlanningController) AddTask(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanEditSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	var data retroSerializers.SprintPlanTaskCreate
+	if err := c.BindJSON(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	task, status, err := ctrl.SprintPlanningService.AddTask(sprintID, data)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.PlannedSprintTask,
+		constants.SprintTask,
+		fmt.Sprint(task.ID),
+		userID.(uint),
+		nil)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemCreated,
+		constants.SprintTask,
+		fmt.Sprint(task.ID),
+		userID.(uint),
+		task)
+	c.JSON(http.StatusCreated, task)
+}
+
+// UpdateTask updates the planned estimate of a task of the sprint plan
+func (ctrl SprintPlanningController) UpdateTask(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+	sprintTaskID := c.Param("sprintTaskID")
+
+	if !ctrl.PermissionService.UserCanEditSprintTask(retroID, sprintID, sprintTaskID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	var data retroSerializers.SprintPlanTaskUpdate
+	if err := c.BindJSON(&data); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	before := ctrl.TrailService.Snapshot(constants.SprintTask, sprintTaskID)
+
+	task, status, err := ctrl.SprintPlanningService.UpdateTask(sprintID, sprintTaskID, data)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.UpdatedPlannedTask,
+		constants.SprintTask,
+		sprintTaskID,
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintTask,
+		sprintTaskID,
+		userID.(uint),
+		task)
+	c.JSON(status, task)
+}
+
+// Commitment returns the scope the sprint was committed to on its activation
+func (ctrl SprintPlanningController) Commitment(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	sprintID := c.Param("sprintID")
+	retroID := c.Param("retroID")
+
+	if !ctrl.PermissionService.UserCanAccessSprint(retroID, sprintID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	commitment, status, err := ctrl.SprintPlanningService.GetCommitment(sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, commitment)
+}
@@ -13,9 +13,10 @@ import (
 
 // SprintTaskController ...
 type SprintTaskController struct {
-	SprintTaskService retroServices.SprintTaskService
-	PermissionService retroServices.PermissionService
-	TrailService      retroServices.TrailService
+	SprintTaskService  retroServices.SprintTaskService
+	PermissionService  retroServices.PermissionService
+	TrailService       retroServices.TrailService
+	SprintEventService retroServices.SprintEventService
 }
 
 // Routes for Tasks
@@ -26,6 +27,7 @@ func (ctrl SprintTaskController) Routes(r *gin.RouterGroup) {
 	r.PATCH("/:sprintTaskID/", ctrl.Update)
 	r.POST("/:sprintTaskID/done/", ctrl.MarkDone)
 	r.DELETE("/:sprintTaskID/done/", ctrl.MarkUndone)
+	r.GET("/:sprintTaskID/flow-metrics/", ctrl.GetFlowMetrics)
 }
 
 // List ...
@@ -70,6 +72,27 @@ func (ctrl SprintTaskController) Get(c *gin.Context) {
 	c.JSON(status, task)
 }
 
+// GetFlowMetrics returns the cycle time, lead time and time in status of the task along with its status transitions
+func (ctrl SprintTaskController) GetFlowMetrics(c *gin.Context) {
+	id := c.Param("sprintTaskID")
+	retroID := c.Param("retroID")
+	sprintID := c.Param("sprintID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanAccessSprintTask(retroID, sprintID, id, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	metrics, status, err := ctrl.SprintTaskService.GetFlowMetrics(id, retroID, sprintID)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(status, metrics)
+}
+
 // Update ...
 func (ctrl SprintTaskController) Update(c *gin.Context) {
 	id := c.Param("sprintTaskID")
@@ -89,6 +112,8 @@ func (ctrl SprintTaskController) Update(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.SprintTask, id)
+
 	task, status, err := ctrl.SprintTaskService.Update(id, retroID, sprintID, data, userID.(uint))
 
 	if err != nil {
@@ -99,7 +124,15 @@ func (ctrl SprintTaskController) Update(c *gin.Context) {
 		constants.UpdatedSprintTask,
 		constants.SprintTask,
 		fmt.Sprint(task.ID),
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintTask,
+		fmt.Sprint(task.ID),
+		userID.(uint),
+		task)
 	c.JSON(status, task)
 }
 
@@ -120,6 +153,9 @@ func (ctrl SprintTaskController) MarkDone(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "failed to mark the issue as done"})
 		return
 	}
+
+	before := ctrl.TrailService.Snapshot(constants.SprintTask, id)
+
 	task, status, err := ctrl.SprintTaskService.MarkDone(id, retroID, sprintID, userID.(uint), data)
 
 	if err != nil {
@@ -130,7 +166,15 @@ func (ctrl SprintTaskController) MarkDone(c *gin.Context) {
 		constants.MarkDoneSprintTask,
 		constants.SprintTask,
 		fmt.Sprint(task.ID),
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintTask,
+		fmt.Sprint(task.ID),
+		userID.(uint),
+		task)
 
 	c.JSON(status, task)
 }
@@ -147,6 +191,8 @@ func (ctrl SprintTaskController) MarkUndone(c *gin.Context) {
 		return
 	}
 
+	before := ctrl.TrailService.Snapshot(constants.SprintTask, id)
+
 	task, status, err := ctrl.SprintTaskService.MarkUndone(id, retroID, sprintID, userID.(uint))
 
 	if err != nil {
@@ -157,7 +203,15 @@ func (ctrl SprintTaskController) MarkUndone(c *gin.Context) {
 		constants.MarkUndoneSprintTask,
 		constants.SprintTask,
 		fmt.Sprint(task.ID),
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintTask,
+		fmt.Sprint(task.ID),
+		userID.(uint),
+		task)
 	c.JSON(status, task)
 }
 
@@ -172,7 +226,10 @@ func (ctrl SprintTaskController) Delete(c *gin.Context) {
 		c.AbortWithStatus(http.StatusForbidden)
 		return
 	}
-	status, err := ctrl.SprintTaskService.Delete(sprintTaskID, retroID, sprintID)
+
+	before := ctrl.TrailService.Snapshot(constants.SprintTask, sprintTaskID)
+
+	status, err := ctrl.SprintTaskService.Delete(sprintTaskID, retroID, sprintID, userID.(uint))
 
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -182,7 +239,15 @@ func (ctrl SprintTaskController) Delete(c *gin.Context) {
 		constants.DeletedSprintTask,
 		constants.SprintTask,
 		fmt.Sprint(sprintTaskID),
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemDeleted,
+		constants.SprintTask,
+		fmt.Sprint(sprintTaskID),
+		userID.(uint),
+		nil)
 
 	c.JSON(status, nil)
 }
@@ -16,6 +16,7 @@ type SprintTaskMemberController struct {
 	SprintTaskMemberService retroServices.SprintTaskMemberService
 	PermissionService       retroServices.PermissionService
 	TrailService            retroServices.TrailService
+	SprintEventService      retroServices.SprintEventService
 }
 
 // Routes for Tasks
@@ -23,6 +24,8 @@ func (ctrl SprintTaskMemberController) Routes(r *gin.RouterGroup) {
 	r.GET("/", ctrl.GetMembers)
 	r.POST("/", ctrl.AddMember)
 	r.PATCH("/:smtID/", ctrl.UpdateTaskMember)
+	r.POST("/:smtID/lock/", ctrl.LockPoints)
+	r.DELETE("/:smtID/lock/", ctrl.UnlockPoints)
 }
 
 // GetMembers ...
@@ -72,11 +75,22 @@ func (ctrl SprintTaskMemberController) AddMember(c *gin.Context) {
 		return
 	}
 
-	ctrl.TrailService.Add(
-		constants.AddedSprintMemberTask,
-		constants.SprintMemberTask,
-		sprintTaskID,
-		userID.(uint))
+	smtID, err := ctrl.SprintTaskMemberService.GetSprintMemberTaskID(sprintTaskID, addTaskMemberData.MemberID)
+	if err == nil {
+		ctrl.TrailService.Add(
+			constants.AddedSprintMemberTask,
+			constants.SprintMemberTask,
+			strconv.Itoa(int(smtID)),
+			userID.(uint),
+			nil)
+		ctrl.SprintEventService.Publish(
+			sprintID,
+			retroSerializers.SprintItemCreated,
+			constants.SprintMemberTask,
+			strconv.Itoa(int(smtID)),
+			userID.(uint),
+			members)
+	}
 
 	c.JSON(status, members)
 }
@@ -100,7 +114,10 @@ func (ctrl SprintTaskMemberController) UpdateTaskMember(c *gin.Context) {
 		return
 	}
 
-	taskMember, status, err := ctrl.SprintTaskMemberService.UpdateTaskMember(sprintTaskID, retroID, sprintID, smtID, &taskMemberData)
+	before := ctrl.TrailService.Snapshot(constants.SprintMemberTask, smtID)
+
+	taskMember, status, err := ctrl.SprintTaskMemberService.UpdateTaskMember(sprintTaskID, retroID, sprintID, smtID, &taskMemberData,
+		userID.(uint))
 
 	if err != nil {
 		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
@@ -111,7 +128,100 @@ func (ctrl SprintTaskMemberController) UpdateTaskMember(c *gin.Context) {
 		constants.UpdatedSprintMemberTask,
 		constants.SprintMemberTask,
 		strconv.Itoa(int(taskMember.ID)),
-		userID.(uint))
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintMemberTask,
+		strconv.Itoa(int(taskMember.ID)),
+		userID.(uint),
+		taskMember)
+
+	c.JSON(status, taskMember)
+}
+
+// LockPoints locks the points of a member for a task against the points allocation
+func (ctrl SprintTaskMemberController) LockPoints(c *gin.Context) {
+	sprintTaskID := c.Param("sprintTaskID")
+	retroID := c.Param("retroID")
+	sprintID := c.Param("sprintID")
+	smtID := c.Param("smtID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanEditSprintTask(retroID, sprintID, sprintTaskID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	lockData := retroSerializers.SprintTaskMemberLock{}
+	if err := c.BindJSON(&lockData); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid request data"})
+		return
+	}
+
+	before := ctrl.TrailService.Snapshot(constants.SprintMemberTask, smtID)
+
+	taskMember, status, err := ctrl.SprintTaskMemberService.LockPoints(sprintTaskID, retroID, sprintID, smtID,
+		lockData.Reason, userID.(uint))
+
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.LockedSprintMemberTaskPoints,
+		constants.SprintMemberTask,
+		smtID,
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintMemberTask,
+		smtID,
+		userID.(uint),
+		taskMember)
+
+	c.JSON(status, taskMember)
+}
+
+// UnlockPoints unlocks the points of a member for a task, the points of the task are re-allocated
+func (ctrl SprintTaskMemberController) UnlockPoints(c *gin.Context) {
+	sprintTaskID := c.Param("sprintTaskID")
+	retroID := c.Param("retroID")
+	sprintID := c.Param("sprintID")
+	smtID := c.Param("smtID")
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.UserCanEditSprintTask(retroID, sprintID, sprintTaskID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	before := ctrl.TrailService.Snapshot(constants.SprintMemberTask, smtID)
+
+	taskMember, status, err := ctrl.SprintTaskMemberService.UnlockPoints(sprintTaskID, retroID, sprintID, smtID)
+
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctrl.TrailService.Add(
+		constants.UnlockedSprintMemberTaskPoints,
+		constants.SprintMemberTask,
+		smtID,
+		userID.(uint),
+		before)
+	ctrl.SprintEventService.Publish(
+		sprintID,
+		retroSerializers.SprintItemUpdated,
+		constants.SprintMemberTask,
+		smtID,
+		userID.(uint),
+		taskMember)
 
 	c.JSON(status, taskMember)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	retrospectiveServices "github.com/iReflect/reflect-app/apps/retrospective/services"
 	taskTrackerServices "github.com/iReflect/reflect-app/apps/tasktracker/services"
 	"github.com/iReflect/reflect-app/constants"
 )
@@ -12,12 +13,14 @@ import (
 //TaskTrackerController ...
 type TaskTrackerController struct {
 	TaskTrackerService taskTrackerServices.TaskTrackerService
+	PermissionService  retrospectiveServices.PermissionService
 }
 
 //Routes for TaskTracker
 func (ctrl TaskTrackerController) Routes(r *gin.RouterGroup) {
 	r.GET("/config-list/", ctrl.ConfigList)
 	r.GET("/supported-time-providers/", ctrl.SupportedTimeTrackersList)
+	r.GET("/sprints/", ctrl.ListSprints)
 }
 
 // ConfigList List task tracker config
@@ -44,3 +47,26 @@ func (ctrl TaskTrackerController) SupportedTimeTrackersList(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, timeTrackers)
 }
+
+// ListSprints lists the sprints of the task tracker of a retrospective, the board (or project) and
+// the comma separated states of the sprints are optional
+func (ctrl TaskTrackerController) ListSprints(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	retroID, exists := c.GetQuery("retroID")
+	if !exists {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": constants.RetroIDIsMustError})
+		return
+	}
+
+	if !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	sprints, status, err := ctrl.TaskTrackerService.ListSprints(retroID, c.Query("board"), c.Query("state"))
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, sprints)
+}
@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	retroSerializers "github.com/iReflect/reflect-app/apps/retrospective/serializers"
+	retrospectiveServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/libs/utils"
+)
+
+// TrailController ...
+type TrailController struct {
+	TrailService      retrospectiveServices.TrailService
+	PermissionService retrospectiveServices.PermissionService
+}
+
+// Routes for the trails of all the retrospectives, accessible only to the admins
+func (ctrl TrailController) Routes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.List)
+}
+
+// RetrospectiveRoutes for the trails of a retrospective
+func (ctrl TrailController) RetrospectiveRoutes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.ListRetrospectiveTrails)
+}
+
+// TeamRoutes for the trails of all the retrospectives of a team
+func (ctrl TrailController) TeamRoutes(r *gin.RouterGroup) {
+	r.GET("/", ctrl.ListTeamTrails)
+}
+
+// List the trails of all the retrospectives
+func (ctrl TrailController) List(c *gin.Context) {
+	userID, _ := c.Get("userID")
+
+	if !ctrl.PermissionService.IsUserAdmin(userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	filter, err := getTrailFilter(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctrl.respond(c, filter, "trails")
+}
+
+// ListRetrospectiveTrails lists the trails of a retrospective
+func (ctrl TrailController) ListRetrospectiveTrails(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	retroID := c.Param("retroID")
+
+	intRetroID, err := strconv.Atoi(retroID)
+	if err != nil || !ctrl.PermissionService.UserCanAccessRetro(retroID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	filter, err := getTrailFilter(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.RetrospectiveID = uint(intRetroID)
+	ctrl.respond(c, filter, fmt.Sprintf("retrospective-%d-trails", intRetroID))
+}
+
+// ListTeamTrails lists the trails of all the retrospectives of a team
+func (ctrl TrailController) ListTeamTrails(c *gin.Context) {
+	userID, _ := c.Get("userID")
+	teamID := c.Param("teamID")
+
+	intTeamID, err := strconv.Atoi(teamID)
+	if err != nil || !ctrl.PermissionService.UserCanManageTeam(teamID, userID.(uint)) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{})
+		return
+	}
+
+	filter, err := getTrailFilter(c)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.TeamID = uint(intTeamID)
+	ctrl.respond(c, filter, fmt.Sprintf("team-%d-trails", intTeamID))
+}
+
+// respond with a page of the trails, or with all of them as a csv file when asked for
+func (ctrl TrailController) respond(c *gin.Context, filter retroSerializers.TrailFilter, fileName string) {
+	if c.Query("format") == "csv" {
+		content, status, err := ctrl.TrailService.ExportTrails(filter)
+		if err != nil {
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName+".csv"))
+		c.Data(status, "text/csv", content)
+		return
+	}
+
+	trails, status, err := ctrl.TrailService.GetTrails(filter)
+	if err != nil {
+		c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(status, trails)
+}
+
+// getTrailFilter reads the trail filters and the pagination from the query params
+func getTrailFilter(c *gin.Context) (filter retroSerializers.TrailFilter, err error) {
+	filter.Action = c.Query("action")
+	filter.ActionItem = c.Query("actionItem")
+
+	if actionBy := c.Query("actionBy"); actionBy != "" {
+		actionByID, err := strconv.Atoi(actionBy)
+		if err != nil || actionByID <= 0 {
+			return filter, errors.New("invalid action by")
+		}
+		filter.ActionByID = uint(actionByID)
+	}
+	if from := c.Query("from"); from != "" {
+		if filter.From, err = utils.ParseDateString(from); err != nil {
+			return filter, errors.New("invalid from date")
+		}
+	}
+	if to := c.Query("to"); to != "" {
+		if filter.To, err = utils.ParseDateString(to); err != nil {
+			return filter, errors.New("invalid to date")
+		}
+	}
+	if after, err := strconv.Atoi(c.Query("after")); err == nil && after > 0 {
+		filter.After = uint(after)
+	}
+
+	filter.Count, err = strconv.Atoi(c.Query("count"))
+	if err != nil || filter.Count <= 0 {
+		filter.Count = 100
+	}
+	return filter, nil
+}
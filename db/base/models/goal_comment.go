@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// GoalComment ...
+type GoalComment struct {
+	gorm.Model
+	Goal        RetrospectiveFeedback
+	GoalID      uint   `gorm:"not null"`
+	Text        string `gorm:"type:text; not null"`
+	CreatedBy   User
+	CreatedByID uint `gorm:"not null"`
+}
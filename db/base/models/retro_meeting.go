@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// RetroMeeting ...
+type RetroMeeting struct {
+	gorm.Model
+	Sprint         Sprint
+	SprintID       uint `gorm:"not null"`
+	Facilitator    User
+	FacilitatorID  uint `gorm:"not null"`
+	Phase          int8 `gorm:"default:0; not null"`
+	VotesPerUser   uint `gorm:"not null; default:3"`
+	TimerDuration  uint `gorm:"not null; default:0"`
+	TimerRemaining uint `gorm:"not null; default:0"`
+	TimerStartedAt *time.Time
+}
+
+// RetroMeetingGroup ...
+type RetroMeetingGroup struct {
+	gorm.Model
+	Meeting   RetroMeeting
+	MeetingID uint   `gorm:"not null"`
+	Title     string `gorm:"type:varchar(255); not null"`
+	Goal      *RetrospectiveFeedback
+	GoalID    *uint
+}
+
+// RetroMeetingGroupItem ...
+type RetroMeetingGroupItem struct {
+	gorm.Model
+	Group      RetroMeetingGroup
+	GroupID    uint `gorm:"not null"`
+	Feedback   RetrospectiveFeedback
+	FeedbackID uint `gorm:"not null"`
+}
+
+// RetroMeetingVote ...
+type RetroMeetingVote struct {
+	gorm.Model
+	Group   RetroMeetingGroup
+	GroupID uint `gorm:"not null"`
+	Voter   User
+	VoterID uint `gorm:"not null"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// RetroTemplate ...
+type RetroTemplate struct {
+	gorm.Model
+	Name        string `gorm:"type:varchar(255); not null"`
+	Description string `gorm:"type:text"`
+}
+
+// RetroTemplateSubType ...
+type RetroTemplateSubType struct {
+	gorm.Model
+	Template   RetroTemplate
+	TemplateID uint   `gorm:"not null"`
+	Type       int8   `gorm:"default:0; not null"`
+	Name       string `gorm:"type:varchar(30); not null"`
+}
@@ -10,6 +10,7 @@ import (
 type Sprint struct {
 	gorm.Model
 	Title            string `gorm:"type:varchar(255); not null"`
+	Number           int    `gorm:"not null; default:0"`
 	SprintID         string `gorm:"type:varchar(30); not null"`
 	Retrospective    Retrospective
 	RetrospectiveID  uint `gorm:"not null"`
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/jinzhu/gorm"
+
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+// SprintCommitment ...
+type SprintCommitment struct {
+	gorm.Model
+	Sprint            Sprint
+	SprintID          uint         `gorm:"not null"`
+	Capacity          float64      `gorm:"not null; default:0"`
+	CommittedEstimate float64      `gorm:"not null; default:0"`
+	PlannedPoints     float64      `gorm:"not null; default:0"`
+	TaskCount         uint         `gorm:"not null; default:0"`
+	Plan              fields.JSONB `gorm:"type:jsonb; not null; default:'{}'::jsonb"`
+}
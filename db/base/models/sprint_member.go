@@ -15,6 +15,7 @@ type SprintMember struct {
 	ExpectationPercent float64 `gorm:"not null;default:100"`
 	Tasks              []SprintMemberTask
 	Vacations          float64 `gorm:"not null;default:0"`
+	UndatedVacations   float64 `gorm:"not null;default:0"`
 	Rating             int8    `gorm:"default:2; not null"`
 	Comment            string  `gorm:"type:text"`
 }
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SprintMemberLeave ...
+type SprintMemberLeave struct {
+	gorm.Model
+	SprintMember   SprintMember
+	SprintMemberID uint      `gorm:"not null"`
+	Date           time.Time `gorm:"type:date; not null"`
+	Type           int8      `gorm:"default:0; not null"`
+	HalfDay        bool      `gorm:"not null; default:false"`
+	Note           string    `gorm:"type:text"`
+}
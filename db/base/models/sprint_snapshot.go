@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// SprintSnapshot ...
+type SprintSnapshot struct {
+	gorm.Model
+	Sprint            Sprint
+	SprintID          uint      `gorm:"not null"`
+	Date              time.Time `gorm:"type:date; not null"`
+	TaskCount         uint      `gorm:"not null; default:0"`
+	DoneTaskCount     uint      `gorm:"not null; default:0"`
+	TotalEstimate     float64   `gorm:"not null; default:0"`
+	DoneEstimate      float64   `gorm:"not null; default:0"`
+	RemainingEstimate float64   `gorm:"not null; default:0"`
+	PointsEarned      float64   `gorm:"not null; default:0"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"github.com/jinzhu/gorm"
+)
+
+// SprintTaskScopeChange ...
+type SprintTaskScopeChange struct {
+	gorm.Model
+	Sprint           Sprint
+	SprintID         uint `gorm:"not null"`
+	Task             Task
+	TaskID           uint    `gorm:"not null"`
+	Type             int8    `gorm:"default:0; not null"`
+	Source           int8    `gorm:"default:0; not null"`
+	PreviousEstimate float64 `gorm:"not null; default:0"`
+	Estimate         float64 `gorm:"not null; default:0"`
+	ChangedBy        *User
+	ChangedByID      *uint
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// TaskStatusTransition ...
+type TaskStatusTransition struct {
+	gorm.Model
+	Task           Task
+	TaskID         uint      `gorm:"not null"`
+	FromStatus     string    `gorm:"type:varchar(50); not null; default:''"`
+	ToStatus       string    `gorm:"type:varchar(50); not null"`
+	TransitionedAt time.Time `gorm:"not null"`
+	TransitionedBy string    `gorm:"type:varchar(100); not null; default:''"`
+}
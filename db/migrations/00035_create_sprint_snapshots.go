@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00035, Down00035)
+}
+
+// Up00035 ...
+func Up00035(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.SprintSnapshot{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintSnapshot{}).
+		AddForeignKey("sprint_id", "sprints(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.SprintSnapshot{}).
+		AddUniqueIndex("unique_sprint_snapshot_date", "sprint_id", "date").Error
+}
+
+// Down00035 ...
+func Down00035(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.DropTable(&models.SprintSnapshot{}).Error
+}
@@ -0,0 +1,75 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00036, Down00036)
+}
+
+// Up00036 ...
+func Up00036(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.SprintTaskScopeChange{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintTaskScopeChange{}).
+		AddForeignKey("sprint_id", "sprints(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintTaskScopeChange{}).
+		AddForeignKey("task_id", "tasks(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintTaskScopeChange{}).
+		AddForeignKey("changed_by_id", "users(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintTaskScopeChange{}).
+		AddIndex("idx_sprint_task_scope_changes_sprint_id", "sprint_id").Error; err != nil {
+		return err
+	}
+
+	// Backfill the log from the existing sprint tasks, the existing tasks were added and removed by the sync
+	// and the users respectively
+	if err = gormDB.Exec(`
+        INSERT INTO sprint_task_scope_changes
+            (created_at, updated_at, sprint_id, task_id, type, source, previous_estimate, estimate)
+        SELECT sprint_tasks.created_at, sprint_tasks.created_at, sprint_tasks.sprint_id, sprint_tasks.task_id,
+            0, 0, 0, tasks.estimate
+        FROM sprint_tasks JOIN tasks ON sprint_tasks.task_id = tasks.id`).Error; err != nil {
+		return err
+	}
+
+	return gormDB.Exec(`
+        INSERT INTO sprint_task_scope_changes
+            (created_at, updated_at, sprint_id, task_id, type, source, previous_estimate, estimate)
+        SELECT sprint_tasks.deleted_at, sprint_tasks.deleted_at, sprint_tasks.sprint_id, sprint_tasks.task_id,
+            1, 1, tasks.estimate, 0
+        FROM sprint_tasks JOIN tasks ON sprint_tasks.task_id = tasks.id
+        WHERE sprint_tasks.deleted_at IS NOT NULL`).Error
+}
+
+// Down00036 ...
+func Down00036(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.DropTable(&models.SprintTaskScopeChange{}).Error
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+	"github.com/iReflect/reflect-app/db/models/fields"
+)
+
+func init() {
+	goose.AddMigration(Up00037, Down00037)
+}
+
+// Up00037 ...
+func Up00037(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type trail struct {
+		Changes fields.JSONB `gorm:"type:jsonb"`
+	}
+
+	return gormDB.AutoMigrate(&trail{}).Error
+}
+
+// Down00037 ...
+func Down00037(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.Trail{}).DropColumn("changes").Error
+}
@@ -0,0 +1,110 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00038, Down00038)
+}
+
+// Up00038 ...
+func Up00038(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.RetroMeeting{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeeting{}).
+		AddForeignKey("sprint_id", "sprints(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeeting{}).
+		AddForeignKey("facilitator_id", "users(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeeting{}).
+		AddUniqueIndex("unique_retro_meeting_sprint", "sprint_id").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.RetroMeetingGroup{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingGroup{}).
+		AddForeignKey("meeting_id", "retro_meetings(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingGroup{}).
+		AddForeignKey("goal_id", "retrospective_feedbacks(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingGroup{}).
+		AddIndex("idx_retro_meeting_groups_meeting_id", "meeting_id").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.RetroMeetingGroupItem{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingGroupItem{}).
+		AddForeignKey("group_id", "retro_meeting_groups(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingGroupItem{}).
+		AddForeignKey("feedback_id", "retrospective_feedbacks(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingGroupItem{}).
+		AddIndex("idx_retro_meeting_group_items_group_id", "group_id").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.RetroMeetingVote{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingVote{}).
+		AddForeignKey("group_id", "retro_meeting_groups(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroMeetingVote{}).
+		AddForeignKey("voter_id", "users(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.RetroMeetingVote{}).
+		AddIndex("idx_retro_meeting_votes_group_id", "group_id").Error
+}
+
+// Down00038 ...
+func Down00038(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.DropTable(
+		&models.RetroMeetingVote{},
+		&models.RetroMeetingGroupItem{},
+		&models.RetroMeetingGroup{},
+		&models.RetroMeeting{}).Error
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00039, Down00039)
+}
+
+// Up00039 ...
+func Up00039(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		AnonymousFeedback bool `gorm:"not null; default:false"`
+	}
+
+	type retrospectiveFeedback struct {
+		Anonymous bool `gorm:"not null; default:false"`
+	}
+
+	return gormDB.AutoMigrate(&retrospective{}, &retrospectiveFeedback{}).Error
+}
+
+// Down00039 ...
+func Down00039(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.Retrospective{}).DropColumn("anonymous_feedback").Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.RetrospectiveFeedback{}).DropColumn("anonymous").Error
+}
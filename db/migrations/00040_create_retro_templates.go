@@ -0,0 +1,104 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00040, Down00040)
+}
+
+// retroTemplates are the retro formats available out of the box, their sub-types are for the highlights
+var retroTemplates = []struct {
+	name        string
+	description string
+	subTypes    []string
+}{
+	{"Start/Stop/Continue", "What should the team start doing, stop doing and keep doing",
+		[]string{"Start", "Stop", "Continue"}},
+	{"4Ls", "What the team liked, learned, lacked and longed for",
+		[]string{"Liked", "Learned", "Lacked", "Longed For"}},
+	{"Mad/Sad/Glad", "What made the team mad, sad and glad",
+		[]string{"Mad", "Sad", "Glad"}},
+	{"Sailboat", "What pushed the team forward, held it back, lies ahead as risks and is the goal",
+		[]string{"Wind", "Anchors", "Rocks", "Island"}},
+}
+
+// Up00040 ...
+func Up00040(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.RetroTemplate{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.RetroTemplateSubType{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroTemplateSubType{}).
+		AddForeignKey("template_id", "retro_templates(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.RetroTemplateSubType{}).
+		AddIndex("idx_retro_template_sub_types_template_id_type", "template_id", "type").Error; err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		TemplateID *uint
+	}
+
+	if err = gormDB.AutoMigrate(&retrospective{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.Retrospective{}).
+		AddForeignKey("template_id", "retro_templates(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	for _, retroTemplate := range retroTemplates {
+		template := models.RetroTemplate{Name: retroTemplate.name, Description: retroTemplate.description}
+		if err = gormDB.Create(&template).Error; err != nil {
+			return err
+		}
+		for _, name := range retroTemplate.subTypes {
+			// 1 is the highlight type of the retrospective feedbacks
+			subType := models.RetroTemplateSubType{TemplateID: template.ID, Type: 1, Name: name}
+			if err = gormDB.Create(&subType).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Down00040 ...
+func Down00040(tx *sql.Tx) error {
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.Retrospective{}).
+		RemoveForeignKey("template_id", "retro_templates(id)").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.Retrospective{}).DropColumn("template_id").Error; err != nil {
+		return err
+	}
+
+	return gormDB.DropTable(&models.RetroTemplateSubType{}, &models.RetroTemplate{}).Error
+}
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00041, Down00041)
+}
+
+// Up00041 ...
+func Up00041(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospectiveFeedback struct {
+		Progress       int8    `gorm:"default:0; not null"`
+		CarryOverCount uint    `gorm:"not null; default:0"`
+		TrackerTaskKey *string `gorm:"type:varchar(255)"`
+	}
+
+	if err = gormDB.AutoMigrate(&retrospectiveFeedback{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.GoalComment{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.GoalComment{}).
+		AddForeignKey("goal_id", "retrospective_feedbacks(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.GoalComment{}).
+		AddForeignKey("created_by_id", "users(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.GoalComment{}).
+		AddIndex("idx_goal_comments_goal_id", "goal_id").Error; err != nil {
+		return err
+	}
+
+	// Count the started sprints (1 - active, 2 - completed) each existing goal has been carried over into
+	return gormDB.Exec(`UPDATE retrospective_feedbacks SET carry_over_count = (
+		SELECT COUNT(*) FROM sprints WHERE sprints.retrospective_id = retrospective_feedbacks.retrospective_id
+		AND sprints.deleted_at IS NULL AND sprints.status IN (1, 2)
+		AND sprints.start_date > retrospective_feedbacks.added_at
+		AND (retrospective_feedbacks.resolved_at IS NULL OR sprints.start_date <= retrospective_feedbacks.resolved_at))
+		WHERE retrospective_feedbacks.type = 2`).Error
+}
+
+// Down00041 ...
+func Down00041(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.DropTable(&models.GoalComment{}).Error; err != nil {
+		return err
+	}
+
+	for _, column := range []string{"progress", "carry_over_count", "tracker_task_key"} {
+		if err = gormDB.Model(&models.RetrospectiveFeedback{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00042, Down00042)
+}
+
+// Up00042 ...
+func Up00042(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospectiveFeedback struct {
+		TrackerTaskStatus string `gorm:"type:varchar(50); not null; default:''"`
+	}
+
+	return gormDB.AutoMigrate(&retrospectiveFeedback{}).Error
+}
+
+// Down00042 ...
+func Down00042(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.RetrospectiveFeedback{}).DropColumn("tracker_task_status").Error
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00043, Down00043)
+}
+
+// Up00043 ...
+func Up00043(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		TrackerWriteBack bool `gorm:"not null; default:false"`
+	}
+
+	type task struct {
+		TrackerUpdatedAt *time.Time
+	}
+
+	return gormDB.AutoMigrate(&retrospective{}, &task{}).Error
+}
+
+// Down00043 ...
+func Down00043(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.Retrospective{}).DropColumn("tracker_write_back").Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.Task{}).DropColumn("tracker_updated_at").Error
+}
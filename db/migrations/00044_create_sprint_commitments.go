@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00044, Down00044)
+}
+
+// Up00044 ...
+func Up00044(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type sprintTask struct {
+		PlannedEstimate *float64
+	}
+
+	type sprintMemberTask struct {
+		PlannedPoints float64 `gorm:"default:0; not null"`
+	}
+
+	if err = gormDB.AutoMigrate(&sprintTask{}, &sprintMemberTask{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.SprintCommitment{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintCommitment{}).
+		AddForeignKey("sprint_id", "sprints(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.SprintCommitment{}).
+		AddUniqueIndex("unique_sprint_commitment", "sprint_id").Error
+}
+
+// Down00044 ...
+func Down00044(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.DropTable(&models.SprintCommitment{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintMemberTask{}).DropColumn("planned_points").Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.SprintTask{}).DropColumn("planned_estimate").Error
+}
@@ -0,0 +1,46 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00045, Down00045)
+}
+
+// Up00045 ...
+func Up00045(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		PointsAllocationStrategy int8    `gorm:"default:0; not null"`
+		ReviewerPointsWeight     float64 `gorm:"not null; default:0.5"`
+	}
+
+	return gormDB.AutoMigrate(&retrospective{}).Error
+}
+
+// Down00045 ...
+func Down00045(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	for _, column := range []string{"points_allocation_strategy", "reviewer_points_weight"} {
+		if err = gormDB.Model(&models.Retrospective{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00046, Down00046)
+}
+
+// Up00046 ...
+func Up00046(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type sprintMemberTask struct {
+		PointsLocked     bool `gorm:"not null; default:false"`
+		PointsLockedByID *uint
+		PointsLockReason string `gorm:"type:text"`
+		PointsLockedAt   *time.Time
+	}
+
+	if err = gormDB.AutoMigrate(&sprintMemberTask{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintMemberTask{}).
+		AddForeignKey("points_locked_by_id", "users(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	// The points which were edited by hand so far are the ones differing from the points assigned
+	return gormDB.Exec(`UPDATE sprint_member_tasks SET points_locked = TRUE, points_locked_at = updated_at
+		WHERE deleted_at IS NULL AND points_earned <> points_assigned`).Error
+}
+
+// Down00046 ...
+func Down00046(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintMemberTask{}).
+		RemoveForeignKey("points_locked_by_id", "users(id)").Error; err != nil {
+		return err
+	}
+
+	for _, column := range []string{"points_locked", "points_locked_by_id", "points_lock_reason", "points_locked_at"} {
+		if err = gormDB.Model(&models.SprintMemberTask{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
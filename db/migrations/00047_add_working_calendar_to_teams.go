@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00047, Down00047)
+}
+
+// Up00047 ...
+func Up00047(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type team struct {
+		Workweek    string  `gorm:"type:varchar(32); not null; default:'Mon,Tue,Wed,Thu,Fri'"`
+		TimeZone    string  `gorm:"type:varchar(64); not null; default:''"`
+		HoursPerDay float64 `gorm:"not null; default:8"`
+	}
+
+	return gormDB.AutoMigrate(&team{}).Error
+}
+
+// Down00047 ...
+func Down00047(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	for _, column := range []string{"workweek", "time_zone", "hours_per_day"} {
+		if err = gormDB.Model(&models.Team{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00048, Down00048)
+}
+
+// Up00048 ...
+func Up00048(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	// The existing vacations of the sprint members have no dates, they are kept as the undated vacations by 00052
+	if err = gormDB.CreateTable(&models.SprintMemberLeave{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.SprintMemberLeave{}).
+		AddForeignKey("sprint_member_id", "sprint_members(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	// The deleted leaves don't keep a member from taking a leave on the same day again
+	return gormDB.Exec(`CREATE UNIQUE INDEX unique_sprint_member_leave_date ON sprint_member_leaves
+		(sprint_member_id, date) WHERE deleted_at IS NULL`).Error
+}
+
+// Down00048 ...
+func Down00048(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.DropTable(&models.SprintMemberLeave{}).Error
+}
@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00049, Down00049)
+}
+
+// Up00049 ...
+func Up00049(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospective struct {
+		SprintLengthDays   int    `gorm:"not null; default:0"`
+		SprintStartWeekday int8   `gorm:"not null; default:1"`
+		SprintTitlePattern string `gorm:"type:varchar(255); not null; default:''"`
+	}
+
+	return gormDB.AutoMigrate(&retrospective{}).Error
+}
+
+// Down00049 ...
+func Down00049(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	for _, column := range []string{"sprint_length_days", "sprint_start_weekday", "sprint_title_pattern"} {
+		if err = gormDB.Model(&models.Retrospective{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
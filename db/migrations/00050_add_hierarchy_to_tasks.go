@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00050, Down00050)
+}
+
+// Up00050 ...
+func Up00050(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type task struct {
+		ParentID              *uint
+		ParentTrackerUniqueID string `gorm:"type:varchar(255); not null; default:''"`
+		EpicKey               string `gorm:"type:varchar(255); not null; default:''"`
+	}
+
+	type retrospective struct {
+		RollUpSubtasks bool `gorm:"not null; default:false"`
+	}
+
+	if err = gormDB.AutoMigrate(&task{}, &retrospective{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.Task{}).
+		AddForeignKey("parent_id", "tasks(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.Task{}).
+		AddIndex("idx_tasks_parent_id", "parent_id").Error
+}
+
+// Down00050 ...
+func Down00050(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.Retrospective{}).DropColumn("roll_up_subtasks").Error; err != nil {
+		return err
+	}
+
+	for _, column := range []string{"parent_id", "parent_tracker_unique_id", "epic_key"} {
+		if err = gormDB.Model(&models.Task{}).DropColumn(column).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
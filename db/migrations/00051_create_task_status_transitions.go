@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00051, Down00051)
+}
+
+// Up00051 ...
+func Up00051(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type task struct {
+		TrackerCreatedAt *time.Time
+	}
+
+	if err = gormDB.AutoMigrate(&task{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.CreateTable(&models.TaskStatusTransition{}).Error; err != nil {
+		return err
+	}
+
+	if err = gormDB.Model(&models.TaskStatusTransition{}).
+		AddForeignKey("task_id", "tasks(id)", "RESTRICT", "RESTRICT").Error; err != nil {
+		return err
+	}
+
+	// The transitions are captured again on each sync, so a transition is stored only once
+	return gormDB.Exec(`CREATE UNIQUE INDEX unique_task_status_transition ON task_status_transitions
+		(task_id, transitioned_at, to_status) WHERE deleted_at IS NULL`).Error
+}
+
+// Down00051 ...
+func Down00051(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	if err = gormDB.DropTable(&models.TaskStatusTransition{}).Error; err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.Task{}).DropColumn("tracker_created_at").Error
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00052, Down00052)
+}
+
+// Up00052 ...
+func Up00052(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type sprintMember struct {
+		UndatedVacations float64 `gorm:"not null;default:0"`
+	}
+
+	if err = gormDB.AutoMigrate(&sprintMember{}).Error; err != nil {
+		return err
+	}
+
+	// The vacations entered before the leaves were dated are kept as the undated vacations
+	return gormDB.Exec(`UPDATE sprint_members SET undated_vacations = GREATEST(sprint_members.vacations - (
+			SELECT COALESCE(SUM(CASE WHEN half_day THEN 0.5 ELSE 1 END), 0) FROM sprint_member_leaves
+			WHERE sprint_member_leaves.deleted_at IS NULL AND sprint_member_leaves.sprint_member_id = sprint_members.id
+		), 0)`).Error
+}
+
+// Down00052 ...
+func Down00052(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.SprintMember{}).DropColumn("undated_vacations").Error
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00053, Down00053)
+}
+
+// Up00053 ...
+func Up00053(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type sprint struct {
+		Number int `gorm:"not null;default:0"`
+	}
+
+	if err = gormDB.AutoMigrate(&sprint{}).Error; err != nil {
+		return err
+	}
+
+	// The existing sprints are numbered in the order they were created, leaving out the deleted ones (status 3)
+	return gormDB.Exec(`UPDATE sprints SET number = numbered_sprints.number
+		FROM (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY retrospective_id ORDER BY id) AS number FROM sprints
+			WHERE deleted_at IS NULL AND status <> 3
+		) AS numbered_sprints
+		WHERE sprints.id = numbered_sprints.id`).Error
+}
+
+// Down00053 ...
+func Down00053(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.Sprint{}).DropColumn("number").Error
+}
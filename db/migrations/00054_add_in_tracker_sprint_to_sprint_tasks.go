@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00054, Down00054)
+}
+
+// Up00054 ...
+func Up00054(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	// The existing sprint tasks are marked by the next sync of their sprints
+	type sprintTask struct {
+		InTrackerSprint bool `gorm:"not null;default:false"`
+	}
+
+	return gormDB.AutoMigrate(&sprintTask{}).Error
+}
+
+// Down00054 ...
+func Down00054(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.SprintTask{}).DropColumn("in_tracker_sprint").Error
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+	"github.com/pressly/goose"
+
+	"github.com/iReflect/reflect-app/db/base/models"
+)
+
+func init() {
+	goose.AddMigration(Up00055, Down00055)
+}
+
+// Up00055 ...
+func Up00055(tx *sql.Tx) error {
+	// This code is executed when the migration is applied.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	type retrospectiveFeedback struct {
+		TrackerTaskPending bool `gorm:"not null;default:false"`
+	}
+
+	return gormDB.AutoMigrate(&retrospectiveFeedback{}).Error
+}
+
+// Down00055 ...
+func Down00055(tx *sql.Tx) error {
+	// This code is executed when the migration is rolled back.
+	gormDB, err := gorm.Open("postgres", interface{}(tx).(gorm.SQLCommon))
+	if err != nil {
+		return err
+	}
+
+	return gormDB.Model(&models.RetrospectiveFeedback{}).DropColumn("tracker_task_pending").Error
+}
@@ -0,0 +1,201 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// A4 page dimensions and margins in points
+const (
+	pageWidth   = 595.0
+	pageHeight  = 842.0
+	pageMargin  = 40.0
+	lineSpacing = 1.4
+)
+
+// Font ...
+type Font string
+
+// Fonts available in the document, all of them are standard Type1 fonts so nothing needs to be embedded
+const (
+	Regular Font = "F1"
+	Bold    Font = "F2"
+	Mono    Font = "F3"
+)
+
+var fontNames = map[Font]string{
+	Regular: "Helvetica",
+	Bold:    "Helvetica-Bold",
+	Mono:    "Courier",
+}
+
+// Approximate average glyph width per font as a fraction of the font size, used for wrapping the text
+var fontWidths = map[Font]float64{
+	Regular: 0.5,
+	Bold:    0.55,
+	Mono:    0.6,
+}
+
+// Document is a minimal, text only, multi page PDF document
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+// New returns an empty document
+func New() *Document {
+	document := new(Document)
+	document.addPage()
+	return document
+}
+
+// Heading writes a bold line of the given size
+func (document *Document) Heading(text string, size float64) {
+	document.write(text, Bold, size)
+}
+
+// Text writes a wrapped paragraph in the regular font
+func (document *Document) Text(text string) {
+	document.write(text, Regular, 10)
+}
+
+// Mono writes a wrapped paragraph in the mono spaced font, useful for tables
+func (document *Document) Mono(text string) {
+	document.write(text, Mono, 8)
+}
+
+// Space adds an empty line
+func (document *Document) Space() {
+	document.moveDown(10 * lineSpacing)
+}
+
+// Bytes renders the document
+func (document *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	beginObject := func() int {
+		offsets = append(offsets, out.Len())
+		id := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", id)
+		return id
+	}
+	endObject := func() {
+		out.WriteString("endobj\n")
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// 1: catalog, 2: pages, 3-5: fonts, followed by a page & content object for each page
+	beginObject()
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\n")
+	endObject()
+
+	pageCount := len(document.pages)
+	kids := make([]string, pageCount)
+	for index := range document.pages {
+		kids[index] = fmt.Sprintf("%d 0 R", 6+index*2)
+	}
+	beginObject()
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\n", strings.Join(kids, " "), pageCount)
+	endObject()
+
+	for _, font := range []Font{Regular, Bold, Mono} {
+		beginObject()
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\n",
+			fontNames[font])
+		endObject()
+	}
+
+	for _, page := range document.pages {
+		pageID := beginObject()
+		fmt.Fprintf(&out,
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R >> >> /Contents %d 0 R >>\n",
+			pageWidth, pageHeight, pageID+1)
+		endObject()
+
+		beginObject()
+		fmt.Fprintf(&out, "<< /Length %d >>\nstream\n", page.Len())
+		out.Write(page.Bytes())
+		out.WriteString("\nendstream\n")
+		endObject()
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	return out.Bytes()
+}
+
+func (document *Document) addPage() {
+	document.pages = append(document.pages, new(bytes.Buffer))
+	document.y = pageHeight - pageMargin
+}
+
+func (document *Document) moveDown(height float64) {
+	document.y -= height
+	if document.y < pageMargin {
+		document.addPage()
+		document.y -= height
+	}
+}
+
+func (document *Document) write(text string, font Font, size float64) {
+	maxChars := int((pageWidth - 2*pageMargin) / (size * fontWidths[font]))
+	for _, line := range strings.Split(text, "\n") {
+		for _, wrappedLine := range wrap(line, maxChars) {
+			document.moveDown(size * lineSpacing)
+			page := document.pages[len(document.pages)-1]
+			fmt.Fprintf(page, "BT /%s %.1f Tf %.1f %.1f Td (%s) Tj ET\n",
+				font, size, pageMargin, document.y, escape(wrappedLine))
+		}
+	}
+}
+
+// wrap splits the line on word boundaries so that no line is longer than maxChars
+func wrap(line string, maxChars int) []string {
+	runes := []rune(line)
+	if len(runes) <= maxChars || maxChars <= 0 {
+		return []string{line}
+	}
+	var lines []string
+	for len(runes) > maxChars {
+		cut := maxChars
+		for index := maxChars; index > maxChars/2; index-- {
+			if runes[index] == ' ' {
+				cut = index
+				break
+			}
+		}
+		lines = append(lines, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(lines, string(runes))
+}
+
+// escape converts the text to a PDF literal string, characters outside Latin-1 are replaced by '?'
+func escape(text string) string {
+	var out bytes.Buffer
+	for _, char := range text {
+		switch {
+		case char == '(' || char == ')' || char == '\\':
+			out.WriteByte('\\')
+			out.WriteByte(byte(char))
+		case char == '\t':
+			out.WriteString("    ")
+		case char < 32:
+			continue
+		case char < 256:
+			out.WriteByte(byte(char))
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
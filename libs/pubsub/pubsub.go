@@ -0,0 +1,85 @@
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/iReflect/reflect-app/config"
+)
+
+// Subscriptions hold their connection for as long as they are open, so the pool is not capped
+var redisPool = &redis.Pool{
+	MaxIdle: 5,
+	Dial: func() (redis.Conn, error) {
+		return redis.Dial("tcp", config.GetConfig().Redis.Address)
+	},
+}
+
+// Publish a message to all the subscribers of the channel, across all the server instances
+func Publish(channel string, message []byte) error {
+	conn := redisPool.Get()
+	defer conn.Close()
+
+	_, err := conn.Do("PUBLISH", channel, message)
+	return err
+}
+
+// Subscription receives the messages published to a channel till it is closed
+type Subscription struct {
+	Messages   <-chan []byte
+	pubSubConn redis.PubSubConn
+	messages   chan []byte
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+// Subscribe to a channel, the subscription must be closed once done with it
+func Subscribe(channel string) (*Subscription, error) {
+	pubSubConn := redis.PubSubConn{Conn: redisPool.Get()}
+	if err := pubSubConn.Subscribe(channel); err != nil {
+		pubSubConn.Close()
+		return nil, err
+	}
+
+	messages := make(chan []byte, 16)
+	subscription := &Subscription{
+		Messages:   messages,
+		pubSubConn: pubSubConn,
+		messages:   messages,
+		done:       make(chan struct{}),
+	}
+	go subscription.receive()
+	return subscription, nil
+}
+
+// receive forwards the published messages till the subscription is closed or the connection fails,
+// it is the only reader of the connection and releases it along with closing Messages when it returns
+func (subscription *Subscription) receive() {
+	defer subscription.pubSubConn.Close()
+	defer close(subscription.messages)
+	for {
+		switch message := subscription.pubSubConn.Receive().(type) {
+		case redis.Message:
+			select {
+			case subscription.messages <- message.Data:
+			case <-subscription.done:
+				return
+			}
+		case redis.Subscription:
+			if message.Count == 0 {
+				return
+			}
+		case error:
+			return
+		}
+	}
+}
+
+// Close the subscription, its connection is released by the receiver once unsubscribed
+func (subscription *Subscription) Close() {
+	subscription.closeOnce.Do(func() {
+		close(subscription.done)
+		subscription.pubSubConn.Unsubscribe()
+	})
+}
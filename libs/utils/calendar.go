@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/iReflect/reflect-app/config"
+)
+
+// DefaultWorkweek is the Monday to Friday working week
+const DefaultWorkweek = "Mon,Tue,Wed,Thu,Fri"
+
+// DefaultHoursPerDay ...
+const DefaultHoursPerDay = 8
+
+var weekdayAbbreviations = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// WorkingCalendar is the working week, the time zone and the working hours per day of a team
+type WorkingCalendar struct {
+	Workweek    map[time.Weekday]bool
+	Location    *time.Location
+	HoursPerDay float64
+}
+
+// NewWorkingCalendar returns the calendar of a comma separated workweek, i.e. "Sun,Mon,Tue,Wed,Thu",
+// the server time zone is used when timeZone is empty
+func NewWorkingCalendar(workweek string, timeZone string, hoursPerDay float64) (*WorkingCalendar, error) {
+	calendar := &WorkingCalendar{Workweek: make(map[time.Weekday]bool), HoursPerDay: hoursPerDay}
+
+	for _, day := range strings.Split(workweek, ",") {
+		weekday, ok := weekdayAbbreviations[strings.ToLower(strings.TrimSpace(day))]
+		if !ok {
+			return nil, fmt.Errorf("invalid workweek day %q", strings.TrimSpace(day))
+		}
+		calendar.Workweek[weekday] = true
+	}
+
+	if hoursPerDay <= 0 || hoursPerDay > 24 {
+		return nil, errors.New("hours per day should be between 0 and 24")
+	}
+
+	if timeZone == "" {
+		timeZone = config.GetConfig().Server.TimeZone
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q", timeZone)
+	}
+	calendar.Location = location
+	return calendar, nil
+}
+
+// GetDefaultWorkingCalendar returns the Monday to Friday calendar in the server time zone
+func GetDefaultWorkingCalendar() WorkingCalendar {
+	calendar, err := NewWorkingCalendar(DefaultWorkweek, "", DefaultHoursPerDay)
+	if err != nil {
+		log.Println("Invalid Timezone: ", err)
+		LogToSentry(err)
+		calendar, _ = NewWorkingCalendar(DefaultWorkweek, "UTC", DefaultHoursPerDay)
+	}
+	return *calendar
+}
+
+// In returns the time in the time zone of the calendar
+func (calendar WorkingCalendar) In(t time.Time) time.Time {
+	if calendar.Location == nil {
+		return t
+	}
+	return t.In(calendar.Location)
+}
+
+// IsWorkingDay ...
+func (calendar WorkingCalendar) IsWorkingDay(t time.Time) bool {
+	return calendar.Workweek[calendar.In(t).Weekday()]
+}
+
+// GetWorkingDaysPerWeek ...
+func (calendar WorkingCalendar) GetWorkingDaysPerWeek() int {
+	return len(calendar.Workweek)
+}
+
+// GetWorkingDaysBetweenTwoDates calculates the working days between two dates, both the dates included
+func (calendar WorkingCalendar) GetWorkingDaysBetweenTwoDates(startDate time.Time, endDate time.Time) int {
+	if endDate.Before(startDate) {
+		return 0
+	}
+
+	workingDays := 0
+	end := GetStartOfDay(calendar.In(endDate))
+	for day := GetStartOfDay(calendar.In(startDate)); !day.After(end); day = day.AddDate(0, 0, 1) {
+		if calendar.Workweek[day.Weekday()] {
+			workingDays++
+		}
+	}
+	return workingDays
+}
+
+// IsSameDay checks if the two times fall on the same day in the time zone of the calendar
+func (calendar WorkingCalendar) IsSameDay(first time.Time, second time.Time) bool {
+	first = calendar.In(first)
+	second = calendar.In(second)
+	return first.Year() == second.Year() && first.YearDay() == second.YearDay()
+}
+
+// CalculateExpectedSP calculates the expected story points of a member, the story points per week are spread
+// over the working days of the week
+func (calendar WorkingCalendar) CalculateExpectedSP(startDate time.Time, endDate time.Time, vacations float64,
+	expectationPercent float64, allocationPercent float64, spPerWeek float64) float64 {
+	if calendar.GetWorkingDaysPerWeek() == 0 {
+		return 0
+	}
+	workingDays := float64(calendar.GetWorkingDaysBetweenTwoDates(startDate, endDate)) - vacations
+	expectationCoefficient := expectationPercent / 100.00
+	allocationCoefficient := allocationPercent / 100.00
+	storyPointPerDay := spPerWeek / float64(calendar.GetWorkingDaysPerWeek())
+	return workingDays * storyPointPerDay * expectationCoefficient * allocationCoefficient
+}
+
+// CalculateCapacityHours calculates the working hours of a member in between two dates
+func (calendar WorkingCalendar) CalculateCapacityHours(startDate time.Time, endDate time.Time, vacations float64,
+	allocationPercent float64) float64 {
+	workingDays := float64(calendar.GetWorkingDaysBetweenTwoDates(startDate, endDate)) - vacations
+	return workingDays * calendar.HoursPerDay * allocationPercent / 100.00
+}
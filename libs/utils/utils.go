@@ -4,10 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"github.com/getsentry/raven-go"
-	"github.com/iReflect/reflect-app/config"
 	"github.com/sirupsen/logrus"
-	"log"
-	"math"
 	"net/url"
 	"strings"
 	"time"
@@ -51,70 +48,12 @@ func UIntInSlice(element uint, slice []uint) bool {
 	return false
 }
 
-// GetWorkingDaysBetweenTwoDates calculates the working days between two dates,
-// i.e., number of days between two dates excluding weekends
-func GetWorkingDaysBetweenTwoDates(startDate time.Time, endDate time.Time) int {
-	serverConf := config.GetConfig().Server
-	location, err := time.LoadLocation(serverConf.TimeZone)
-	if err != nil {
-		log.Println("Invalid Timezone: ", err)
-		LogToSentry(err)
-	}
-
-	if endDate.Before(startDate) {
-		return 0
-	}
-	workingDays := 0
-	start := startDate
-	end := endDate
-
-	if location != nil {
-		start = startDate.In(location)
-		end = endDate.In(location)
-	}
-	start = GetStartOfDay(start)
-	end = GetStartOfDay(end)
-
-	for start.Weekday() != time.Monday && start.Before(end) {
-		if start.Weekday() != time.Sunday && start.Weekday() != time.Saturday {
-			workingDays++
-		}
-		start = start.Add(time.Hour * 24)
-	}
-
-	for end.Weekday() != time.Sunday && end.After(start) {
-		if end.Weekday() != time.Saturday {
-			workingDays++
-		}
-		end = end.Add(-time.Hour * 24)
-	}
-	duration := end.Sub(start)
-	if duration.Hours() > 24 {
-		weeks := int(math.Ceil(duration.Hours() / (24 * 7)))
-		workingDays += weeks * 5
-	} else {
-		workingDays++
-	}
-
-	return workingDays
-}
-
 // GetStartOfDay ...
 func GetStartOfDay(t time.Time) time.Time {
 	year, month, day := t.Date()
 	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
 }
 
-// CalculateExpectedSP ...
-func CalculateExpectedSP(startDate time.Time, endDate time.Time, vacations float64, expectationPercent float64, allocationPercent float64, spPerWeek float64) float64 {
-	sprintWorkingDays := GetWorkingDaysBetweenTwoDates(startDate, endDate)
-	workingDays := float64(sprintWorkingDays) - vacations
-	expectationCoefficient := expectationPercent / 100.00
-	allocationCoefficient := allocationPercent / 100.00
-	storyPointPerDay := spPerWeek / 5
-	return workingDays * storyPointPerDay * expectationCoefficient * allocationCoefficient
-}
-
 // StringSliceToInterfaceSlice ...
 func StringSliceToInterfaceSlice(originalSlice []string) []interface{} {
 	newSlice := make([]interface{}, len(originalSlice))
@@ -36,14 +36,23 @@ func (a *Admin) Router() *http.ServeMux {
 
 	// Retrospective Management
 	retrospectiveModels.RegisterRetrospectiveToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
+	retrospectiveModels.RegisterRetroTemplateToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
 	retrospectiveModels.RegisterTaskToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
 	Admin.AddResource(&retrospectiveModels.TaskKeyMap{}, &admin.Config{Menu: []string{"Retrospective Management"}})
+	Admin.AddResource(&retrospectiveModels.TaskStatusTransition{}, &admin.Config{Menu: []string{"Retrospective Management"}})
 	retrospectiveModels.RegisterSprintToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
 	retrospectiveModels.RegisterSprintSyncStatusToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
+	Admin.AddResource(&retrospectiveModels.SprintSnapshot{}, &admin.Config{Menu: []string{"Retrospective Management"}})
+	Admin.AddResource(&retrospectiveModels.SprintTaskScopeChange{}, &admin.Config{Menu: []string{"Retrospective Management"}})
+	Admin.AddResource(&retrospectiveModels.SprintCommitment{}, &admin.Config{Menu: []string{"Retrospective Management"}})
 	retrospectiveModels.RegisterSprintTaskToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
 	retrospectiveModels.RegisterSprintMemberToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
 	retrospectiveModels.RegisterSprintMemberTaskToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
+	retrospectiveModels.RegisterSprintMemberLeaveToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
 	retrospectiveModels.RegisterRetrospectiveFeedbackToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
+	retrospectiveModels.RegisterGoalCommentToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
+	retrospectiveModels.RegisterRetroMeetingToAdmin(Admin, admin.Config{Menu: []string{"Retrospective Management"}})
+	Admin.AddResource(&retrospectiveModels.RetroMeetingGroup{}, &admin.Config{Menu: []string{"Retrospective Management"}})
 
 	// Retrospective Audit Trails
 	Admin.AddResource(&retrospectiveModels.Trail{}, &admin.Config{Menu: []string{"Retrospective Audit Trail Management"}})
@@ -101,60 +101,115 @@ func (a *App) SetRoutes() {
 
 	permissionService := retrospectiveServices.PermissionService{DB: a.DB}
 	trailService := retrospectiveServices.TrailService{DB: a.DB}
+	sprintEventService := retrospectiveServices.SprintEventService{DB: a.DB}
 	retrospectiveService := retrospectiveServices.RetrospectiveService{DB: a.DB, TeamService: teamService}
 	retrospectiveRoute := v1.Group("retrospectives")
 
-	retrospectiveController := apiControllers.RetrospectiveController{RetrospectiveService: retrospectiveService, PermissionService: permissionService, TrailService: trailService}
+	retrospectiveExportService := retrospectiveServices.RetrospectiveExportService{DB: a.DB}
+	retrospectiveController := apiControllers.RetrospectiveController{
+		RetrospectiveService:       retrospectiveService,
+		RetrospectiveExportService: retrospectiveExportService,
+		PermissionService:          permissionService,
+		TrailService:               trailService}
 	retrospectiveController.Routes(retrospectiveRoute)
+	retrospectiveController.ImportRoutes(v1.Group("retrospective-imports"))
+
+	trailController := apiControllers.TrailController{TrailService: trailService, PermissionService: permissionService}
+	trailController.Routes(v1.Group("trails"))
+	trailController.RetrospectiveRoutes(retrospectiveRoute.Group(":retroID/trails"))
+	trailController.TeamRoutes(teamControllerRoute.Group(":teamID/trails"))
 
 	retrospectiveFeedbackService := retrospectiveServices.RetrospectiveFeedbackService{DB: a.DB}
 
+	taskMemberService := retrospectiveServices.SprintTaskMemberService{DB: a.DB}
+	taskService := retrospectiveServices.SprintTaskService{DB: a.DB}
+
 	sprintRoute := retrospectiveRoute.Group(":retroID/sprints")
 	sprintService := retrospectiveServices.SprintService{DB: a.DB}
-	sprintController := apiControllers.SprintController{SprintService: sprintService, PermissionService: permissionService, TrailService: trailService}
+	sprintReportService := retrospectiveServices.SprintReportService{
+		DB:                           a.DB,
+		SprintService:                sprintService,
+		SprintTaskService:            taskService,
+		RetrospectiveFeedbackService: retrospectiveFeedbackService}
+	sprintController := apiControllers.SprintController{
+		SprintService:       sprintService,
+		SprintReportService: sprintReportService,
+		PermissionService:   permissionService,
+		TrailService:        trailService}
 	sprintController.Routes(sprintRoute)
 
 	sprintMemberRoute := sprintRoute.Group(":sprintID/members")
-	sprintMemberController := apiControllers.SprintMemberController{SprintService: sprintService, PermissionService: permissionService, TrailService: trailService}
+	sprintMemberController := apiControllers.SprintMemberController{SprintService: sprintService, PermissionService: permissionService, TrailService: trailService, SprintEventService: sprintEventService}
 	sprintMemberController.Routes(sprintMemberRoute)
 
+	memberController := apiControllers.MemberController{SprintService: sprintService, PermissionService: permissionService}
+	memberController.Routes(v1.Group("members"))
+
+	retroTemplateController := apiControllers.RetroTemplateController{
+		RetroTemplateService: retrospectiveServices.RetroTemplateService{DB: a.DB}}
+	retroTemplateController.Routes(v1.Group("retro-templates"))
+
 	sprintHighlightRoute := sprintRoute.Group(":sprintID/highlights")
 	sprintHighlightController := apiControllers.SprintHighlightController{
 		RetrospectiveFeedbackService: retrospectiveFeedbackService,
 		PermissionService:            permissionService,
-		TrailService:                 trailService}
+		TrailService:                 trailService,
+		SprintEventService:           sprintEventService}
 	sprintHighlightController.Routes(sprintHighlightRoute)
 
 	sprintGoalRoute := sprintRoute.Group(":sprintID/goals")
 	sprintGoalController := apiControllers.SprintGoalController{
 		RetrospectiveFeedbackService: retrospectiveFeedbackService,
 		PermissionService:            permissionService,
-		TrailService:                 trailService}
+		TrailService:                 trailService,
+		SprintEventService:           sprintEventService,
+		GoalCommentService:           retrospectiveServices.GoalCommentService{DB: a.DB}}
 	sprintGoalController.Routes(sprintGoalRoute)
 
 	sprintNoteRoute := sprintRoute.Group(":sprintID/notes")
 	sprintNoteController := apiControllers.SprintNoteController{
 		RetrospectiveFeedbackService: retrospectiveFeedbackService,
 		PermissionService:            permissionService,
-		TrailService:                 trailService}
+		TrailService:                 trailService,
+		SprintEventService:           sprintEventService}
 	sprintNoteController.Routes(sprintNoteRoute)
 
-	taskMemberService := retrospectiveServices.SprintTaskMemberService{DB: a.DB}
-	taskService := retrospectiveServices.SprintTaskService{DB: a.DB}
 	taskRoute := sprintRoute.Group(":sprintID/tasks")
 	tasksController := apiControllers.SprintTaskController{
-		SprintTaskService: taskService,
-		PermissionService: permissionService,
-		TrailService:      trailService,
+		SprintTaskService:  taskService,
+		PermissionService:  permissionService,
+		TrailService:       trailService,
+		SprintEventService: sprintEventService,
 	}
 	tasksController.Routes(taskRoute)
 
 	taskMemberRoute := sprintRoute.Group(":sprintID/tasks/:sprintTaskID/members")
-	taskMemberController := apiControllers.SprintTaskMemberController{SprintTaskMemberService: taskMemberService, PermissionService: permissionService, TrailService: trailService}
+	taskMemberController := apiControllers.SprintTaskMemberController{SprintTaskMemberService: taskMemberService, PermissionService: permissionService, TrailService: trailService, SprintEventService: sprintEventService}
 	taskMemberController.Routes(taskMemberRoute)
 
+	sprintPlanningController := apiControllers.SprintPlanningController{
+		SprintPlanningService: retrospectiveServices.SprintPlanningService{DB: a.DB},
+		PermissionService:     permissionService,
+		TrailService:          trailService,
+		SprintEventService:    sprintEventService}
+	sprintPlanningController.Routes(sprintRoute.Group(":sprintID/planning"))
+
+	sprintEventController := apiControllers.SprintEventController{
+		SprintEventService: sprintEventService,
+		PermissionService:  permissionService}
+	sprintEventController.Routes(sprintRoute.Group(":sprintID/events"))
+
+	retroMeetingController := apiControllers.RetroMeetingController{
+		RetroMeetingService: retrospectiveServices.RetroMeetingService{DB: a.DB},
+		PermissionService:   permissionService,
+		TrailService:        trailService,
+		SprintEventService:  sprintEventService}
+	retroMeetingController.Routes(sprintRoute.Group(":sprintID/meeting"))
+
 	taskTrackerService := taskTrackerServices.TaskTrackerService{DB: a.DB}
-	taskTrackerController := apiControllers.TaskTrackerController{TaskTrackerService: taskTrackerService}
+	taskTrackerController := apiControllers.TaskTrackerController{
+		TaskTrackerService: taskTrackerService,
+		PermissionService:  permissionService}
 	taskTrackerController.Routes(v1.Group("task-tracker"))
 }
 
@@ -37,6 +37,13 @@ type job struct {
 
 var jobs []job
 
+type periodicJob struct {
+	spec string
+	name string
+}
+
+var periodicJobs []periodicJob
+
 // Initialize ...
 func (w *Workers) Initialize(config *config.Config) {
 	Config = config
@@ -78,9 +85,19 @@ func assignJobs() {
 	for _, job := range jobs {
 		Pool.Job(job.name, job.function)
 	}
+	for _, periodicJob := range periodicJobs {
+		Pool.PeriodicallyEnqueue(periodicJob.spec, periodicJob.name)
+	}
 }
 
 // RegisterJob ...
 func RegisterJob(name string, function func(*work.Job) error) {
 	jobs = append(jobs, job{name: name, function: function})
 }
+
+// RegisterPeriodicJob registers a job which is enqueued as per the given cron spec (with seconds),
+// e.g. "0 0 * * * *" for every hour
+func RegisterPeriodicJob(spec string, name string, function func(*work.Job) error) {
+	RegisterJob(name, function)
+	periodicJobs = append(periodicJobs, periodicJob{spec: spec, name: name})
+}
@@ -0,0 +1,49 @@
+package retrospective
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/gocraft/work"
+
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/constants"
+	"github.com/iReflect/reflect-app/db"
+	"github.com/iReflect/reflect-app/workers"
+)
+
+func init() {
+	workers.RegisterJob("create_next_sprint", CreateNextSprint)
+}
+
+// CreateNextSprint creates the draft sprint following a frozen sprint as per the sprint cadence of the retrospective
+func CreateNextSprint(job *work.Job) error {
+	DB := db.Initialize(workers.Config)
+	sprintService := retroServices.SprintService{DB: DB}
+
+	sprintID := job.ArgString("sprintID")
+	if sprintID == "" {
+		log.Println("Job failed: ", job.Name, " with error: sprintID cannot be blank")
+		return errors.New("sprintID cannot be blank")
+	}
+	userID := uint(job.ArgInt64("userID"))
+
+	sprint, err := sprintService.CreateNextSprint(sprintID, userID)
+	if err != nil {
+		log.Println("Job failed: ", job.Name, " with error: ", err)
+		return err
+	}
+
+	if sprint != nil {
+		retroServices.TrailService{DB: DB}.Add(
+			constants.CreatedSprint,
+			constants.Sprint,
+			strconv.Itoa(int(sprint.ID)),
+			userID,
+			nil)
+	}
+
+	log.Println("Completed job: ", job.Name)
+	return nil
+}
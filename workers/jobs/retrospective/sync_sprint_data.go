@@ -6,6 +6,7 @@ import (
 	"github.com/iReflect/reflect-app/db"
 	"github.com/iReflect/reflect-app/workers"
 	"log"
+	"strconv"
 
 	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
 )
@@ -37,6 +38,13 @@ func SyncSprintData(job *work.Job) error {
 		sprintService.AssignPoints(sprintID, nil)
 	}
 
+	// Keep today's snapshot of the sprint in line with the synced data
+	if intSprintID, err := strconv.Atoi(sprintID); err == nil {
+		if err = sprintService.TakeSprintSnapshot(uint(intSprintID)); err != nil {
+			log.Println("Failed to take snapshot of sprint ", sprintID, ": ", err)
+		}
+	}
+
 	log.Println("Completed job: ", job.Name)
 	return nil
 }
@@ -0,0 +1,32 @@
+package retrospective
+
+import (
+	"log"
+
+	"github.com/gocraft/work"
+
+	retroServices "github.com/iReflect/reflect-app/apps/retrospective/services"
+	"github.com/iReflect/reflect-app/db"
+	"github.com/iReflect/reflect-app/workers"
+)
+
+func init() {
+	// Snapshots are taken every hour so that the snapshot of the day is up to date by the end of the day
+	// in any time zone, each run overwrites the snapshot of the current day
+	workers.RegisterPeriodicJob("0 0 * * * *", "take_sprint_snapshots", TakeSprintSnapshots)
+}
+
+// TakeSprintSnapshots ...
+func TakeSprintSnapshots(job *work.Job) error {
+	sprintService := retroServices.SprintService{DB: db.Initialize(workers.Config)}
+
+	err := sprintService.TakeActiveSprintSnapshots()
+
+	if err != nil {
+		log.Println("Job failed: ", job.Name, " with error: ", err)
+		return err
+	}
+
+	log.Println("Completed job: ", job.Name)
+	return nil
+}